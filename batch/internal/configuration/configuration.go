@@ -14,14 +14,96 @@ type Config struct {
 	Env         string `envconfig:"ENV" default:"dev"`
 	ServiceName string `envconfig:"SERVICE_NAME" default:"cost-explorer"`
 	Slack       struct {
-		DailyWebHookURL  string
-		WeeklyWebHookURL string
+		DailyWebHookURL   string
+		WeeklyWebHookURL  string
+		MonthlyWebHookURL string
+		BotToken          string
+
+		Client           string `envconfig:"CLIENT" default:"webhook"` // Slack への送信方法 (webhook: Incoming Webhook, bot: Bot トークンによる chat.postMessage)
+		DailyChannelID   string `envconfig:"DAILY_CHANNEL_ID"`         // 日次レポートの投稿先チャンネルID (bot の場合のみ利用)
+		WeeklyChannelID  string `envconfig:"WEEKLY_CHANNEL_ID"`        // 週次レポートの投稿先チャンネルID (bot の場合のみ利用)
+		MonthlyChannelID string `envconfig:"MONTHLY_CHANNEL_ID"`       // 月次レポートの投稿先チャンネルID (bot の場合のみ利用)
+	}
+	Teams struct {
+		DailyWebHookURL   string
+		WeeklyWebHookURL  string
+		MonthlyWebHookURL string
+	}
+	Discord struct {
+		DailyWebHookURL   string
+		WeeklyWebHookURL  string
+		MonthlyWebHookURL string
+	}
+	PagerDuty struct {
+		RoutingKey string
+
+		Severity          string  `envconfig:"SEVERITY" default:"critical"` // アラートの重要度 (critical, error, warning, info)
+		DailyCostLimit    float64 `envconfig:"DAILY_COST_LIMIT"`            // 昨日の利用コストの上限 (EXCHANGE_RATES_CURRENCIES の先頭の通貨、0 以下で無効)
+		ForecastCostLimit float64 `envconfig:"FORECAST_COST_LIMIT"`         // 今月の利用コストの予測値の上限 (EXCHANGE_RATES_CURRENCIES の先頭の通貨、0 以下で無効)
+
+		IncidentStoreBackend string `envconfig:"INCIDENT_STORE_BACKEND" default:"file"`               // 未解決のインシデントの保存先 (file: ローカルのファイル、Lambda 上では指定不可, s3: S3)
+		IncidentStoreDir     string `envconfig:"INCIDENT_STORE_DIR" default:"/tmp/pagerduty"`         // file の場合の保存先ディレクトリ
+		IncidentStoreBucket  string `envconfig:"INCIDENT_STORE_BUCKET"`                               // s3 の場合の保存先バケット
+		IncidentStorePrefix  string `envconfig:"INCIDENT_STORE_PREFIX" default:"pagerduty/incidents"` // s3 の場合のオブジェクトキーの接頭辞
+	}
+	Email struct {
+		SMTPUsername string
+		SMTPPassword string
+
+		SMTPHost          string   `envconfig:"SMTP_HOST"`               // SMTP サーバーのホスト名
+		SMTPPort          int      `envconfig:"SMTP_PORT" default:"587"` // SMTP サーバーのポート番号
+		From              string   `envconfig:"FROM"`                    // 送信元のメールアドレス
+		DailyRecipients   []string `envconfig:"DAILY_RECIPIENTS"`        // 日次レポートの宛先 (例: a@example.com,b@example.com)
+		WeeklyRecipients  []string `envconfig:"WEEKLY_RECIPIENTS"`       // 週次レポートの宛先
+		MonthlyRecipients []string `envconfig:"MONTHLY_RECIPIENTS"`      // 月次レポートの宛先
+	}
+	Webhook struct {
+		Secret string
+
+		URLs        []string `envconfig:"URLS"`                     // レポートの JSON を送信する URL (例: https://example.com/hooks/cost,https://example.org/hooks/cost)
+		MaxAttempts int      `envconfig:"MAX_ATTEMPTS" default:"4"` // 送信に失敗した場合に再送を含めて送信する最大の回数
+	}
+	Routing struct {
+		Routes  NotificationRoutes  `envconfig:"ROUTES"`                  // 通知の振り分けルール (未指定の場合は NOTIFIERS の全ての通知先に通知する)
+		Targets NotificationTargets `envconfig:"TARGETS"`                 // 送信先を個別に指定した通知先
+		DryRun  bool                `envconfig:"DRY_RUN" default:"false"` // レポートを送信せずに、振り分け先を標準出力に表示する
 	}
 	ExchangeRates struct {
 		AppID string
+
+		Providers   []string           `envconfig:"PROVIDERS" default:"openexchangerates"` // 為替レートの取得元を試行する順に指定 (openexchangerates, ecb, static)
+		StaticRates map[string]float64 `envconfig:"STATIC_RATES"`                          // static で使用する 1 USD あたりの為替レート (例: JPY:145.5)
+		RateMode    string             `envconfig:"RATE_MODE" default:"daily"`             // 週次・月次レポートの変換に使用する為替レート (latest: 実行時点, daily: 利用日ごと, average: 集計期間の平均)
+		Currencies  []string           `envconfig:"CURRENCIES" default:"JPY"`              // 利用コストを変換して表示する通貨 (ISO 4217 の通貨コード、例: JPY,EUR,USD、先頭の通貨で重要度と利用コストの上限を判定する)
+
+		CacheBackend  string        `envconfig:"CACHE_BACKEND" default:"none"`                // 為替レートのキャッシュの保存先 (none: キャッシュしない, file: ローカルのファイル, s3: S3)
+		CacheTTL      time.Duration `envconfig:"CACHE_TTL" default:"6h"`                      // キャッシュした最新の為替レートの有効期間
+		CacheMaxStale time.Duration `envconfig:"CACHE_MAX_STALE" default:"168h"`              // 取得元で失敗した場合に期限切れのキャッシュを使用する上限 (0 の場合は上限なし)
+		CacheDir      string        `envconfig:"CACHE_DIR" default:"/tmp/exchange_rates"`     // file の場合のキャッシュの保存先ディレクトリ
+		CacheBucket   string        `envconfig:"CACHE_BUCKET"`                                // s3 の場合のキャッシュの保存先バケット
+		CachePrefix   string        `envconfig:"CACHE_PREFIX" default:"exchange_rates/cache"` // s3 の場合のキャッシュのオブジェクトキーの接頭辞
+	} `envconfig:"EXCHANGE_RATES"` // 環境変数の接頭辞 (未指定の場合はフィールド名から EXCHANGERATES_ となる)
+	Filter struct {
+		IncludeRecordTypes []string          `envconfig:"INCLUDE_RECORD_TYPES"` // 集計対象とする料金の種別 (例: Usage,Tax)
+		ExcludeRecordTypes []string          `envconfig:"EXCLUDE_RECORD_TYPES"` // 集計対象から除外する料金の種別 (例: Credit,Refund,Tax,Support)
+		IncludeDimensions  map[string]string `envconfig:"INCLUDE_DIMENSIONS"`   // 集計対象とするディメンションの値、複数の値は "|" で区切る (例: REGION:ap-northeast-1|us-east-1)
+		ExcludeDimensions  map[string]string `envconfig:"EXCLUDE_DIMENSIONS"`   // 集計対象から除外するディメンションの値 (例: SERVICE:Tax)
+		IncludeTags        map[string]string `envconfig:"INCLUDE_TAGS"`         // 集計対象とするタグの値、複数の値は "|" で区切る (例: env:production|staging)
+		ExcludeTags        map[string]string `envconfig:"EXCLUDE_TAGS"`         // 集計対象から除外するタグの値 (例: team:sandbox)
 	}
 	Logging   string `envconfig:"LOGGING" default:"off"`
 	AWSConfig aws.Config
+
+	CostMetric            string            `envconfig:"COST_METRIC" default:"UnblendedCost"`   // 集計する利用コストの指標 (UnblendedCost, AmortizedCost, NetAmortizedCost, BlendedCost, NetUnblendedCost)
+	CostCurrency          string            `envconfig:"COST_CURRENCY" default:"USD"`           // Cost Explorer から取得する利用コストの通貨 (AWS アカウントの請求通貨、USD 以外の場合は為替レートをクロスレートで算出する)
+	AccountNames          map[string]string `envconfig:"ACCOUNT_NAMES"`                         // 連結アカウントIDとアカウント名の対応 (例: 123456789012:production,210987654321:staging)
+	CostAllocationTagKeys []string          `envconfig:"COST_ALLOCATION_TAG_KEYS"`              // タグ別の内訳を集計するコスト配分タグのキー (例: team,project)
+	ForecastMode          string            `envconfig:"FORECAST_MODE" default:"api"`           // 今月の利用コストの予測方法 (api: GetCostForecast を利用, linear: 日割りによる推定)
+	QueryConcurrency      int               `envconfig:"QUERY_CONCURRENCY" default:"4"`         // 1つのジョブ内で同時に実行する問い合わせ数の上限
+	Notifiers             []string          `envconfig:"NOTIFIERS" default:"slack"`             // レポートの通知先 (slack, teams, discord, email, webhook, pagerduty)
+	NotifierCurrencies    map[string]string `envconfig:"NOTIFIER_CURRENCIES"`                   // NOTIFIERS の通知先ごとに表示する通貨、複数の通貨は "|" で区切る (例: slack:JPY|USD,email:EUR、未指定の場合は EXCHANGE_RATES_CURRENCIES の先頭)
+	SlackMessageFormat    string            `envconfig:"SLACK_MESSAGE_FORMAT" default:"blocks"` // Slack に送信するメッセージの形式 (blocks: Block Kit, attachment: 従来の添付ファイル形式)
+	AlertThresholdPercent float64           `envconfig:"ALERT_THRESHOLD_PERCENT" default:"120"` // 比較対象のコストに対する割合（%）がこの値以上の場合に強調表示する (0 以下で無効)
 }
 
 func Get() Config {
@@ -29,7 +111,9 @@ func Get() Config {
 }
 
 func Load(ctx context.Context) (Config, error) {
-	envconfig.MustProcess("", &globalConfig)
+	if err := loadEnv(&globalConfig); err != nil {
+		return globalConfig, err
+	}
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
@@ -42,3 +126,8 @@ func Load(ctx context.Context) (Config, error) {
 
 	return globalConfig, nil
 }
+
+// loadEnv: 環境変数から設定値を読み込む
+func loadEnv(cfg *Config) error {
+	return envconfig.Process("", cfg)
+}
@@ -0,0 +1,65 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadEnv(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		check func(t *testing.T, cfg Config)
+	}{
+		{
+			name: "正常系: EXCHANGE_RATES_PROVIDERS から為替レートの取得元を読み込むこと",
+			env:  map[string]string{"EXCHANGE_RATES_PROVIDERS": "openexchangerates,ecb"},
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, []string{"openexchangerates", "ecb"}, cfg.ExchangeRates.Providers)
+			},
+		},
+		{
+			name: "正常系: EXCHANGE_RATES_RATE_MODE から週次・月次レポートの変換に使用する為替レートを読み込むこと",
+			env:  map[string]string{"EXCHANGE_RATES_RATE_MODE": "average"},
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, "average", cfg.ExchangeRates.RateMode)
+			},
+		},
+		{
+			name: "正常系: EXCHANGE_RATES_CACHE_BACKEND と EXCHANGE_RATES_CACHE_BUCKET からキャッシュの保存先を読み込むこと",
+			env:  map[string]string{"EXCHANGE_RATES_CACHE_BACKEND": "s3", "EXCHANGE_RATES_CACHE_BUCKET": "exchange-rates-cache"},
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, "s3", cfg.ExchangeRates.CacheBackend)
+				assert.Equal(t, "exchange-rates-cache", cfg.ExchangeRates.CacheBucket)
+			},
+		},
+		{
+			name: "正常系: EXCHANGE_RATES_CURRENCIES から表示する通貨を読み込むこと",
+			env:  map[string]string{"EXCHANGE_RATES_CURRENCIES": "EUR,JPY", "NOTIFIER_CURRENCIES": "slack:JPY|USD"},
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, []string{"EUR", "JPY"}, cfg.ExchangeRates.Currencies)
+				assert.Equal(t, map[string]string{"slack": "JPY|USD"}, cfg.NotifierCurrencies)
+			},
+		},
+		{
+			name: "正常系: 未指定の場合は為替レートの取得元の既定値を使用すること",
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, []string{"openexchangerates"}, cfg.ExchangeRates.Providers)
+				assert.Equal(t, []string{"JPY"}, cfg.ExchangeRates.Currencies)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			var cfg Config
+			assert.NoError(t, loadEnv(&cfg))
+			tt.check(t, cfg)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package configuration
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// NotificationRoute: 通知の振り分けルールの設定値
+//
+// 条件を指定しない項目は全ての値に一致する
+type NotificationRoute struct {
+	Name       string   `json:"name"`
+	Reports    []string `json:"reports"`    // レポートの種類 (daily, weekly, monthly)
+	Envs       []string `json:"envs"`       // 実行環境 (例: dev, prd)
+	Severities []string `json:"severities"` // レポートの重要度 (info, warn, critical、pagerduty を通知先とするルールには指定できない)
+	Accounts   []string `json:"accounts"`   // 連結アカウントのIDまたはアカウント名
+	Tags       []string `json:"tags"`       // コスト配分タグ (例: team:platform)
+	Targets    []string `json:"targets"`    // 通知先の名前 (NOTIFIERS に指定した通知先、または ROUTING_TARGETS で定義した通知先)
+}
+
+// NotificationRoutes: 通知の振り分けルールの一覧
+//
+// 環境変数には JSON の配列で指定する
+//
+// 例: [{"name":"critical","severities":["critical"],"targets":["slack","pagerduty"]},{"name":"default","targets":["slack"]}]
+type NotificationRoutes []NotificationRoute
+
+// Decode: 環境変数の JSON を振り分けルールの一覧に変換 (envconfig.Decoder の実装)
+func (nr *NotificationRoutes) Decode(value string) error {
+	if err := json.Unmarshal([]byte(value), nr); err != nil {
+		return fmt.Errorf("failed to decode notification routes: %w", err)
+	}
+	return nil
+}
+
+// NotificationTarget: 送信先を個別に指定した通知先の設定値
+//
+// Destination はレポートの種類ごとの送信先の代わりに使用する
+// (slack: bot の場合はチャンネルID、webhook の場合は Webhook URL、teams/discord/webhook: URL、email: "," 区切りの宛先)
+type NotificationTarget struct {
+	Name        string `json:"name"`
+	Notifier    string `json:"notifier"`
+	Destination string `json:"destination"`
+
+	Currencies []string `json:"currencies"` // 表示する通貨 (未指定の場合は EXCHANGE_RATES_CURRENCIES の先頭、指定した通貨ごとにレポートを通知する)
+}
+
+// NotificationTargets: 送信先を個別に指定した通知先の一覧
+//
+// 環境変数には JSON の配列で指定する
+//
+// 例: [{"name":"platform-slack","notifier":"slack","destination":"C0123456789"},{"name":"eu-email","notifier":"email","destination":"eu@example.com","currencies":["EUR"]}]
+type NotificationTargets []NotificationTarget
+
+// Decode: 環境変数の JSON を通知先の一覧に変換 (envconfig.Decoder の実装)
+func (nt *NotificationTargets) Decode(value string) error {
+	if err := json.Unmarshal([]byte(value), nt); err != nil {
+		return fmt.Errorf("failed to decode notification targets: %w", err)
+	}
+	return nil
+}
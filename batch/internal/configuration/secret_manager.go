@@ -17,6 +17,18 @@ func loadSecrets(ctx context.Context, cfg Config) error {
 	case "test":
 		globalConfig.Slack.DailyWebHookURL = "test_slack_daily_webhook_url"
 		globalConfig.Slack.WeeklyWebHookURL = "test_slack_weekly_webhook_url"
+		globalConfig.Slack.MonthlyWebHookURL = "test_slack_monthly_webhook_url"
+		globalConfig.Slack.BotToken = "test_slack_bot_token"
+		globalConfig.Teams.DailyWebHookURL = "test_teams_daily_webhook_url"
+		globalConfig.Teams.WeeklyWebHookURL = "test_teams_weekly_webhook_url"
+		globalConfig.Teams.MonthlyWebHookURL = "test_teams_monthly_webhook_url"
+		globalConfig.Discord.DailyWebHookURL = "test_discord_daily_webhook_url"
+		globalConfig.Discord.WeeklyWebHookURL = "test_discord_weekly_webhook_url"
+		globalConfig.Discord.MonthlyWebHookURL = "test_discord_monthly_webhook_url"
+		globalConfig.Email.SMTPUsername = "test_smtp_username"
+		globalConfig.Email.SMTPPassword = "test_smtp_password"
+		globalConfig.Webhook.Secret = "test_webhook_secret"
+		globalConfig.PagerDuty.RoutingKey = "test_pagerduty_routing_key"
 		globalConfig.ExchangeRates.AppID = "test_app_id"
 		return nil
 
@@ -34,6 +46,11 @@ func loadSecrets(ctx context.Context, cfg Config) error {
 					return err
 				}
 
+			case cfg.genSecretID(notifierConfig.String()):
+				if err := parseAndSetNotifierConfig(secret.SecretString); err != nil {
+					return err
+				}
+
 			case cfg.genSecretID(exchangeRatesAppID.String()):
 				globalConfig.ExchangeRates.AppID = *secret.SecretString
 
@@ -60,6 +77,7 @@ type secretName string
 const (
 	exchangeRatesAppID secretName = "exchange-rates/app-id"
 	slackConfig        secretName = "slack/config"
+	notifierConfig     secretName = "notifier/config"
 )
 
 // String: シークレット名の共通の型を文字列型に変換
@@ -72,6 +90,7 @@ func (cfg Config) newSecretIDList() []string {
 	return []string{
 		cfg.genSecretID(exchangeRatesAppID.String()),
 		cfg.genSecretID(slackConfig.String()),
+		cfg.genSecretID(notifierConfig.String()),
 	}
 }
 
@@ -98,8 +117,10 @@ func (cfg Config) getFromSecretsManager(ctx context.Context, secretIDList []stri
 // parseAndSetSlackConfig: slack config はjson型で登録しているため、予め定義した構造体にマッピングする
 func parseAndSetSlackConfig(secretString *string) error {
 	var slackConfig struct {
-		DailyWebHookURL  string `json:"daily_webhook_url"`
-		WeeklyWebHookURL string `json:"weekly_webhook_url"`
+		DailyWebHookURL   string `json:"daily_webhook_url"`
+		WeeklyWebHookURL  string `json:"weekly_webhook_url"`
+		MonthlyWebHookURL string `json:"monthly_webhook_url"`
+		BotToken          string `json:"bot_token"`
 	}
 
 	if err := json.Unmarshal([]byte(*secretString), &slackConfig); err != nil {
@@ -108,6 +129,41 @@ func parseAndSetSlackConfig(secretString *string) error {
 
 	globalConfig.Slack.DailyWebHookURL = slackConfig.DailyWebHookURL
 	globalConfig.Slack.WeeklyWebHookURL = slackConfig.WeeklyWebHookURL
+	globalConfig.Slack.MonthlyWebHookURL = slackConfig.MonthlyWebHookURL
+	globalConfig.Slack.BotToken = slackConfig.BotToken
+
+	return nil
+}
+
+// parseAndSetNotifierConfig: Slack 以外の通知先の設定はjson型で登録しているため、予め定義した構造体にマッピングする
+func parseAndSetNotifierConfig(secretString *string) error {
+	var notifierConfig struct {
+		TeamsDailyWebHookURL     string `json:"teams_daily_webhook_url"`
+		TeamsWeeklyWebHookURL    string `json:"teams_weekly_webhook_url"`
+		TeamsMonthlyWebHookURL   string `json:"teams_monthly_webhook_url"`
+		DiscordDailyWebHookURL   string `json:"discord_daily_webhook_url"`
+		DiscordWeeklyWebHookURL  string `json:"discord_weekly_webhook_url"`
+		DiscordMonthlyWebHookURL string `json:"discord_monthly_webhook_url"`
+		SMTPUsername             string `json:"smtp_username"`
+		SMTPPassword             string `json:"smtp_password"`
+		WebhookSecret            string `json:"webhook_secret"`
+		PagerDutyRoutingKey      string `json:"pagerduty_routing_key"`
+	}
+
+	if err := json.Unmarshal([]byte(*secretString), &notifierConfig); err != nil {
+		return fmt.Errorf("failed to parse notifier config: %w", err)
+	}
+
+	globalConfig.Teams.DailyWebHookURL = notifierConfig.TeamsDailyWebHookURL
+	globalConfig.Teams.WeeklyWebHookURL = notifierConfig.TeamsWeeklyWebHookURL
+	globalConfig.Teams.MonthlyWebHookURL = notifierConfig.TeamsMonthlyWebHookURL
+	globalConfig.Discord.DailyWebHookURL = notifierConfig.DiscordDailyWebHookURL
+	globalConfig.Discord.WeeklyWebHookURL = notifierConfig.DiscordWeeklyWebHookURL
+	globalConfig.Discord.MonthlyWebHookURL = notifierConfig.DiscordMonthlyWebHookURL
+	globalConfig.Email.SMTPUsername = notifierConfig.SMTPUsername
+	globalConfig.Email.SMTPPassword = notifierConfig.SMTPPassword
+	globalConfig.Webhook.Secret = notifierConfig.WebhookSecret
+	globalConfig.PagerDuty.RoutingKey = notifierConfig.PagerDutyRoutingKey
 
 	return nil
 }
@@ -26,7 +26,10 @@ func JobHandler(job usecase.Job) Job {
 			}
 
 		case "monthlyCostReport":
-			slog.InfoContext(ctx, "monthlyCostReport job  is not yet implemented", slog.String("type", event.Type))
+			if err := job.MonthlyCostReport(ctx); err != nil {
+				slog.ErrorContext(ctx, "monthlyCostReport job failed", slog.String("error", err.Error()))
+				return err
+			}
 
 		default:
 			slog.DebugContext(ctx, "skip to process", slog.String("type:", event.Type))
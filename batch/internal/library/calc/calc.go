@@ -9,13 +9,20 @@ import (
 //
 // 負の値が入力された場合はエラーを返す
 func RoundUpToTwoDecimalPlaces(value float64) (float64, error) {
+	return RoundUpToDecimalPlaces(value, 2)
+}
+
+// RoundUpToDecimalPlaces: float64 の値を小数点以下の指定した桁数で切り上げる (例: JPY は 0 桁、KWD は 3 桁)
+//
+// 負の値が入力された場合はエラーを返す
+func RoundUpToDecimalPlaces(value float64, places int) (float64, error) {
 	// 負の値が入力された場合はエラーを返す
 	if value < 0 {
 		return 0, errors.New("negative values are not allowed")
 	}
 
-	// 小数点以下2桁で切り上げる
-	factor := math.Pow(10, 2)
+	// 小数点以下を指定した桁数で切り上げる
+	factor := math.Pow(10, float64(places))
 	result := math.Ceil(value*factor) / factor
 
 	return result, nil
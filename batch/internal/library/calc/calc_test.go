@@ -62,3 +62,42 @@ func TestRoundUpToTwoDecimalPlaces(t *testing.T) {
 		})
 	}
 }
+
+func TestRoundUpToDecimalPlaces(t *testing.T) {
+
+	tests := map[string]struct {
+		input    float64
+		places   int
+		expected float64
+		err      error
+	}{
+		"小数点以下0桁の場合は整数に切り上げる": {
+			input:    123.01,
+			places:   0,
+			expected: 124,
+			err:      nil,
+		},
+		"小数点以下3桁の場合は4桁目を切り上げる": {
+			input:    1.2341,
+			places:   3,
+			expected: 1.235,
+			err:      nil,
+		},
+		"負の数はエラーを返す": {
+			input:    -1.5,
+			places:   3,
+			expected: 0,
+			err:      errors.New("negative values are not allowed"),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := calc.RoundUpToDecimalPlaces(tt.input, tt.places)
+			assert.Equal(t, result, tt.expected)
+			if tt.err != nil {
+				assert.Equal(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -11,11 +11,12 @@ import (
 // NOTE: debug用途のログ
 func FormatDateForDailyReportLogs(ctx context.Context, fd service.DailyReportDateFormatter) {
 	slog.InfoContext(ctx, "[1]. formatted date",
-		slog.String("昨日の日付", fd.Yesterday),   // 2024-12-28
-		slog.String("今月の開始日付", fd.StartDate), // 2024-12-01
-		slog.String("今月の終了日付", fd.EndDate),   // 2024-12-29
-		slog.Int("今日までの日数", fd.CurrentDay),   // 29
-		slog.Int("今月の総日数", fd.DaysInMonth),   // 31
+		slog.String("昨日の日付", fd.Yesterday),            // 2024-12-28
+		slog.String("今月の開始日付", fd.StartDate),          // 2024-12-01
+		slog.String("今月の終了日付", fd.EndDate),            // 2024-12-29
+		slog.Int("今日までの日数", fd.CurrentDay),            // 29
+		slog.Int("今月の総日数", fd.DaysInMonth),            // 31
+		slog.String("来月の開始日付", fd.NextMonthStartDate), // 2025-01-01
 	)
 }
 
@@ -28,6 +29,15 @@ func FormatDateForWeeklyReportLogs(ctx context.Context, wd service.WeeklyReportD
 	)
 }
 
+func FormatDateForMonthlyReportLogs(ctx context.Context, md service.MonthlyReportDateFormatter) {
+	slog.InfoContext(ctx, "[1] formatted date",
+		slog.String("先月の開始日付", md.LastMonthStartDate),        // 2024-11-01
+		slog.String("先月の終了日付", md.LastMonthEndDate),          // 2024-12-01
+		slog.String("先々月の開始日付", md.MonthBeforeLastStartDate), // 2024-10-01
+		slog.String("先々月の終了日付", md.MonthBeforeLastEndDate),   // 2024-11-01
+	)
+}
+
 func DailyUsageCostLogs(ctx context.Context, yesterdayCost, actualCost, forecastCost float64) {
 	slog.InfoContext(ctx, "[2] get daily cost usage",
 		slog.Float64("yesterday", yesterdayCost), // 0.0217344233
@@ -44,23 +54,68 @@ func WeeklyUsageCostLogs(ctx context.Context, lastWeekCost, weekBeforeLastCost,
 	)
 }
 
+func DailyForecastLogs(ctx context.Context, forecast service.Forecast) {
+	slog.InfoContext(ctx, "[2] get daily forecast",
+		slog.String("mode", forecast.Mode.String()),      // api
+		slog.Float64("forecast", forecast.Cost),          // 0.78
+		slog.Float64("lower bound", forecast.LowerBound), // 0.74
+		slog.Float64("upper bound", forecast.UpperBound), // 0.83
+	)
+}
+
+func DailyServiceCostLogs(ctx context.Context, yesterdayServiceCosts, actualServiceCosts []service.ServiceCost) {
+	slog.InfoContext(ctx, "[2] get daily service cost usage",
+		serviceCostsGroup("yesterday", yesterdayServiceCosts), // {"Amazon RDS": 0.0153, "AWS Lambda": 0.0021, ...}
+		serviceCostsGroup("actual", actualServiceCosts),       // {"Amazon RDS": 0.5012, "AWS Lambda": 0.0712, ...}
+	)
+}
+
+func MonthlyUsageCostLogs(ctx context.Context, lastMonthCost, monthBeforeLastCost, percentageChange float64, topServices []service.ServiceCost) {
+	slog.InfoContext(ctx, "[2] get monthly usage cost",
+		slog.Float64("last month cost", lastMonthCost),              // 0.8472061342
+		slog.Float64("month before last cost", monthBeforeLastCost), // 0.9034710277
+		slog.Float64("percentage change", percentageChange),         // 93.77237402467355
+		serviceCostsGroup("top services", topServices),
+	)
+}
+
 func ExchangeRatesResponseLogs(ctx context.Context, r *exchange_rates.ExchangeRatesResponse) {
 	slog.InfoContext(ctx, "[3]. get exchange rates api response",
-		slog.Float64("JPY", r.Rates["JPY"]), // 157.35784932
+		slog.String("base", r.Base), // USD
+		slog.Any("rates", r.Rates),  // map[EUR:0.95 JPY:157.35784932]
 	)
 }
 
-func DailyParseJPYCostLogs(ctx context.Context, yesterdayCostJPY, actualCostJPY, forecastCostJPY float64) {
-	slog.InfoContext(ctx, "[4] parsed jpy cost",
-		slog.Float64("yesterday", yesterdayCostJPY), // 3.4200821066984974
-		slog.Float64("actual", actualCostJPY),       // 114.52274016489426
-		slog.Float64("forecast", forecastCostJPY),   // 122.73912246960002
+func DailyConvertedCostLogs(ctx context.Context, currency string, yesterdayCost, actualCost, forecastCost float64) {
+	slog.InfoContext(ctx, "[4] converted cost",
+		slog.String("currency", currency),        // JPY
+		slog.Float64("yesterday", yesterdayCost), // 3.4200821066984974
+		slog.Float64("actual", actualCost),       // 114.52274016489426
+		slog.Float64("forecast", forecastCost),   // 122.73912246960002
 	)
 }
 
-func WeeklyParseJPYCostLogs(ctx context.Context, lastWeekCost, weekBeforeLastCost float64) {
-	slog.InfoContext(ctx, "[4] parsed jpy cost",
+func WeeklyConvertedCostLogs(ctx context.Context, currency string, lastWeekCost, weekBeforeLastCost float64) {
+	slog.InfoContext(ctx, "[4] converted cost",
+		slog.String("currency", currency),                         // JPY
 		slog.Float64("last week cost", lastWeekCost),              // 4.73
 		slog.Float64("week before last cost", weekBeforeLastCost), // 4.73
 	)
 }
+
+func MonthlyConvertedCostLogs(ctx context.Context, currency string, lastMonthCost, monthBeforeLastCost float64) {
+	slog.InfoContext(ctx, "[4] converted cost",
+		slog.String("currency", currency),                           // JPY
+		slog.Float64("last month cost", lastMonthCost),              // 133.32
+		slog.Float64("month before last cost", monthBeforeLastCost), // 142.18
+	)
+}
+
+// serviceCostsGroup: サービスごとの利用コストをログ出力用のグループに変換
+func serviceCostsGroup(key string, serviceCosts []service.ServiceCost) slog.Attr {
+	attrs := make([]any, 0, len(serviceCosts))
+	for _, sc := range serviceCosts {
+		attrs = append(attrs, slog.Float64(sc.ServiceName, sc.Cost))
+	}
+	return slog.Group(key, attrs...)
+}
@@ -0,0 +1,140 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/retry"
+)
+
+// IDiscordClient は、Discord にメッセージを送信するためのインターフェースを定義します。
+//
+// GoMockを使用してテスト時にモックを作成するためにこのインターフェースを定義します。
+type IDiscordClient interface {
+	// SendEmbeds は、Embed を含むメッセージを Discord に送信するメソッドです。
+	SendEmbeds(ctx context.Context, embeds []Embed) error
+}
+
+var _ IDiscordClient = (*discordClient)(nil)
+
+// defaultTimeout は、Discord へのリクエストのタイムアウトです。
+const defaultTimeout = 10 * time.Second
+
+// discordClient は、Discord の Webhook にメッセージを送信するための構造体です。
+type discordClient struct {
+	webhookURL string
+	userName   string
+	httpClient *http.Client
+	retry      retry.Policy
+}
+
+// NewDiscordClient は、Discord クライアントのインスタンスを初期化する関数です。
+//
+// webhookURL と userName を引数として受け取り、それを基に discordClient を返します。
+func NewDiscordClient(webhookURL, userName string) *discordClient {
+	return &discordClient{
+		webhookURL: webhookURL,
+		userName:   userName,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		retry:      retry.DefaultPolicy(),
+	}
+}
+
+// message は、Webhook に送信するメッセージの形式です。
+type message struct {
+	Username string  `json:"username,omitempty"`
+	Embeds   []Embed `json:"embeds"`
+}
+
+// SendEmbeds は、Embed を含むメッセージを Discord に送信するメソッドです。
+//
+// Discord の仕様上、1つのメッセージに含められる Embed は10個までのため、超える場合は複数のメッセージに分けて送信します。
+func (dc *discordClient) SendEmbeds(ctx context.Context, embeds []Embed) error {
+	for start := 0; start < len(embeds); start += maxEmbedsPerMessage {
+		end := min(start+maxEmbedsPerMessage, len(embeds))
+
+		chunk := make([]Embed, 0, end-start)
+		for _, e := range embeds[start:end] {
+			chunk = append(chunk, e.normalize())
+		}
+
+		body, err := json.Marshal(message{Username: dc.userName, Embeds: chunk})
+		if err != nil {
+			return fmt.Errorf("failed to marshal discord message: %w", err)
+		}
+
+		if err := dc.send(ctx, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rateLimitResponse は、レート制限を超えた場合 (429) のレスポンスの本文です。
+//
+// DOC: https://discord.com/developers/docs/topics/rate-limits#exceeding-a-rate-limit
+type rateLimitResponse struct {
+	Message    string  `json:"message"`
+	RetryAfter float64 `json:"retry_after"` // 再送までに待機する秒数
+	Global     bool    `json:"global"`
+}
+
+// send は、メッセージを送信し、レート制限を超えた場合は指定された時間だけ待機して再送します。
+//
+// 通信エラーや 5xx の場合も待機時間を延ばしながら再送し、待機するとコンテキストの期限を超える場合や、最大の送信回数に達した場合はエラーを返します。
+func (dc *discordClient) send(ctx context.Context, body []byte) error {
+	if err := dc.retry.Do(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, dc.webhookURL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create discord request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		res, err := dc.httpClient.Do(req)
+		if err != nil {
+			return retry.Retryable(err)
+		}
+
+		b, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		res.Body.Close()
+
+		if res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices {
+			return nil
+		}
+
+		err = fmt.Errorf("status=%d body=%s", res.StatusCode, string(b))
+		switch {
+		case res.StatusCode == http.StatusTooManyRequests:
+			return retry.After(err, retryAfter(res.Header, b))
+		case retry.IsRetryableStatus(res.StatusCode):
+			return retry.Retryable(err)
+		default:
+			return err
+		}
+	}); err != nil {
+		return fmt.Errorf("failed to send discord message: %w", err)
+	}
+	return nil
+}
+
+// retryAfter は、レート制限を超えた場合のレスポンスから再送までの待機時間を取得します。
+//
+// 本文の retry_after を優先し、取得できない場合は Retry-After ヘッダーの秒数を使用します。
+func retryAfter(header http.Header, body []byte) time.Duration {
+	var rl rateLimitResponse
+	if err := json.Unmarshal(body, &rl); err == nil && rl.RetryAfter > 0 {
+		return time.Duration(math.Ceil(rl.RetryAfter*1000)) * time.Millisecond
+	}
+
+	if seconds, err := strconv.ParseFloat(header.Get("Retry-After"), 64); err == nil && seconds > 0 {
+		return time.Duration(math.Ceil(seconds*1000)) * time.Millisecond
+	}
+	return time.Second
+}
@@ -0,0 +1,95 @@
+package discord
+
+import (
+	"strings"
+	"time"
+)
+
+// Discord の Embed に関する上限値です。
+//
+// DOC: https://discord.com/developers/docs/resources/message#embed-object-embed-limits
+const (
+	maxEmbedsPerMessage = 10
+	maxFieldsPerEmbed   = 25
+	maxFieldValueLength = 1024
+	maxDescriptionRunes = 4096
+)
+
+// Color は、Embed の左側に表示されるバーの色を表す型です。
+type Color int
+
+// Embed のバーの色として使用する定数です。
+const (
+	// ColorGood は、コストが減少または横ばいであることを表す緑色です。
+	ColorGood Color = 0x2EB67D
+
+	// ColorWarning は、コストが増加していることを表す黄色です。
+	ColorWarning Color = 0xECB22E
+
+	// ColorDanger は、コストの増加が閾値を超えたことを表す赤色です。
+	ColorDanger Color = 0xE01E5A
+)
+
+// Embed は、Discord のメッセージに埋め込んで表示するカードです。
+type Embed struct {
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Color       Color      `json:"color,omitempty"`
+	Fields      []Field    `json:"fields,omitempty"`
+	Footer      *Footer    `json:"footer,omitempty"`
+	Timestamp   *time.Time `json:"timestamp,omitempty"`
+}
+
+// Field は、Embed に表示する項目名と値の組です。
+//
+// Inline を指定した項目は、横に並べて表示されます。
+type Field struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// Footer は、Embed の下部に小さな文字で表示される補足情報です。
+type Footer struct {
+	Text string `json:"text"`
+}
+
+// CodeBlockField は、複数行のテキストをコードブロックとして表示する項目を生成します。
+//
+// Discord の仕様上、項目の値は1024文字までのため、超える場合は収まる行までを表示し、末尾に省略したことを示す行を追加します。
+func CodeBlockField(name, text string) Field {
+	const (
+		fenceOpen  = "```\n"
+		fenceClose = "```"
+		omitted    = "…\n"
+	)
+
+	value := fenceOpen + text + fenceClose
+	if len([]rune(value)) <= maxFieldValueLength {
+		return Field{Name: name, Value: value}
+	}
+
+	limit := maxFieldValueLength - len([]rune(fenceOpen+fenceClose+omitted))
+	var sb strings.Builder
+	length := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		n := len([]rune(line))
+		if length+n > limit {
+			break
+		}
+		sb.WriteString(line)
+		length += n
+	}
+	return Field{Name: name, Value: fenceOpen + sb.String() + omitted + fenceClose}
+}
+
+// normalize は、Discord の上限を超える項目と説明文を切り詰めた Embed を返します。
+func (e Embed) normalize() Embed {
+	if len(e.Fields) > maxFieldsPerEmbed {
+		e.Fields = e.Fields[:maxFieldsPerEmbed]
+	}
+	if runes := []rune(e.Description); len(runes) > maxDescriptionRunes {
+		e.Description = string(runes[:maxDescriptionRunes-1]) + "…"
+	}
+	return e
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/library/discord/discord.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/library/discord/discord.go -destination=./internal/library/discord/mock/discord.go -package=discord
+//
+
+// Package discord is a generated GoMock package.
+package discord
+
+import (
+	context "context"
+	reflect "reflect"
+
+	discord "github.com/tamaco489/cost_explorer/batch/internal/library/discord"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIDiscordClient is a mock of IDiscordClient interface.
+type MockIDiscordClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDiscordClientMockRecorder
+	isgomock struct{}
+}
+
+// MockIDiscordClientMockRecorder is the mock recorder for MockIDiscordClient.
+type MockIDiscordClientMockRecorder struct {
+	mock *MockIDiscordClient
+}
+
+// NewMockIDiscordClient creates a new mock instance.
+func NewMockIDiscordClient(ctrl *gomock.Controller) *MockIDiscordClient {
+	mock := &MockIDiscordClient{ctrl: ctrl}
+	mock.recorder = &MockIDiscordClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDiscordClient) EXPECT() *MockIDiscordClientMockRecorder {
+	return m.recorder
+}
+
+// SendEmbeds mocks base method.
+func (m *MockIDiscordClient) SendEmbeds(ctx context.Context, embeds []discord.Embed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmbeds", ctx, embeds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmbeds indicates an expected call of SendEmbeds.
+func (mr *MockIDiscordClientMockRecorder) SendEmbeds(ctx, embeds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmbeds", reflect.TypeOf((*MockIDiscordClient)(nil).SendEmbeds), ctx, embeds)
+}
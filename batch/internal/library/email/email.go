@@ -0,0 +1,190 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IEmailClient は、メールを送信するためのインターフェースを定義します。
+//
+// GoMockを使用してテスト時にモックを作成するためにこのインターフェースを定義します。
+type IEmailClient interface {
+	// SendMail は、メールを宛先に送信するメソッドです。
+	SendMail(ctx context.Context, message Message) error
+}
+
+var _ IEmailClient = (*smtpClient)(nil)
+
+// defaultTimeout は、SMTP サーバーとの通信のタイムアウトです。
+const defaultTimeout = 30 * time.Second
+
+// Message は、送信するメールを表す構造体です。
+//
+// HTMLBody と TextBody は multipart/alternative として送信し、HTML を表示できないメールクライアントではテキストの本文が表示されます。
+type Message struct {
+	To       []string
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
+
+// smtpClient は、SMTP サーバー経由でメールを送信するための構造体です。
+type smtpClient struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPClient は、SMTP クライアントのインスタンスを初期化する関数です。
+//
+// username が空の場合は、SMTP 認証を行わずに送信します。
+func NewSMTPClient(host string, port int, username, password, from string) *smtpClient {
+	return &smtpClient{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// SendMail は、メールを宛先に送信するメソッドです。
+//
+// SMTP サーバーが STARTTLS に対応している場合は、暗号化した上で認証と送信を行います。
+func (sc *smtpClient) SendMail(ctx context.Context, message Message) error {
+	if len(message.To) == 0 {
+		return fmt.Errorf("no email recipients are specified")
+	}
+
+	body, err := buildMessage(sc.from, message, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to build email message: %w", err)
+	}
+
+	addr := net.JoinHostPort(sc.host, strconv.Itoa(sc.port))
+	dialer := net.Dialer{Timeout: defaultTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to set smtp deadline: %w", err)
+	}
+
+	c, err := smtp.NewClient(conn, sc.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to create smtp client: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: sc.host}); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+
+	if sc.username != "" {
+		if err := c.Auth(smtp.PlainAuth("", sc.username, sc.password, sc.host)); err != nil {
+			return fmt.Errorf("failed to authenticate smtp: %w", err)
+		}
+	}
+
+	if err := c.Mail(sc.from); err != nil {
+		return fmt.Errorf("failed to set email sender: %w", err)
+	}
+	for _, to := range message.To {
+		if err := c.Rcpt(to); err != nil {
+			return fmt.Errorf("failed to set email recipient %s: %w", to, err)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start email data: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("failed to write email data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return c.Quit()
+}
+
+// buildMessage は、テキストと HTML の本文を multipart/alternative としてまとめたメールを生成します。
+func buildMessage(from string, message Message, now time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		// multipart/alternative では、後ろのパートほど優先して表示されるため、HTML を最後に配置する
+		{contentType: "text/plain; charset=UTF-8", content: message.TextBody},
+		{contentType: "text/html; charset=UTF-8", content: message.HTMLBody},
+	}
+	for _, p := range parts {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(pw, p.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	headers := []struct{ key, value string }{
+		{"From", from},
+		{"To", strings.Join(message.To, ", ")},
+		{"Subject", mime.BEncoding.Encode("UTF-8", message.Subject)},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": mw.Boundary()})},
+	}
+	for _, h := range headers {
+		fmt.Fprintf(&msg, "%s: %s\r\n", h.key, h.value)
+	}
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+// writeQuotedPrintable は、本文を quoted-printable でエンコードして書き込みます。
+func writeQuotedPrintable(w io.Writer, content string) error {
+	qw := quotedprintable.NewWriter(w)
+	if _, err := qw.Write([]byte(content)); err != nil {
+		return err
+	}
+	return qw.Close()
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/library/email/email.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/library/email/email.go -destination=./internal/library/email/mock/email.go -package=email
+//
+
+// Package email is a generated GoMock package.
+package email
+
+import (
+	context "context"
+	reflect "reflect"
+
+	email "github.com/tamaco489/cost_explorer/batch/internal/library/email"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIEmailClient is a mock of IEmailClient interface.
+type MockIEmailClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEmailClientMockRecorder
+	isgomock struct{}
+}
+
+// MockIEmailClientMockRecorder is the mock recorder for MockIEmailClient.
+type MockIEmailClientMockRecorder struct {
+	mock *MockIEmailClient
+}
+
+// NewMockIEmailClient creates a new mock instance.
+func NewMockIEmailClient(ctrl *gomock.Controller) *MockIEmailClient {
+	mock := &MockIEmailClient{ctrl: ctrl}
+	mock.recorder = &MockIEmailClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEmailClient) EXPECT() *MockIEmailClientMockRecorder {
+	return m.recorder
+}
+
+// SendMail mocks base method.
+func (m *MockIEmailClient) SendMail(ctx context.Context, message email.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMail", ctx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMail indicates an expected call of SendMail.
+func (mr *MockIEmailClientMockRecorder) SendMail(ctx, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMail", reflect.TypeOf((*MockIEmailClient)(nil).SendMail), ctx, message)
+}
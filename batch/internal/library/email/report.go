@@ -0,0 +1,154 @@
+package email
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"unicode"
+)
+
+// Report は、メール本文に表示するレポートを表す構造体です。
+//
+// 同じ内容から HTML の本文とテキストの本文の両方を生成します。
+type Report struct {
+	Title  string
+	Facts  []Fact
+	Alerts []string // 閾値を超えた項目など、強調して表示する文言
+	Tables []Table
+	Footer string
+}
+
+// Fact は、レポートの概要に表示する項目名と値の組です。
+type Fact struct {
+	Label string
+	Value string
+}
+
+// Table は、レポートに表示する表です。
+//
+// 1列目は左揃え、2列目以降は右揃えで表示します。
+type Table struct {
+	Title  string
+	Header []string
+	Rows   [][]string
+}
+
+// reportTemplate は、HTML の本文のテンプレートです。
+//
+// メールクライアントによっては <style> を解釈しないため、スタイルは要素ごとに指定します。
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="UTF-8"><title>{{.Title}}</title></head>
+<body style="font-family: sans-serif; color: #1d1c1d;">
+<h2>{{.Title}}</h2>
+<table style="border-collapse: collapse;">
+{{- range .Facts}}
+<tr><th style="text-align: left; padding: 4px 16px 4px 0;">{{.Label}}</th><td style="text-align: right; padding: 4px 0;">{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- range .Alerts}}
+<p style="color: #d50000; font-weight: bold;">&#9888; {{.}}</p>
+{{- end}}
+{{- range .Tables}}
+<h3>{{.Title}}</h3>
+<table style="border-collapse: collapse;">
+<tr>{{range $i, $h := .Header}}<th style="border-bottom: 1px solid #999; padding: 4px 8px; text-align: {{if $i}}right{{else}}left{{end}};">{{$h}}</th>{{end}}</tr>
+{{- range .Rows}}
+<tr>{{range $i, $c := .}}<td style="border-bottom: 1px solid #ddd; padding: 4px 8px; text-align: {{if $i}}right{{else}}left{{end}};">{{$c}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- end}}
+<p style="color: #616061; font-size: small;">{{.Footer}}</p>
+</body>
+</html>
+`))
+
+// HTML は、レポートを HTML の本文に変換します。
+func (r Report) HTML() (string, error) {
+	var buf bytes.Buffer
+	if err := reportTemplate.Execute(&buf, r); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Text は、レポートを HTML を表示できないメールクライアント向けのテキストの本文に変換します。
+func (r Report) Text() string {
+	var sb strings.Builder
+	sb.WriteString(r.Title + "\n\n")
+
+	for _, f := range r.Facts {
+		sb.WriteString("• " + f.Label + ": " + f.Value + "\n")
+	}
+
+	for _, alert := range r.Alerts {
+		sb.WriteString("\n⚠ " + alert + "\n")
+	}
+
+	for _, t := range r.Tables {
+		sb.WriteString("\n" + t.Title + "\n")
+		sb.WriteString(t.String())
+	}
+
+	if r.Footer != "" {
+		sb.WriteString("\n" + r.Footer + "\n")
+	}
+	return sb.String()
+}
+
+// String は、表の各列の幅を揃えた文字列を生成します。
+//
+// 全角文字は半角2文字分の幅として桁を揃えます。
+func (t Table) String() string {
+	widths := make([]int, len(t.Header))
+	for i, h := range t.Header {
+		widths[i] = displayWidth(h)
+	}
+	for _, row := range t.Rows {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], displayWidth(cell))
+			}
+		}
+	}
+
+	var sb strings.Builder
+	writeRow := func(cells []string) {
+		for i := range widths {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			padding := strings.Repeat(" ", widths[i]-displayWidth(cell))
+			if i > 0 {
+				sb.WriteString("  " + padding + cell)
+				continue
+			}
+			sb.WriteString(cell)
+			if len(widths) > 1 {
+				sb.WriteString(padding)
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	writeRow(t.Header)
+	for _, row := range t.Rows {
+		writeRow(row)
+	}
+	return sb.String()
+}
+
+// displayWidth は、等幅フォントで表示した場合の文字列の幅を算出します。
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+			(r >= 0x3000 && r <= 0x30FF) || (r >= 0xFF01 && r <= 0xFF60) || (r >= 0xFFE0 && r <= 0xFFE6) {
+			width += 2
+			continue
+		}
+		width++
+	}
+	return width
+}
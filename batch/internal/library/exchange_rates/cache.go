@@ -0,0 +1,168 @@
+package exchange_rates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrCacheMiss: キャッシュに指定したキーの値が保存されていないことを表すエラー
+var ErrCacheMiss = errors.New("exchange rates cache miss")
+
+// CacheStore: 為替レートのキャッシュの保存先のインターフェース
+//
+// ローカルでの実行にはファイル、Lambda での実行には S3 など、実行環境に合わせて保存先を差し替える
+type CacheStore interface {
+	// Get: キーに対応する値を取得 (保存されていない場合は ErrCacheMiss を返す)
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set: キーに対応する値を保存
+	Set(ctx context.Context, key string, value []byte) error
+}
+
+// cacheEntry: キャッシュに保存する為替レート
+type cacheEntry struct {
+	CachedAt    time.Time              `json:"cached_at"`
+	Source      string                 `json:"source"` // レスポンスの Source は JSON に含まれないため個別に保存する
+	Approximate bool                   `json:"approximate"`
+	Response    *ExchangeRatesResponse `json:"response"`
+}
+
+// CachedProvider: 為替レートの取得元の前段でレスポンスをキャッシュする Provider
+//
+// 最新の為替レートは TTL の間はキャッシュを返し、取得元で失敗した場合は MaxStale の範囲で期限切れのキャッシュを返す。
+// 過去の日付の為替レートは変わらないため、期限を設けずにキャッシュを返す。
+// ただし、指定した日付の為替レートを代用した場合 (Approximate) は、最新の為替レートと同様に TTL を過ぎると取得し直す。
+type CachedProvider struct {
+	Provider HistoricalProvider
+	Store    CacheStore
+	TTL      time.Duration    // キャッシュした最新の為替レートの有効期間
+	MaxStale time.Duration    // 取得元で失敗した場合に期限切れのキャッシュを返す上限 (0 の場合は上限なし)
+	Now      func() time.Time // キャッシュの経過時間の算出に使用する現在時刻を取得する関数
+}
+
+var _ HistoricalProvider = (*CachedProvider)(nil)
+
+// NewCachedProvider: CachedProvider のコンストラクタ
+func NewCachedProvider(provider HistoricalProvider, store CacheStore, ttl, maxStale time.Duration) *CachedProvider {
+	return &CachedProvider{
+		Provider: provider,
+		Store:    store,
+		TTL:      ttl,
+		MaxStale: maxStale,
+		Now:      time.Now,
+	}
+}
+
+// Name: 取得元の名前
+func (cp *CachedProvider) Name() string {
+	return cp.Provider.Name()
+}
+
+// GetExchangeRates: キャッシュまたは取得元から最新の為替レートを取得
+//
+// 取得元で失敗し期限切れのキャッシュを返す場合は、レスポンスの Stale を true にする
+func (cp *CachedProvider) GetExchangeRates(ctx context.Context, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error) {
+	key := cacheKey("latest", baseCurrencyCode, exchangeCurrencyCodes)
+
+	entry := cp.load(ctx, key)
+	if entry != nil && cp.Now().Sub(entry.CachedAt) < cp.TTL {
+		slog.InfoContext(ctx, "exchange rates cache hit", slog.String("key", key))
+		return entry.response(), nil
+	}
+
+	res, err := cp.Provider.GetExchangeRates(ctx, baseCurrencyCode, exchangeCurrencyCodes)
+	if err != nil {
+		return cp.stale(ctx, key, entry, err)
+	}
+
+	cp.save(ctx, key, res)
+	return res, nil
+}
+
+// GetHistoricalExchangeRates: キャッシュまたは取得元から指定した日付の為替レートを取得
+func (cp *CachedProvider) GetHistoricalExchangeRates(ctx context.Context, date time.Time, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error) {
+	key := cacheKey("historical/"+date.Format("2006-01-02"), baseCurrencyCode, exchangeCurrencyCodes)
+
+	entry := cp.load(ctx, key)
+	if entry != nil && (!entry.Approximate || cp.Now().Sub(entry.CachedAt) < cp.TTL) {
+		return entry.response(), nil
+	}
+
+	res, err := cp.Provider.GetHistoricalExchangeRates(ctx, date, baseCurrencyCode, exchangeCurrencyCodes)
+	if err != nil {
+		return cp.stale(ctx, key, entry, err)
+	}
+
+	cp.save(ctx, key, res)
+	return res, nil
+}
+
+// stale: 取得元で失敗した場合に、MaxStale の範囲で期限切れのキャッシュを返す (キャッシュがない場合は取得元のエラーを返す)
+func (cp *CachedProvider) stale(ctx context.Context, key string, entry *cacheEntry, err error) (*ExchangeRatesResponse, error) {
+	if entry == nil {
+		return nil, err
+	}
+	age := cp.Now().Sub(entry.CachedAt)
+	if cp.MaxStale > 0 && age >= cp.MaxStale {
+		return nil, err
+	}
+
+	slog.WarnContext(ctx, "failed to get exchange rates, serving stale cached rates",
+		slog.String("key", key),
+		slog.Duration("age", age),
+		slog.String("error", err.Error()),
+	)
+	stale := entry.response()
+	stale.Stale = true
+	return stale, nil
+}
+
+// load: キャッシュを取得 (キャッシュが利用できない場合は取得元から取得するため、エラーはログに出力して nil を返す)
+func (cp *CachedProvider) load(ctx context.Context, key string) *cacheEntry {
+	b, err := cp.Store.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, ErrCacheMiss) {
+			slog.WarnContext(ctx, "failed to read exchange rates cache", slog.String("key", key), slog.String("error", err.Error()))
+		}
+		return nil
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil || entry.Response == nil {
+		slog.WarnContext(ctx, "invalid exchange rates cache entry", slog.String("key", key))
+		return nil
+	}
+	return &entry
+}
+
+// save: 取得した為替レートをキャッシュに保存 (保存に失敗しても為替レートは取得できているため、エラーはログに出力する)
+func (cp *CachedProvider) save(ctx context.Context, key string, res *ExchangeRatesResponse) {
+	b, err := json.Marshal(cacheEntry{CachedAt: cp.Now(), Source: res.Source, Approximate: res.Approximate, Response: res})
+	if err == nil {
+		err = cp.Store.Set(ctx, key, b)
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "failed to write exchange rates cache", slog.String("key", key), slog.String("error", err.Error()))
+	}
+}
+
+// response: キャッシュした為替レートのレスポンスを生成
+func (e *cacheEntry) response() *ExchangeRatesResponse {
+	res := *e.Response
+	res.Source = e.Source
+	res.Approximate = e.Approximate
+	return &res
+}
+
+// cacheKey: 為替レートの種類と通貨の組み合わせからキャッシュのキーを生成
+func cacheKey(kind, baseCurrencyCode string, exchangeCurrencyCodes []string) string {
+	codes := slices.Clone(exchangeCurrencyCodes)
+	slices.Sort(codes)
+	return fmt.Sprintf("%s/%s/%s", kind, baseCurrencyCode, strings.Join(codes, ","))
+}
@@ -0,0 +1,62 @@
+package exchange_rates
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FileCacheStore: ローカルのファイルに為替レートのキャッシュを保存する CacheStore
+//
+// キーの "/" をディレクトリの区切りとして、Dir 配下に JSON ファイルとして保存する
+type FileCacheStore struct {
+	Dir string
+}
+
+var _ CacheStore = (*FileCacheStore)(nil)
+
+// NewFileCacheStore: FileCacheStore のコンストラクタ
+func NewFileCacheStore(dir string) *FileCacheStore {
+	return &FileCacheStore{Dir: dir}
+}
+
+// Get: キーに対応するファイルを読み込む
+func (fcs *FileCacheStore) Get(ctx context.Context, key string) ([]byte, error) {
+	b, err := os.ReadFile(fcs.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrCacheMiss
+	}
+	return b, err
+}
+
+// Set: キーに対応するファイルに書き込む
+//
+// 同時に実行されたジョブが書き込み途中のファイルを読み込まないよう、一時ファイルに書き込んでから置き換える
+func (fcs *FileCacheStore) Set(ctx context.Context, key string, value []byte) error {
+	path := fcs.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(value); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// path: キーに対応するファイルのパス
+func (fcs *FileCacheStore) path(key string) string {
+	return filepath.Join(fcs.Dir, filepath.FromSlash(key)+".json")
+}
@@ -0,0 +1,76 @@
+package exchange_rates
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3API: S3CacheStore が使用する S3 の API
+type S3API interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// S3CacheStore: S3 のオブジェクトとして為替レートのキャッシュを保存する CacheStore
+//
+// Lambda の一時領域は実行環境ごとに破棄されるため、実行をまたいでキャッシュを共有する場合に使用する
+type S3CacheStore struct {
+	Client S3API
+	Bucket string
+	Prefix string // オブジェクトキーの接頭辞 (例: exchange_rates/cache)
+}
+
+var _ CacheStore = (*S3CacheStore)(nil)
+
+// NewS3CacheStore: S3CacheStore のコンストラクタ
+func NewS3CacheStore(client S3API, bucket, prefix string) *S3CacheStore {
+	return &S3CacheStore{
+		Client: client,
+		Bucket: bucket,
+		Prefix: prefix,
+	}
+}
+
+// Get: キーに対応するオブジェクトを取得
+func (scs *S3CacheStore) Get(ctx context.Context, key string) ([]byte, error) {
+	out, err := scs.Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(scs.Bucket),
+		Key:    aws.String(scs.objectKey(key)),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrCacheMiss
+		}
+		return nil, fmt.Errorf("failed to get exchange rates cache object: %w", err)
+	}
+	defer out.Body.Close()
+
+	return io.ReadAll(out.Body)
+}
+
+// Set: キーに対応するオブジェクトを保存
+func (scs *S3CacheStore) Set(ctx context.Context, key string, value []byte) error {
+	if _, err := scs.Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(scs.Bucket),
+		Key:         aws.String(scs.objectKey(key)),
+		Body:        bytes.NewReader(value),
+		ContentType: aws.String("application/json"),
+	}); err != nil {
+		return fmt.Errorf("failed to put exchange rates cache object: %w", err)
+	}
+	return nil
+}
+
+// objectKey: キーに対応するオブジェクトキー
+func (scs *S3CacheStore) objectKey(key string) string {
+	return path.Join(scs.Prefix, key) + ".json"
+}
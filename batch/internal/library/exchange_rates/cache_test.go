@@ -0,0 +1,221 @@
+package exchange_rates_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/exchange_rates"
+)
+
+// countingProvider: 呼び出し回数を記録し、設定したレートまたはエラーを返す HistoricalProvider
+type countingProvider struct {
+	rate        float64
+	err         error
+	calls       int
+	approximate bool // 過去の日付の為替レートを代用したものとして返すか
+}
+
+func (cp *countingProvider) Name() string { return "upstream" }
+
+func (cp *countingProvider) GetExchangeRates(ctx context.Context, baseCurrencyCode string, exchangeCurrencyCodes []string) (*exchange_rates.ExchangeRatesResponse, error) {
+	cp.calls++
+	if cp.err != nil {
+		return nil, cp.err
+	}
+	return &exchange_rates.ExchangeRatesResponse{Base: baseCurrencyCode, Rates: map[string]float64{"JPY": cp.rate}, Source: "upstream"}, nil
+}
+
+func (cp *countingProvider) GetHistoricalExchangeRates(ctx context.Context, date time.Time, baseCurrencyCode string, exchangeCurrencyCodes []string) (*exchange_rates.ExchangeRatesResponse, error) {
+	res, err := cp.GetExchangeRates(ctx, baseCurrencyCode, exchangeCurrencyCodes)
+	if err != nil {
+		return nil, err
+	}
+	res.Approximate = cp.approximate
+	return res, nil
+}
+
+// TestCachedProvider_GetExchangeRates: TTL の間はキャッシュを返し、取得元で失敗した場合は期限切れのキャッシュを返すことをテストします
+func TestCachedProvider_GetExchangeRates(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC)
+
+	upstream := &countingProvider{rate: 150}
+	cp := exchange_rates.NewCachedProvider(upstream, exchange_rates.NewFileCacheStore(t.TempDir()), 6*time.Hour, 48*time.Hour)
+	cp.Now = func() time.Time { return now }
+
+	// 初回は取得元から取得してキャッシュに保存する
+	res, err := cp.GetExchangeRates(ctx, "USD", []string{"JPY"})
+	assert.NoError(t, err)
+	assert.Equal(t, 150.0, res.Rates["JPY"])
+	assert.Equal(t, 1, upstream.calls)
+
+	tests := []struct {
+		name      string
+		elapsed   time.Duration
+		err       error
+		wantRate  float64
+		wantStale bool
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name:      "正常系: TTL の間は取得元を呼び出さずにキャッシュを返すこと",
+			elapsed:   time.Hour,
+			wantRate:  150,
+			wantCalls: 1,
+		},
+		{
+			name:      "正常系: 取得元で失敗した場合は期限切れのキャッシュを Stale として返すこと",
+			elapsed:   12 * time.Hour,
+			err:       exchange_rates.ErrQuotaExceeded,
+			wantRate:  150,
+			wantStale: true,
+			wantCalls: 2,
+		},
+		{
+			name:      "異常系: MaxStale を超えたキャッシュは使用せずにエラーを返すこと",
+			elapsed:   72 * time.Hour,
+			err:       exchange_rates.ErrQuotaExceeded,
+			wantCalls: 3,
+			wantErr:   true,
+		},
+		{
+			name:      "正常系: TTL を過ぎた場合は取得元から取得し直すこと",
+			elapsed:   12 * time.Hour,
+			wantRate:  152,
+			wantCalls: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream.rate, upstream.err = 152, tt.err
+			cp.Now = func() time.Time { return now.Add(tt.elapsed) }
+
+			res, err := cp.GetExchangeRates(ctx, "USD", []string{"JPY"})
+			assert.Equal(t, tt.wantCalls, upstream.calls)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRate, res.Rates["JPY"])
+			assert.Equal(t, tt.wantStale, res.Stale)
+			assert.Equal(t, "upstream", res.Source)
+		})
+	}
+}
+
+// TestCachedProvider_GetHistoricalExchangeRates: 過去の日付の為替レートは期限を設けずにキャッシュを返すことをテストします
+func TestCachedProvider_GetHistoricalExchangeRates(t *testing.T) {
+	ctx := context.Background()
+	date := time.Date(2024, 12, 10, 0, 0, 0, 0, time.UTC)
+
+	upstream := &countingProvider{rate: 151}
+	cp := exchange_rates.NewCachedProvider(upstream, exchange_rates.NewFileCacheStore(t.TempDir()), time.Hour, 0)
+
+	_, err := cp.GetHistoricalExchangeRates(ctx, date, "USD", []string{"JPY"})
+	assert.NoError(t, err)
+
+	cp.Now = func() time.Time { return time.Now().AddDate(1, 0, 0) }
+	res, err := cp.GetHistoricalExchangeRates(ctx, date, "USD", []string{"JPY"})
+	assert.NoError(t, err)
+	assert.Equal(t, 151.0, res.Rates["JPY"])
+	assert.Equal(t, 1, upstream.calls)
+}
+
+// TestCachedProvider_GetHistoricalExchangeRates_Approximate: 指定した日付の為替レートを代用した場合は、TTL を過ぎると取得し直すことをテストします
+func TestCachedProvider_GetHistoricalExchangeRates_Approximate(t *testing.T) {
+	ctx := context.Background()
+	date := time.Date(2024, 12, 14, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 12, 16, 9, 0, 0, 0, time.UTC)
+
+	upstream := &countingProvider{rate: 150, approximate: true}
+	cp := exchange_rates.NewCachedProvider(upstream, exchange_rates.NewFileCacheStore(t.TempDir()), time.Hour, 24*time.Hour)
+	cp.Now = func() time.Time { return now }
+
+	res, err := cp.GetHistoricalExchangeRates(ctx, date, "USD", []string{"JPY"})
+	assert.NoError(t, err)
+	assert.True(t, res.Approximate)
+
+	// TTL の間はキャッシュを返すこと
+	res, err = cp.GetHistoricalExchangeRates(ctx, date, "USD", []string{"JPY"})
+	assert.NoError(t, err)
+	assert.True(t, res.Approximate)
+	assert.Equal(t, 1, upstream.calls)
+
+	// TTL を過ぎた場合は取得し直し、取得元で失敗した場合は期限切れのキャッシュを返すこと
+	now = now.Add(2 * time.Hour)
+	upstream.err = errors.New("connection refused")
+	res, err = cp.GetHistoricalExchangeRates(ctx, date, "USD", []string{"JPY"})
+	assert.NoError(t, err)
+	assert.True(t, res.Stale)
+	assert.Equal(t, 2, upstream.calls)
+
+	// 指定した日付の為替レートを取得できた場合は、期限を設けずにキャッシュすること
+	upstream.err = nil
+	upstream.rate = 151
+	upstream.approximate = false
+	res, err = cp.GetHistoricalExchangeRates(ctx, date, "USD", []string{"JPY"})
+	assert.NoError(t, err)
+	assert.False(t, res.Approximate)
+	assert.Equal(t, 151.0, res.Rates["JPY"])
+
+	now = now.AddDate(1, 0, 0)
+	res, err = cp.GetHistoricalExchangeRates(ctx, date, "USD", []string{"JPY"})
+	assert.NoError(t, err)
+	assert.Equal(t, 151.0, res.Rates["JPY"])
+	assert.Equal(t, 3, upstream.calls)
+}
+
+// stubS3API: オブジェクトをメモリ上に保存する S3API
+type stubS3API struct {
+	objects map[string]string
+	err     error
+}
+
+func (s *stubS3API) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	body, ok := s.objects[*params.Bucket+"/"+*params.Key]
+	if !ok {
+		return nil, &types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func (s *stubS3API) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	b, _ := io.ReadAll(params.Body)
+	s.objects[*params.Bucket+"/"+*params.Key] = string(b)
+	return &s3.PutObjectOutput{}, nil
+}
+
+// TestS3CacheStore: 接頭辞を付けたオブジェクトキーで保存し、存在しないキーは ErrCacheMiss を返すことをテストします
+func TestS3CacheStore(t *testing.T) {
+	ctx := context.Background()
+	client := &stubS3API{objects: map[string]string{}}
+	store := exchange_rates.NewS3CacheStore(client, "cost-explorer-cache", "exchange_rates/cache")
+
+	_, err := store.Get(ctx, "latest/USD/JPY")
+	assert.ErrorIs(t, err, exchange_rates.ErrCacheMiss)
+
+	assert.NoError(t, store.Set(ctx, "latest/USD/JPY", []byte(`{"rate":150}`)))
+	assert.Equal(t, `{"rate":150}`, client.objects["cost-explorer-cache/exchange_rates/cache/latest/USD/JPY.json"])
+
+	b, err := store.Get(ctx, "latest/USD/JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"rate":150}`, string(b))
+
+	client.err = errors.New("access denied")
+	_, err = store.Get(ctx, "latest/USD/JPY")
+	assert.ErrorContains(t, err, "access denied")
+	assert.NotErrorIs(t, err, exchange_rates.ErrCacheMiss)
+}
@@ -0,0 +1,90 @@
+package exchange_rates
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// CrossRateProvider: 取得元から共通の基軸通貨 (USD) の為替レートを取得し、指定した基軸通貨の為替レートを算出する Provider
+//
+// 現状のプランでは USD 以外を基軸通貨として指定できないため、Cost Explorer の請求通貨が USD 以外の場合や
+// EUR を基軸通貨とした為替レートが必要な場合も、USD の為替レートからクロスレートを算出して返す。
+type CrossRateProvider struct {
+	Provider         HistoricalProvider
+	BaseCurrencyCode string // 取得元に指定する共通の基軸通貨
+}
+
+var _ HistoricalProvider = (*CrossRateProvider)(nil)
+
+// NewCrossRateProvider: CrossRateProvider のコンストラクタ
+func NewCrossRateProvider(provider HistoricalProvider) *CrossRateProvider {
+	return &CrossRateProvider{
+		Provider:         provider,
+		BaseCurrencyCode: GetBaseCurrency(),
+	}
+}
+
+// Name: 取得元の名前
+func (crp *CrossRateProvider) Name() string {
+	return crp.Provider.Name()
+}
+
+// GetExchangeRates: 指定した基軸通貨1単位あたりの変換対象通貨の最新の為替レートを取得
+func (crp *CrossRateProvider) GetExchangeRates(ctx context.Context, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error) {
+	return crp.cross(baseCurrencyCode, exchangeCurrencyCodes, func(symbols []string) (*ExchangeRatesResponse, error) {
+		return crp.Provider.GetExchangeRates(ctx, crp.BaseCurrencyCode, symbols)
+	})
+}
+
+// GetHistoricalExchangeRates: 指定した日付の基軸通貨1単位あたりの変換対象通貨の為替レートを取得
+func (crp *CrossRateProvider) GetHistoricalExchangeRates(ctx context.Context, date time.Time, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error) {
+	return crp.cross(baseCurrencyCode, exchangeCurrencyCodes, func(symbols []string) (*ExchangeRatesResponse, error) {
+		return crp.Provider.GetHistoricalExchangeRates(ctx, date, crp.BaseCurrencyCode, symbols)
+	})
+}
+
+// cross: 共通の基軸通貨の為替レートを取得し、指定した基軸通貨の為替レートに変換
+//
+// 指定した基軸通貨が共通の基軸通貨の場合は、取得元のレスポンスをそのまま返す
+func (crp *CrossRateProvider) cross(baseCurrencyCode string, exchangeCurrencyCodes []string, get func(symbols []string) (*ExchangeRatesResponse, error)) (*ExchangeRatesResponse, error) {
+	if baseCurrencyCode == crp.BaseCurrencyCode {
+		return get(exchangeCurrencyCodes)
+	}
+
+	// 指定した基軸通貨の為替レートも取得し、共通の基軸通貨は 1 として算出する
+	symbols := []string{baseCurrencyCode}
+	for _, code := range exchangeCurrencyCodes {
+		if code != crp.BaseCurrencyCode && !slices.Contains(symbols, code) {
+			symbols = append(symbols, code)
+		}
+	}
+
+	res, err := get(symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	common := make(map[string]float64, len(res.Rates)+1)
+	for code, rate := range res.Rates {
+		common[code] = rate
+	}
+	common[crp.BaseCurrencyCode] = 1
+
+	rates, err := crossRates(common, baseCurrencyCode, exchangeCurrencyCodes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive %s cross rates from %s rates: %w", baseCurrencyCode, crp.BaseCurrencyCode, err)
+	}
+
+	return &ExchangeRatesResponse{
+		Disclaimer:  res.Disclaimer,
+		License:     res.License,
+		Timestamp:   res.Timestamp,
+		Base:        baseCurrencyCode,
+		Rates:       rates,
+		Source:      res.Source,
+		Stale:       res.Stale,
+		Approximate: res.Approximate,
+	}, nil
+}
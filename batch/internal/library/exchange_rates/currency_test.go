@@ -16,20 +16,28 @@ func TestExchangeRatesCurrencyCode_Valid(t *testing.T) {
 			input:    exchange_rates.USD,
 			expected: true,
 		},
-		"EURは無効な通貨コード": {
+		"EURは有効な通貨コード": {
 			input:    exchange_rates.EUR,
-			expected: false,
+			expected: true,
 		},
-		"JPYは無効な通貨コード": {
+		"JPYは有効な通貨コード": {
 			input:    exchange_rates.JPY,
-			expected: false,
+			expected: true,
+		},
+		"定数を定義していない ISO 4217 の通貨コードも有効": {
+			input:    exchange_rates.ExchangeRatesCurrencyCode("CHF"),
+			expected: true,
 		},
 		"空文字は無効な通貨コード": {
 			input:    exchange_rates.ExchangeRatesCurrencyCode(""),
 			expected: false,
 		},
-		"他の通貨コードも無効": {
-			input:    exchange_rates.ExchangeRatesCurrencyCode("GBP"),
+		"ISO 4217 に存在しない通貨コードは無効": {
+			input:    exchange_rates.ExchangeRatesCurrencyCode("XYZ"),
+			expected: false,
+		},
+		"小文字の通貨コードは無効": {
+			input:    exchange_rates.ExchangeRatesCurrencyCode("jpy"),
 			expected: false,
 		},
 	}
@@ -41,3 +49,24 @@ func TestExchangeRatesCurrencyCode_Valid(t *testing.T) {
 		})
 	}
 }
+
+func TestExchangeRatesCurrencyCode_MinorUnits(t *testing.T) {
+	tests := map[string]struct {
+		input    exchange_rates.ExchangeRatesCurrencyCode
+		expected int
+		ok       bool
+	}{
+		"USDの補助単位は2桁":     {input: exchange_rates.USD, expected: 2, ok: true},
+		"JPYの補助単位は0桁":     {input: exchange_rates.JPY, expected: 0, ok: true},
+		"KWDの補助単位は3桁":     {input: exchange_rates.ExchangeRatesCurrencyCode("KWD"), expected: 3, ok: true},
+		"無効な通貨コードは取得できない": {input: exchange_rates.ExchangeRatesCurrencyCode("XYZ"), expected: 0, ok: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			units, ok := tt.input.MinorUnits()
+			assert.Equal(t, tt.expected, units)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package exchange_rates
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/retry"
+)
+
+// ecbDailyURL: 欧州中央銀行 (ECB) が公表する日次の参照レートの XML
+//
+// DOC: https://www.ecb.europa.eu/stats/policy_and_exchange_rates/euro_reference_exchange_rates/html/index.en.html
+const ecbDailyURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// ecbHistoryURL: 欧州中央銀行が公表する直近90日分の参照レートの XML
+const ecbHistoryURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml"
+
+// ECBProvider: 欧州中央銀行の参照レートから為替レートを取得する Provider
+//
+// 参照レートは 1 EUR あたりの各通貨の額のため、基軸通貨に合わせて換算する
+type ECBProvider struct {
+	HTTPClient *http.Client
+	URL        string
+	HistoryURL string // 過去の参照レートの XML (直近90日分)
+	Retry      retry.Policy
+
+	mu      sync.Mutex
+	fetched bool     // 過去の参照レートを取得済みか (公表された参照レートが空の場合も再取得しない)
+	history []ecbDay // 取得済みの過去の参照レート (日付の降順)
+}
+
+var _ HistoricalProvider = (*ECBProvider)(nil)
+
+// NewECBProvider: ECBProvider のコンストラクタ
+func NewECBProvider() *ECBProvider {
+	return &ECBProvider{
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		URL:        ecbDailyURL,
+		HistoryURL: ecbHistoryURL,
+		Retry:      retry.DefaultPolicy(),
+	}
+}
+
+// Name: 取得元の名前
+func (ep *ECBProvider) Name() string {
+	return ProviderECB
+}
+
+// ecbEnvelope: 参照レートの XML の構造
+//
+// <Cube><Cube time="2024-09-02"><Cube currency="USD" rate="1.1061"/>...</Cube></Cube>
+type ecbEnvelope struct {
+	Cube struct {
+		Days []ecbDay `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+type ecbDay struct {
+	Time  string    `xml:"time,attr"`
+	Rates []ecbRate `xml:"Cube"`
+}
+
+type ecbRate struct {
+	Currency string  `xml:"currency,attr"`
+	Rate     float64 `xml:"rate,attr"`
+}
+
+// GetExchangeRates: 参照レートを取得し、基軸通貨1単位あたりの為替レートに換算
+//
+// 公表時刻は XML に含まれないため、基準日の 00:00 (UTC) をタイムスタンプとする
+func (ep *ECBProvider) GetExchangeRates(ctx context.Context, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error) {
+	days, err := ep.fetch(ctx, ep.URL)
+	if err != nil {
+		return nil, err
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("no ecb reference rates are published")
+	}
+
+	return ep.response(days[0], baseCurrencyCode, exchangeCurrencyCodes)
+}
+
+// GetHistoricalExchangeRates: 指定した日付の参照レートを取得し、基軸通貨1単位あたりの為替レートに換算
+//
+// 参照レートは土日と祝日には公表されないため、指定した日付以前で直近に公表された参照レートを使用する。
+// 過去の参照レートは1回の実行で同じ XML を繰り返し取得しないよう、初回の取得結果を保持する。
+func (ep *ECBProvider) GetHistoricalExchangeRates(ctx context.Context, date time.Time, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error) {
+	days, err := ep.historicalDays(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	target := date.Format("2006-01-02")
+	for _, day := range days {
+		// 日付は YYYY-MM-DD 形式のため、文字列の比較で前後を判定できる
+		if day.Time <= target {
+			res, err := ep.response(day, baseCurrencyCode, exchangeCurrencyCodes)
+			if err != nil {
+				return nil, err
+			}
+			res.Approximate = day.Time != target
+			return res, nil
+		}
+	}
+	return nil, fmt.Errorf("no ecb reference rates are published on or before %s", target)
+}
+
+// historicalDays: 過去の参照レートを取得 (取得済みの場合は、空の場合も含めて保持している参照レートを返す)
+func (ep *ECBProvider) historicalDays(ctx context.Context) ([]ecbDay, error) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	if ep.fetched {
+		return ep.history, nil
+	}
+
+	days, err := ep.fetch(ctx, ep.HistoryURL)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(days, func(a, b ecbDay) int {
+		return strings.Compare(b.Time, a.Time)
+	})
+	ep.history = days
+	ep.fetched = true
+	return days, nil
+}
+
+// fetch: 参照レートの XML を取得
+func (ep *ECBProvider) fetch(ctx context.Context, url string) ([]ecbDay, error) {
+	var envelope ecbEnvelope
+	if err := ep.Retry.Do(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := ep.HTTPClient.Do(req)
+		if err != nil {
+			return retry.Retryable(err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			err := fmt.Errorf("unexpected ecb response: status=%d body=%s", resp.StatusCode, string(b))
+			if retry.IsRetryableStatus(resp.StatusCode) {
+				return retry.Retryable(err)
+			}
+			return err
+		}
+
+		if err := xml.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+			return fmt.Errorf("failed to decode ecb reference rates: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get ecb reference rates: %w", err)
+	}
+
+	return envelope.Cube.Days, nil
+}
+
+// response: 1日分の参照レートを、基軸通貨1単位あたりの為替レートのレスポンスに換算
+func (ep *ECBProvider) response(day ecbDay, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error) {
+	date, err := time.Parse("2006-01-02", day.Time)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ecb reference date: %w", err)
+	}
+
+	eurRates := map[string]float64{EUR.String(): 1}
+	for _, r := range day.Rates {
+		eurRates[r.Currency] = r.Rate
+	}
+
+	rates, err := crossRates(eurRates, baseCurrencyCode, exchangeCurrencyCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExchangeRatesResponse{
+		Timestamp: date.Unix(),
+		Base:      baseCurrencyCode,
+		Rates:     rates,
+		Source:    ep.Name(),
+	}, nil
+}
+
+// crossRates: 共通の通貨を基準とした為替レートから、基軸通貨1単位あたりの為替レートを算出
+func crossRates(rates map[string]float64, baseCurrencyCode string, exchangeCurrencyCodes []string) (map[string]float64, error) {
+	base, ok := rates[baseCurrencyCode]
+	if !ok || base <= 0 {
+		return nil, fmt.Errorf("exchange rate for base currency %s is not found", baseCurrencyCode)
+	}
+
+	result := make(map[string]float64, len(exchangeCurrencyCodes))
+	for _, code := range exchangeCurrencyCodes {
+		rate, ok := rates[code]
+		if !ok {
+			return nil, fmt.Errorf("exchange rate for %s is not found", code)
+		}
+		result[code] = rate / base
+	}
+	return result, nil
+}
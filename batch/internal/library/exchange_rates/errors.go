@@ -0,0 +1,61 @@
+package exchange_rates
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Open Exchange Rates API のエラーの種類
+//
+// errors.Is で APIError の種類を判定するために使用する
+var (
+	ErrUnauthorized        = errors.New("exchange rates api: unauthorized")          // App ID が未指定または無効 (401)
+	ErrQuotaExceeded       = errors.New("exchange rates api: quota exceeded")        // プランのリクエスト数の上限を超過 (429)
+	ErrInvalidBaseCurrency = errors.New("exchange rates api: invalid base currency") // 基軸通貨が無効 (400)
+)
+
+// APIError: Open Exchange Rates API のエラーレスポンス
+//
+// DOC: https://docs.openexchangerates.org/reference/errors
+type APIError struct {
+	StatusCode  int    `json:"status"`
+	Message     string `json:"message"`     // エラーの種類 (例: invalid_app_id, not_allowed, invalid_base)
+	Description string `json:"description"` // エラーの詳細
+}
+
+// Error: エラーメッセージを生成
+func (e *APIError) Error() string {
+	return fmt.Sprintf("exchange rates api error: status=%d message=%s description=%s", e.StatusCode, e.Message, e.Description)
+}
+
+// Unwrap: ステータスコードに対応するエラーの種類を返す
+func (e *APIError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusTooManyRequests:
+		return ErrQuotaExceeded
+	case http.StatusBadRequest:
+		if e.Message == "invalid_base" {
+			return ErrInvalidBaseCurrency
+		}
+	}
+	return nil
+}
+
+// parseAPIError: エラーレスポンスの本文から APIError を生成
+//
+// 本文が JSON でない場合も、ステータスコードからエラーの種類を判定できるようにする
+func parseAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{}
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Message == "" {
+		apiErr.Message = http.StatusText(statusCode)
+		apiErr.Description = string(body)
+	}
+
+	// ステータスコードは本文ではなく HTTP レスポンスの値を正とする
+	apiErr.StatusCode = statusCode
+	return apiErr
+}
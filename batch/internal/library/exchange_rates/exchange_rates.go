@@ -5,26 +5,35 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/tamaco489/cost_explorer/batch/internal/configuration"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/retry"
 )
 
 const baseURL string = "https://openexchangerates.org/api"
 
 type IExchangeRatesClient interface {
 	GetExchangeRates(ctx context.Context, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error)
+	GetHistoricalExchangeRates(ctx context.Context, date time.Time, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error)
 }
 
-var _ IExchangeRatesClient = (*ExchangeRatesClient)(nil)
+var (
+	_ IExchangeRatesClient = (*ExchangeRatesClient)(nil)
+	_ HistoricalProvider   = (*ExchangeRatesClient)(nil)
+)
 
 type ExchangeRatesClient struct {
 	AppID          string
 	HTTPClient     *http.Client
 	BaseURL        string
 	BaseCurrencyFn func() string // 基軸通貨を取得する関数
+	Retry          retry.Policy  // 取得に失敗した場合の再送の方針 (未指定の場合は再送しない)
 }
 
 type ExchangeRatesResponse struct {
@@ -33,6 +42,10 @@ type ExchangeRatesResponse struct {
 	Timestamp  int64              `json:"timestamp"`
 	Base       string             `json:"base"`
 	Rates      map[string]float64 `json:"rates"`
+
+	Source      string `json:"-"` // 為替レートの取得元の名前 (Provider.Name)
+	Stale       bool   `json:"-"` // 取得元で失敗したため、期限切れのキャッシュを返したか
+	Approximate bool   `json:"-"` // 指定した日付の為替レートがないため、直近の公表日や固定の為替レートで代用したか
 }
 
 // NewExchangeClient: GetExchangeRates のコンストラクタ
@@ -48,23 +61,42 @@ func NewExchangeClient() (*ExchangeRatesClient, error) {
 		HTTPClient:     &http.Client{Timeout: 10 * time.Second},
 		BaseURL:        baseURL,
 		BaseCurrencyFn: GetBaseCurrency,
+		Retry:          retry.DefaultPolicy(),
 	}
 
 	return client, nil
 }
 
+// Name: 取得元の名前
+func (erc *ExchangeRatesClient) Name() string {
+	return ProviderOpenExchangeRates
+}
+
 // PrepareExchangeRates: GetExchangeRates を実行するにあたっての準備
 //
-// 基軸通貨をUSDに設定し、変換対象通貨をJPYに限定
-func (erc *ExchangeRatesClient) PrepareExchangeRates() (*prepareExchangeRates, error) {
-	baseCurrencyCode := erc.BaseCurrencyFn()
+// 基軸通貨をUSDに設定し、変換対象通貨を指定した通貨に限定
+func (erc *ExchangeRatesClient) PrepareExchangeRates(exchangeCurrencyCodes []string) (*prepareExchangeRates, error) {
+	return Prepare(erc.BaseCurrencyFn(), exchangeCurrencyCodes)
+}
+
+// Prepare: 為替レートを取得するにあたっての準備
+//
+// 指定した基軸通貨を検証し、変換対象通貨を指定した通貨に限定 (基軸通貨は変換不要のため除外する)
+func Prepare(baseCurrencyCode string, exchangeCurrencyCodes []string) (*prepareExchangeRates, error) {
 	if !ExchangeRatesCurrencyCode(baseCurrencyCode).Valid() {
 		return nil, fmt.Errorf("invalid base currency: %s", baseCurrencyCode)
 	}
 
+	codes := make([]string, 0, len(exchangeCurrencyCodes))
+	for _, code := range exchangeCurrencyCodes {
+		if code != baseCurrencyCode && !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+
 	return &prepareExchangeRates{
 		BaseCurrencyCode:      baseCurrencyCode,
-		ExchangeCurrencyCodes: []string{JPY.String()},
+		ExchangeCurrencyCodes: codes,
 	}, nil
 }
 
@@ -75,31 +107,70 @@ type prepareExchangeRates struct {
 
 // GetExchangeRates: 為替レートを取得
 func (erc *ExchangeRatesClient) GetExchangeRates(ctx context.Context, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error) {
+	query := url.Values{}
+	query.Set("base", baseCurrencyCode)
+	query.Set("symbols", strings.Join(exchangeCurrencyCodes, ","))
 
-	symbolsParam := strings.Join(exchangeCurrencyCodes, ",")
-
-	url := fmt.Sprintf("%s/latest.json?app_id=%s&base=%s&symbols=%s", erc.BaseURL, erc.AppID, baseCurrencyCode, symbolsParam)
-
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
+	var ratesResponse ExchangeRatesResponse
+	if err := erc.get(ctx, "latest.json", query, &ratesResponse); err != nil {
+		return nil, fmt.Errorf("failed to get exchange rates: %w", err)
 	}
+	ratesResponse.Source = erc.Name()
 
-	resp, err := erc.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return &ratesResponse, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get exchange rates, status code: %d", resp.StatusCode)
-	}
+// GetHistoricalExchangeRates: 指定した日付の終値の為替レートを取得
+//
+// DOC: https://docs.openexchangerates.org/reference/historical-json
+func (erc *ExchangeRatesClient) GetHistoricalExchangeRates(ctx context.Context, date time.Time, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error) {
+	query := url.Values{}
+	query.Set("base", baseCurrencyCode)
+	query.Set("symbols", strings.Join(exchangeCurrencyCodes, ","))
 
 	var ratesResponse ExchangeRatesResponse
-	decoder := json.NewDecoder(resp.Body)
-	if err := decoder.Decode(&ratesResponse); err != nil {
-		return nil, err
+	if err := erc.get(ctx, fmt.Sprintf("historical/%s.json", date.Format("2006-01-02")), query, &ratesResponse); err != nil {
+		return nil, fmt.Errorf("failed to get historical exchange rates for %s: %w", date.Format("2006-01-02"), err)
 	}
+	ratesResponse.Source = erc.Name()
 
 	return &ratesResponse, nil
 }
+
+// get: API にリクエストを送信し、レスポンスの JSON を v に変換
+//
+// リクエストはコンテキストに紐付けるため、Lambda のタイムアウトやキャンセルで中断される。
+// 通信エラーと 5xx の場合は Retry の方針に従って再送し、エラーレスポンスは APIError に変換する。
+// 429 はプランのリクエスト数の上限を超過したことを表し、時間を置いても解消しないため再送しない。
+func (erc *ExchangeRatesClient) get(ctx context.Context, path string, query url.Values, v any) error {
+	endpoint := fmt.Sprintf("%s/%s?%s", erc.BaseURL, path, query.Encode())
+
+	return erc.Retry.Do(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return err
+		}
+		// App ID をエラーメッセージの URL に含めないため、クエリパラメータではなくヘッダーで指定する
+		req.Header.Set("Authorization", "Token "+erc.AppID)
+
+		resp, err := erc.HTTPClient.Do(req)
+		if err != nil {
+			return retry.Retryable(err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			apiErr := parseAPIError(resp.StatusCode, b)
+			if resp.StatusCode != http.StatusTooManyRequests && retry.IsRetryableStatus(resp.StatusCode) {
+				return retry.Retryable(apiErr)
+			}
+			return apiErr
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		return nil
+	})
+}
@@ -2,14 +2,19 @@ package exchange_rates_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tamaco489/cost_explorer/batch/internal/configuration"
 	"github.com/tamaco489/cost_explorer/batch/internal/library/exchange_rates"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/retry"
 	"github.com/tamaco489/cost_explorer/batch/internal/utils"
 
 	exchange_rates_mock "github.com/tamaco489/cost_explorer/batch/internal/library/exchange_rates/mock"
@@ -42,12 +47,12 @@ func TestPrepareExchangeRates(t *testing.T) {
 			BaseCurrencyFn: exchange_rates.GetBaseCurrency,
 		}
 
-		result, err := client.PrepareExchangeRates()
+		result, err := client.PrepareExchangeRates([]string{"JPY", "EUR", "USD", "JPY"})
 		assert.NoError(t, err)
 
-		// 結果が期待通りであることを確認
+		// 結果が期待通りであることを確認 (基軸通貨と重複した通貨は除外される)
 		assert.Equal(t, exchange_rates.GetBaseCurrency(), result.BaseCurrencyCode)
-		assert.Equal(t, []string{exchange_rates.JPY.String()}, result.ExchangeCurrencyCodes)
+		assert.Equal(t, []string{"JPY", "EUR"}, result.ExchangeCurrencyCodes)
 	})
 
 	t.Run("異常系: 無効な基軸通貨が指定された場合にエラーが発生すること", func(t *testing.T) {
@@ -57,7 +62,7 @@ func TestPrepareExchangeRates(t *testing.T) {
 			},
 		}
 
-		_, err := client.PrepareExchangeRates()
+		_, err := client.PrepareExchangeRates([]string{"JPY"})
 
 		// エラーが返され、内容が期待通りであることを確認
 		assert.Error(t, err)
@@ -97,3 +102,184 @@ func TestGetExchangeRates(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResponse, response)
 }
+
+// TestGetExchangeRates_Retry: 一時的なエラーの場合は再送し、それ以外のエラーの場合は再送しないことをテストします
+func TestGetExchangeRates_Retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int // 送信回数ごとに返すステータスコード (超えた分は最後の値を返す)
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{
+			name:         "正常系: 5xx の場合は再送し、成功したレスポンスを返すこと",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantAttempts: 2,
+		},
+		{
+			name:         "異常系: 401 の場合は再送せずにエラーを返すこと",
+			statuses:     []int{http.StatusUnauthorized},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(attempts.Add(1))
+				status := tt.statuses[min(n, len(tt.statuses))-1]
+				w.WriteHeader(status)
+				if status == http.StatusOK {
+					_, _ = w.Write([]byte(`{"timestamp": 1725148800, "base": "USD", "rates": {"JPY": 146.2}}`))
+				}
+			}))
+			defer server.Close()
+
+			client := exchange_rates.ExchangeRatesClient{
+				AppID:      "test_app_id",
+				HTTPClient: server.Client(),
+				BaseURL:    server.URL,
+				Retry:      retry.Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond},
+			}
+
+			res, err := client.GetExchangeRates(context.Background(), "USD", []string{"JPY"})
+			assert.Equal(t, tt.wantAttempts, attempts.Load())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 146.2, res.Rates["JPY"])
+		})
+	}
+}
+
+// TestGetExchangeRates_APIError: エラーレスポンスの本文からエラーの種類を判定できることをテストします
+func TestGetExchangeRates_APIError(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantErr      error
+		wantMessage  string
+		wantAttempts int32
+	}{
+		{
+			name:         "異常系: 401 の場合は認証エラーを返すこと",
+			status:       http.StatusUnauthorized,
+			body:         `{"error": true, "status": 401, "message": "invalid_app_id", "description": "Invalid App ID provided."}`,
+			wantErr:      exchange_rates.ErrUnauthorized,
+			wantMessage:  "invalid_app_id",
+			wantAttempts: 1,
+		},
+		{
+			name:         "異常系: 429 の場合は再送せずにリクエスト数の上限超過のエラーを返すこと",
+			status:       http.StatusTooManyRequests,
+			body:         `{"error": true, "status": 429, "message": "access_restricted", "description": "Access restricted for repeated over-use."}`,
+			wantErr:      exchange_rates.ErrQuotaExceeded,
+			wantMessage:  "access_restricted",
+			wantAttempts: 1,
+		},
+		{
+			name:         "異常系: 400 かつ invalid_base の場合は基軸通貨が無効なエラーを返すこと",
+			status:       http.StatusBadRequest,
+			body:         `{"error": true, "status": 400, "message": "invalid_base", "description": "Client requested rates for an unsupported base currency."}`,
+			wantErr:      exchange_rates.ErrInvalidBaseCurrency,
+			wantMessage:  "invalid_base",
+			wantAttempts: 1,
+		},
+		{
+			name:         "異常系: 本文が JSON でない場合もステータスコードからエラーの種類を判定すること",
+			status:       http.StatusUnauthorized,
+			body:         `Unauthorized`,
+			wantErr:      exchange_rates.ErrUnauthorized,
+			wantMessage:  "Unauthorized",
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				assert.Equal(t, "Token test_app_id", r.Header.Get("Authorization"))
+				assert.Empty(t, r.URL.Query().Get("app_id"))
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := exchange_rates.ExchangeRatesClient{
+				AppID:      "test_app_id",
+				HTTPClient: server.Client(),
+				BaseURL:    server.URL,
+				Retry:      retry.Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond},
+			}
+
+			_, err := client.GetExchangeRates(context.Background(), "USD", []string{"JPY"})
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			var apiErr *exchange_rates.APIError
+			if assert.ErrorAs(t, err, &apiErr) {
+				assert.Equal(t, tt.status, apiErr.StatusCode)
+				assert.Equal(t, tt.wantMessage, apiErr.Message)
+			}
+			assert.Equal(t, tt.wantAttempts, attempts.Load())
+		})
+	}
+}
+
+// TestGetExchangeRates_Context: コンテキストの期限を超えた場合はレスポンスを待たずにエラーを返すことをテストします
+func TestGetExchangeRates_Context(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := exchange_rates.ExchangeRatesClient{
+		AppID:      "test_app_id",
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		BaseURL:    server.URL,
+		Retry:      retry.DefaultPolicy(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetExchangeRates(ctx, "USD", []string{"JPY"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+// TestGetHistoricalExchangeRates: 指定した日付の為替レートを historical エンドポイントから取得することをテストします
+func TestGetHistoricalExchangeRates(t *testing.T) {
+	var path, query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"timestamp":1733875199,"base":"USD","rates":{"JPY":151.95}}`))
+	}))
+	defer server.Close()
+
+	client := exchange_rates.ExchangeRatesClient{
+		AppID:      "test_app_id",
+		HTTPClient: server.Client(),
+		BaseURL:    server.URL,
+	}
+
+	res, err := client.GetHistoricalExchangeRates(context.Background(), time.Date(2024, 12, 10, 0, 0, 0, 0, time.UTC), "USD", []string{"JPY"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/historical/2024-12-10.json", path)
+	assert.Equal(t, "base=USD&symbols=JPY", query)
+	assert.Equal(t, 151.95, res.Rates["JPY"])
+	assert.Equal(t, exchange_rates.ProviderOpenExchangeRates, res.Source)
+}
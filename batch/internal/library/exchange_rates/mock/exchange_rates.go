@@ -12,6 +12,7 @@ package exchange_rates
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	exchange_rates "github.com/tamaco489/cost_explorer/batch/internal/library/exchange_rates"
 	gomock "go.uber.org/mock/gomock"
@@ -55,3 +56,18 @@ func (mr *MockIExchangeRatesClientMockRecorder) GetExchangeRates(ctx, baseCurren
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRates", reflect.TypeOf((*MockIExchangeRatesClient)(nil).GetExchangeRates), ctx, baseCurrencyCode, exchangeCurrencyCodes)
 }
+
+// GetHistoricalExchangeRates mocks base method.
+func (m *MockIExchangeRatesClient) GetHistoricalExchangeRates(ctx context.Context, date time.Time, baseCurrencyCode string, exchangeCurrencyCodes []string) (*exchange_rates.ExchangeRatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoricalExchangeRates", ctx, date, baseCurrencyCode, exchangeCurrencyCodes)
+	ret0, _ := ret[0].(*exchange_rates.ExchangeRatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoricalExchangeRates indicates an expected call of GetHistoricalExchangeRates.
+func (mr *MockIExchangeRatesClientMockRecorder) GetHistoricalExchangeRates(ctx, date, baseCurrencyCode, exchangeCurrencyCodes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricalExchangeRates", reflect.TypeOf((*MockIExchangeRatesClient)(nil).GetHistoricalExchangeRates), ctx, date, baseCurrencyCode, exchangeCurrencyCodes)
+}
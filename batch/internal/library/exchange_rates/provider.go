@@ -0,0 +1,115 @@
+package exchange_rates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// 為替レートの取得元の名前
+const (
+	ProviderOpenExchangeRates = "openexchangerates"
+	ProviderECB               = "ecb"
+	ProviderStatic            = "static"
+)
+
+// Provider: 為替レートの取得元のインターフェース
+type Provider interface {
+	// Name: 取得元の名前 (レポートに為替レートの取得元として表示する)
+	Name() string
+
+	// GetExchangeRates: 基軸通貨1単位あたりの変換対象通貨の為替レートを取得
+	GetExchangeRates(ctx context.Context, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error)
+}
+
+// HistoricalProvider: 過去の日付の為替レートを取得できる取得元のインターフェース
+type HistoricalProvider interface {
+	Provider
+
+	// GetHistoricalExchangeRates: 指定した日付の基軸通貨1単位あたりの変換対象通貨の為替レートを取得
+	GetHistoricalExchangeRates(ctx context.Context, date time.Time, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error)
+}
+
+// FallbackProvider: 複数の取得元を順に試行し、最初に取得できた為替レートを返す Provider
+type FallbackProvider struct {
+	providers []Provider
+}
+
+var _ HistoricalProvider = (*FallbackProvider)(nil)
+
+// NewFallbackProvider: FallbackProvider のコンストラクタ
+//
+// providers は試行する順に指定する
+func NewFallbackProvider(providers ...Provider) *FallbackProvider {
+	return &FallbackProvider{providers: providers}
+}
+
+// Name: 取得元の名前
+func (fp *FallbackProvider) Name() string {
+	return "fallback"
+}
+
+// GetExchangeRates: 取得元を順に試行して為替レートを取得
+//
+// レスポンスの Source には為替レートを取得できた取得元の名前を設定し、全ての取得元で失敗した場合は全てのエラーをまとめて返す
+func (fp *FallbackProvider) GetExchangeRates(ctx context.Context, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error) {
+	res, err := fallback(ctx, fp.providers, func(p Provider) (*ExchangeRatesResponse, error) {
+		return p.GetExchangeRates(ctx, baseCurrencyCode, exchangeCurrencyCodes)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rates from all providers: %w", err)
+	}
+	return res, nil
+}
+
+// GetHistoricalExchangeRates: 過去の為替レートを取得できる取得元を順に試行して、指定した日付の為替レートを取得
+func (fp *FallbackProvider) GetHistoricalExchangeRates(ctx context.Context, date time.Time, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error) {
+	providers := make([]Provider, 0, len(fp.providers))
+	for _, p := range fp.providers {
+		if _, ok := p.(HistoricalProvider); ok {
+			providers = append(providers, p)
+		}
+	}
+
+	res, err := fallback(ctx, providers, func(p Provider) (*ExchangeRatesResponse, error) {
+		return p.(HistoricalProvider).GetHistoricalExchangeRates(ctx, date, baseCurrencyCode, exchangeCurrencyCodes)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get historical exchange rates for %s from all providers: %w", date.Format("2006-01-02"), err)
+	}
+	return res, nil
+}
+
+// fallback: 取得元を順に試行し、最初に取得できた為替レートを返す
+func fallback(ctx context.Context, providers []Provider, get func(p Provider) (*ExchangeRatesResponse, error)) (*ExchangeRatesResponse, error) {
+	if len(providers) == 0 {
+		return nil, errors.New("no exchange rates providers are configured")
+	}
+
+	var errs []error
+	for i, p := range providers {
+		res, err := get(p)
+		if err == nil {
+			if res.Source == "" {
+				res.Source = p.Name()
+			}
+			return res, nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+		if ctx.Err() != nil {
+			break
+		}
+		if i < len(providers)-1 {
+			slog.WarnContext(ctx, "failed to get exchange rates, falling back to next provider",
+				slog.String("provider", p.Name()),
+				slog.String("next provider", providers[i+1].Name()),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil, errors.Join(errs...)
+}
@@ -0,0 +1,266 @@
+package exchange_rates_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/exchange_rates"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/retry"
+)
+
+// ecbDailyXML: 欧州中央銀行の日次の参照レートの XML (抜粋)
+const ecbDailyXML = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+		<Cube time='2024-09-02'>
+			<Cube currency='USD' rate='1.1061'/>
+			<Cube currency='JPY' rate='161.71'/>
+			<Cube currency='GBP' rate='0.84245'/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+// ecbHistoryXML: 欧州中央銀行の過去の参照レートの XML (抜粋、土日は公表されない)
+const ecbHistoryXML = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<Cube>
+		<Cube time='2024-12-16'>
+			<Cube currency='USD' rate='1.05'/>
+			<Cube currency='JPY' rate='161.7'/>
+		</Cube>
+		<Cube time='2024-12-13'>
+			<Cube currency='USD' rate='1.0'/>
+			<Cube currency='JPY' rate='160.0'/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+// stubProvider: 固定のレスポンスまたはエラーを返す Provider
+type stubProvider struct {
+	name  string
+	res   *exchange_rates.ExchangeRatesResponse
+	err   error
+	calls *int
+}
+
+func (sp stubProvider) Name() string { return sp.name }
+
+func (sp stubProvider) GetExchangeRates(ctx context.Context, baseCurrencyCode string, exchangeCurrencyCodes []string) (*exchange_rates.ExchangeRatesResponse, error) {
+	*sp.calls++
+	return sp.res, sp.err
+}
+
+// TestFallbackProvider: 取得元を順に試行し、最初に取得できた取得元の名前を記録することをテストします
+func TestFallbackProvider(t *testing.T) {
+	t.Run("正常系: 先頭の取得元で失敗した場合は次の取得元の為替レートを返すこと", func(t *testing.T) {
+		var primaryCalls, secondaryCalls, staticCalls int
+		fp := exchange_rates.NewFallbackProvider(
+			stubProvider{name: "primary", err: exchange_rates.ErrQuotaExceeded, calls: &primaryCalls},
+			stubProvider{name: "secondary", res: &exchange_rates.ExchangeRatesResponse{Base: "USD", Rates: map[string]float64{"JPY": 146.2}}, calls: &secondaryCalls},
+			stubProvider{name: "static", calls: &staticCalls},
+		)
+
+		res, err := fp.GetExchangeRates(context.Background(), "USD", []string{"JPY"})
+		assert.NoError(t, err)
+		assert.Equal(t, "secondary", res.Source)
+		assert.Equal(t, 146.2, res.Rates["JPY"])
+		assert.Equal(t, []int{1, 1, 0}, []int{primaryCalls, secondaryCalls, staticCalls})
+	})
+
+	t.Run("異常系: 全ての取得元で失敗した場合は全てのエラーを返すこと", func(t *testing.T) {
+		var calls int
+		fp := exchange_rates.NewFallbackProvider(
+			stubProvider{name: "primary", err: exchange_rates.ErrUnauthorized, calls: &calls},
+			stubProvider{name: "secondary", err: errors.New("connection refused"), calls: &calls},
+		)
+
+		_, err := fp.GetExchangeRates(context.Background(), "USD", []string{"JPY"})
+		assert.ErrorIs(t, err, exchange_rates.ErrUnauthorized)
+		assert.ErrorContains(t, err, "secondary: connection refused")
+		assert.Equal(t, 2, calls)
+	})
+}
+
+// TestFallbackProvider_GetHistoricalExchangeRates: 過去の為替レートを取得できない取得元を除外して試行することをテストします
+func TestFallbackProvider_GetHistoricalExchangeRates(t *testing.T) {
+	var calls int
+	fp := exchange_rates.NewFallbackProvider(
+		stubProvider{name: "latest only", calls: &calls},
+		exchange_rates.NewStaticProvider("USD", map[string]float64{"JPY": 145.5}),
+	)
+
+	date := time.Date(2024, 12, 10, 0, 0, 0, 0, time.UTC)
+	res, err := fp.GetHistoricalExchangeRates(context.Background(), date, "USD", []string{"JPY"})
+	assert.NoError(t, err)
+	assert.Equal(t, exchange_rates.ProviderStatic, res.Source)
+	assert.Equal(t, date.Unix(), res.Timestamp)
+	assert.True(t, res.Approximate)
+	assert.Equal(t, 0, calls)
+}
+
+// TestECBProvider: 1 EUR あたりの参照レートを基軸通貨1単位あたりの為替レートに換算することをテストします
+func TestECBProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(ecbDailyXML))
+	}))
+	defer server.Close()
+
+	ep := &exchange_rates.ECBProvider{HTTPClient: server.Client(), URL: server.URL, Retry: retry.Policy{MaxAttempts: 1}}
+
+	t.Run("正常系: USD を基軸通貨とした為替レートに換算すること", func(t *testing.T) {
+		res, err := ep.GetExchangeRates(context.Background(), "USD", []string{"JPY", "EUR"})
+		assert.NoError(t, err)
+		assert.Equal(t, "USD", res.Base)
+		assert.Equal(t, exchange_rates.ProviderECB, res.Source)
+		assert.InDelta(t, 161.71/1.1061, res.Rates["JPY"], 1e-9)
+		assert.InDelta(t, 1/1.1061, res.Rates["EUR"], 1e-9)
+		assert.Equal(t, time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC).Unix(), res.Timestamp)
+	})
+
+	t.Run("異常系: 参照レートに含まれない通貨を指定した場合はエラーを返すこと", func(t *testing.T) {
+		_, err := ep.GetExchangeRates(context.Background(), "USD", []string{"XYZ"})
+		assert.ErrorContains(t, err, "exchange rate for XYZ is not found")
+	})
+}
+
+// TestStaticProvider: 設定で固定した為替レートを返すことをテストします
+func TestStaticProvider(t *testing.T) {
+	sp := exchange_rates.NewStaticProvider("USD", map[string]float64{"JPY": 145.5})
+	sp.Now = func() time.Time { return time.Date(2024, 9, 2, 9, 0, 0, 0, time.UTC) }
+
+	res, err := sp.GetExchangeRates(context.Background(), "USD", []string{"JPY"})
+	assert.NoError(t, err)
+	assert.Equal(t, 145.5, res.Rates["JPY"])
+	assert.Equal(t, exchange_rates.ProviderStatic, res.Source)
+	assert.Equal(t, time.Date(2024, 9, 2, 9, 0, 0, 0, time.UTC).Unix(), res.Timestamp)
+
+	_, err = sp.GetExchangeRates(context.Background(), "USD", []string{"EUR"})
+	assert.ErrorContains(t, err, "static exchange rate for EUR is not configured")
+
+	_, err = sp.GetExchangeRates(context.Background(), "EUR", []string{"JPY"})
+	assert.Error(t, err)
+}
+
+// TestECBProvider_GetHistoricalExchangeRates: 指定した日付以前で直近に公表された参照レートを使用することをテストします
+func TestECBProvider_GetHistoricalExchangeRates(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(ecbHistoryXML))
+	}))
+	defer server.Close()
+
+	ep := &exchange_rates.ECBProvider{HTTPClient: server.Client(), HistoryURL: server.URL, Retry: retry.Policy{MaxAttempts: 1}}
+
+	tests := []struct {
+		name            string
+		date            time.Time
+		wantRate        float64
+		wantDate        time.Time
+		wantApproximate bool
+		wantErr         bool
+	}{
+		{
+			name:     "正常系: 参照レートが公表された日はその日の参照レートを使用すること",
+			date:     time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC),
+			wantRate: 161.7 / 1.05,
+			wantDate: time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:            "正常系: 土日は直前の金曜日の参照レートを代用すること",
+			date:            time.Date(2024, 12, 15, 0, 0, 0, 0, time.UTC),
+			wantRate:        160.0,
+			wantDate:        time.Date(2024, 12, 13, 0, 0, 0, 0, time.UTC),
+			wantApproximate: true,
+		},
+		{
+			name:    "異常系: 保持している期間より前の日付はエラーを返すこと",
+			date:    time.Date(2024, 12, 12, 0, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := ep.GetHistoricalExchangeRates(context.Background(), tt.date, "USD", []string{"JPY"})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.wantRate, res.Rates["JPY"], 1e-9)
+			assert.Equal(t, tt.wantDate.Unix(), res.Timestamp)
+			assert.Equal(t, tt.wantApproximate, res.Approximate)
+		})
+	}
+
+	// 過去の参照レートは初回の取得結果を使い回すこと
+	assert.Equal(t, 1, requests)
+}
+
+// TestECBProvider_GetHistoricalExchangeRates_Empty: 過去の参照レートが空の場合も取得結果を保持し、再取得しないことをテストします
+func TestECBProvider_GetHistoricalExchangeRates_Empty(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(`<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01"><Cube></Cube></gesmes:Envelope>`))
+	}))
+	defer server.Close()
+
+	ep := &exchange_rates.ECBProvider{HTTPClient: server.Client(), HistoryURL: server.URL, Retry: retry.Policy{MaxAttempts: 1}}
+	for _, day := range []int{13, 14, 15} {
+		_, err := ep.GetHistoricalExchangeRates(context.Background(), time.Date(2024, 12, day, 0, 0, 0, 0, time.UTC), "USD", []string{"JPY"})
+		assert.ErrorContains(t, err, "no ecb reference rates are published on or before")
+	}
+	assert.Equal(t, 1, requests)
+}
+
+// TestCrossRateProvider: USD を基軸通貨とした為替レートから、他の基軸通貨の為替レートを算出することをテストします
+func TestCrossRateProvider(t *testing.T) {
+	sp := exchange_rates.NewStaticProvider("USD", map[string]float64{"JPY": 150, "EUR": 0.9, "GBP": 0.75})
+	sp.Now = func() time.Time { return time.Date(2024, 9, 2, 9, 0, 0, 0, time.UTC) }
+	crp := exchange_rates.NewCrossRateProvider(sp)
+
+	t.Run("正常系: EUR を基軸通貨とした為替レートを算出すること", func(t *testing.T) {
+		res, err := crp.GetExchangeRates(context.Background(), "EUR", []string{"JPY", "USD"})
+		assert.NoError(t, err)
+		assert.Equal(t, "EUR", res.Base)
+		assert.Equal(t, exchange_rates.ProviderStatic, res.Source)
+		assert.InDelta(t, 150/0.9, res.Rates["JPY"], 1e-9)
+		assert.InDelta(t, 1/0.9, res.Rates["USD"], 1e-9)
+		assert.Len(t, res.Rates, 2)
+	})
+
+	t.Run("正常系: USD を基軸通貨とした場合は取得元の為替レートをそのまま返すこと", func(t *testing.T) {
+		res, err := crp.GetExchangeRates(context.Background(), "USD", []string{"JPY"})
+		assert.NoError(t, err)
+		assert.Equal(t, "USD", res.Base)
+		assert.Equal(t, 150.0, res.Rates["JPY"])
+	})
+
+	t.Run("正常系: 指定した日付の為替レートからも算出すること", func(t *testing.T) {
+		date := time.Date(2024, 12, 10, 0, 0, 0, 0, time.UTC)
+		res, err := crp.GetHistoricalExchangeRates(context.Background(), date, "GBP", []string{"EUR"})
+		assert.NoError(t, err)
+		assert.Equal(t, "GBP", res.Base)
+		assert.InDelta(t, 0.9/0.75, res.Rates["EUR"], 1e-9)
+		assert.Equal(t, date.Unix(), res.Timestamp)
+	})
+
+	t.Run("異常系: 取得元が基軸通貨の為替レートを返さない場合はエラーを返すこと", func(t *testing.T) {
+		_, err := crp.GetExchangeRates(context.Background(), "AUD", []string{"JPY"})
+		assert.ErrorContains(t, err, "static exchange rate for AUD is not configured")
+	})
+}
@@ -0,0 +1,74 @@
+package exchange_rates
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// StaticProvider: 設定で固定した為替レートを返す Provider
+//
+// 外部の取得元が全て利用できない場合の最後の手段として使用する
+type StaticProvider struct {
+	BaseCurrencyCode string
+	Rates            map[string]float64 // 基軸通貨1単位あたりの各通貨の額
+	Now              func() time.Time   // タイムスタンプに使用する現在時刻を取得する関数
+}
+
+var _ HistoricalProvider = (*StaticProvider)(nil)
+
+// NewStaticProvider: StaticProvider のコンストラクタ
+func NewStaticProvider(baseCurrencyCode string, rates map[string]float64) *StaticProvider {
+	return &StaticProvider{
+		BaseCurrencyCode: baseCurrencyCode,
+		Rates:            rates,
+		Now:              time.Now,
+	}
+}
+
+// Name: 取得元の名前
+func (sp *StaticProvider) Name() string {
+	return ProviderStatic
+}
+
+// GetExchangeRates: 固定した為替レートを返す
+//
+// 固定したレートには公表時刻がないため、取得した時刻をタイムスタンプとする
+func (sp *StaticProvider) GetExchangeRates(ctx context.Context, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error) {
+	return sp.rates(sp.Now(), baseCurrencyCode, exchangeCurrencyCodes)
+}
+
+// GetHistoricalExchangeRates: 固定した為替レートを指定した日付の為替レートとして返す
+//
+// 指定した日付に公表された為替レートではないため、レスポンスの Approximate を true にする
+func (sp *StaticProvider) GetHistoricalExchangeRates(ctx context.Context, date time.Time, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error) {
+	res, err := sp.rates(date, baseCurrencyCode, exchangeCurrencyCodes)
+	if err != nil {
+		return nil, err
+	}
+	res.Approximate = true
+	return res, nil
+}
+
+// rates: 固定した為替レートから、指定した時刻をタイムスタンプとするレスポンスを生成
+func (sp *StaticProvider) rates(timestamp time.Time, baseCurrencyCode string, exchangeCurrencyCodes []string) (*ExchangeRatesResponse, error) {
+	if baseCurrencyCode != sp.BaseCurrencyCode {
+		return nil, fmt.Errorf("static exchange rates are pinned to base currency %s, but %s is requested", sp.BaseCurrencyCode, baseCurrencyCode)
+	}
+
+	rates := make(map[string]float64, len(exchangeCurrencyCodes))
+	for _, code := range exchangeCurrencyCodes {
+		rate, ok := sp.Rates[code]
+		if !ok || rate <= 0 {
+			return nil, fmt.Errorf("static exchange rate for %s is not configured", code)
+		}
+		rates[code] = rate
+	}
+
+	return &ExchangeRatesResponse{
+		Timestamp: timestamp.Unix(),
+		Base:      baseCurrencyCode,
+		Rates:     rates,
+		Source:    sp.Name(),
+	}, nil
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/library/pagerduty/pagerduty.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/library/pagerduty/pagerduty.go -destination=./internal/library/pagerduty/mock/pagerduty.go -package=pagerduty
+//
+
+// Package pagerduty is a generated GoMock package.
+package pagerduty
+
+import (
+	context "context"
+	reflect "reflect"
+
+	pagerduty "github.com/tamaco489/cost_explorer/batch/internal/library/pagerduty"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIPagerDutyClient is a mock of IPagerDutyClient interface.
+type MockIPagerDutyClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPagerDutyClientMockRecorder
+	isgomock struct{}
+}
+
+// MockIPagerDutyClientMockRecorder is the mock recorder for MockIPagerDutyClient.
+type MockIPagerDutyClientMockRecorder struct {
+	mock *MockIPagerDutyClient
+}
+
+// NewMockIPagerDutyClient creates a new mock instance.
+func NewMockIPagerDutyClient(ctrl *gomock.Controller) *MockIPagerDutyClient {
+	mock := &MockIPagerDutyClient{ctrl: ctrl}
+	mock.recorder = &MockIPagerDutyClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPagerDutyClient) EXPECT() *MockIPagerDutyClientMockRecorder {
+	return m.recorder
+}
+
+// SendEvent mocks base method.
+func (m *MockIPagerDutyClient) SendEvent(ctx context.Context, event pagerduty.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEvent indicates an expected call of SendEvent.
+func (mr *MockIPagerDutyClientMockRecorder) SendEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEvent", reflect.TypeOf((*MockIPagerDutyClient)(nil).SendEvent), ctx, event)
+}
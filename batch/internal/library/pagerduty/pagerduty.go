@@ -0,0 +1,154 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/retry"
+)
+
+// eventsURL は、PagerDuty Events API v2 のエンドポイントです。
+//
+// DOC: https://developer.pagerduty.com/docs/events-api-v2/trigger-events/
+const eventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// EventAction は、イベントの種類を表す文字列型です。
+type EventAction string
+
+// イベントの種類として使用する定数です。
+const (
+	EventActionTrigger EventAction = "trigger"
+	EventActionResolve EventAction = "resolve"
+)
+
+// Severity は、アラートの重要度を表す文字列型です。
+type Severity string
+
+// アラートの重要度として使用する定数です。
+const (
+	SeverityCritical Severity = "critical"
+	SeverityError    Severity = "error"
+	SeverityWarning  Severity = "warning"
+	SeverityInfo     Severity = "info"
+)
+
+// Valid は、PagerDuty が受け付ける重要度かどうかを判定します。
+func (s Severity) Valid() bool {
+	switch s {
+	case SeverityCritical, SeverityError, SeverityWarning, SeverityInfo:
+		return true
+	default:
+		return false
+	}
+}
+
+// Event は、PagerDuty に送信するイベントです。
+//
+// DedupKey が同じイベントは同じインシデントとして扱われ、resolve では trigger と同じ DedupKey を指定します。
+type Event struct {
+	RoutingKey  string      `json:"routing_key"`
+	EventAction EventAction `json:"event_action"`
+	DedupKey    string      `json:"dedup_key"`
+	Payload     *Payload    `json:"payload,omitempty"` // trigger の場合のみ指定
+}
+
+// Payload は、trigger で送信するアラートの内容です。
+type Payload struct {
+	Summary       string         `json:"summary"`
+	Source        string         `json:"source"`
+	Severity      Severity       `json:"severity"`
+	Timestamp     *time.Time     `json:"timestamp,omitempty"`
+	Component     string         `json:"component,omitempty"`
+	Group         string         `json:"group,omitempty"`
+	Class         string         `json:"class,omitempty"`
+	CustomDetails map[string]any `json:"custom_details,omitempty"`
+}
+
+// IPagerDutyClient は、PagerDuty にイベントを送信するためのインターフェースを定義します。
+//
+// GoMockを使用してテスト時にモックを作成するためにこのインターフェースを定義します。
+type IPagerDutyClient interface {
+	// SendEvent は、イベントを PagerDuty に送信するメソッドです。
+	SendEvent(ctx context.Context, event Event) error
+}
+
+var _ IPagerDutyClient = (*pagerDutyClient)(nil)
+
+// defaultTimeout は、PagerDuty へのリクエストのタイムアウトです。
+const defaultTimeout = 10 * time.Second
+
+// pagerDutyClient は、PagerDuty Events API v2 にイベントを送信するための構造体です。
+type pagerDutyClient struct {
+	eventsURL  string
+	httpClient *http.Client
+	retry      retry.Policy
+}
+
+// Option は、pagerDutyClient の設定を変更するための関数です。
+type Option func(*pagerDutyClient)
+
+// WithEventsURL は、イベントの送信先のURLを変更します。
+//
+// テスト時にローカルのサーバーへ送信するために使用します。
+func WithEventsURL(url string) Option {
+	return func(pc *pagerDutyClient) {
+		pc.eventsURL = url
+	}
+}
+
+// NewPagerDutyClient は、PagerDuty クライアントのインスタンスを初期化する関数です。
+func NewPagerDutyClient(opts ...Option) *pagerDutyClient {
+	pc := &pagerDutyClient{
+		eventsURL:  eventsURL,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		retry:      retry.DefaultPolicy(),
+	}
+	for _, opt := range opts {
+		opt(pc)
+	}
+	return pc
+}
+
+// SendEvent は、イベントを PagerDuty に送信するメソッドです。
+//
+// PagerDuty はイベントを受け付けると 202 を返すため、それ以外のステータスコードの場合はエラーを返します。
+// 通信エラー、429、5xx の場合は待機時間を延ばしながら再送します。
+func (pc *pagerDutyClient) SendEvent(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pagerduty event: %w", err)
+	}
+
+	if err := pc.retry.Do(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, pc.eventsURL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create pagerduty request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		res, err := pc.httpClient.Do(req)
+		if err != nil {
+			return retry.Retryable(err)
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusAccepted {
+			b, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+			err := fmt.Errorf("status=%d body=%s", res.StatusCode, string(b))
+			if retry.IsRetryableStatus(res.StatusCode) {
+				return retry.After(err, retry.ParseRetryAfter(res.Header.Get("Retry-After")))
+			}
+			return err
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to send pagerduty %s event: %w", event.EventAction, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// 再送の既定値です。
+//
+// Lambda のタイムアウト (20秒) の中で他の問い合わせや通知を実行する時間を残すため、1回の呼び出しで使用する時間を10秒までとします。
+const (
+	defaultMaxAttempts = 3
+	defaultBaseDelay   = 500 * time.Millisecond
+	defaultMaxDelay    = 5 * time.Second
+	defaultJitter      = 0.5
+	defaultBudget      = 10 * time.Second
+)
+
+// Policy は、外部サービスへのリクエストに失敗した場合の再送の方針です。
+//
+// 待機時間は BaseDelay から再送のたびに2倍に延ばし、MaxDelay を上限として Jitter の割合だけランダムに短縮します。
+// 1回の呼び出しで使用する時間は Budget とコンテキストの期限のうち短い方までとし、待機すると期限を超える場合は再送しません。
+type Policy struct {
+	MaxAttempts int           // 初回を含めた最大の試行回数
+	BaseDelay   time.Duration // 1回目の再送までの待機時間
+	MaxDelay    time.Duration // 再送までの待機時間の上限
+	Jitter      float64       // 待機時間をランダムに短縮する割合 (0 から 1)
+	Budget      time.Duration // 再送を含めた1回の呼び出しで使用できる時間の上限 (0 以下で無制限)
+}
+
+// DefaultPolicy は、既定の再送の方針を返します。
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts: defaultMaxAttempts,
+		BaseDelay:   defaultBaseDelay,
+		MaxDelay:    defaultMaxDelay,
+		Jitter:      defaultJitter,
+		Budget:      defaultBudget,
+	}
+}
+
+// Do は、fn を実行し、再送可能なエラーを返した場合は待機して再実行します。
+//
+// fn には Budget を期限として設定したコンテキストを渡し、Retryable または After でラップしたエラーのみを再送の対象とします。
+func (p Policy) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if p.Budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Budget)
+		defer cancel()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		var re *retryableError
+		if !errors.As(err, &re) || attempt >= max(p.MaxAttempts, 1) {
+			return fmt.Errorf("attempt %d: %w", attempt, err)
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("attempt %d: %w", attempt, errors.Join(err, ctx.Err()))
+		}
+
+		delay := p.backoff(attempt)
+		if re.after > 0 {
+			delay = re.after
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return fmt.Errorf("attempt %d, no time left to retry: %w", attempt, err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("attempt %d: %w", attempt, errors.Join(err, ctx.Err()))
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff は、再送の回数に応じて待機時間を2倍ずつ延ばし、上限で丸めた上でランダムに短縮します。
+func (p Policy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	jitter := min(max(p.Jitter, 0), 1)
+	if jitter == 0 || delay <= 0 {
+		return delay
+	}
+	return delay - time.Duration(rand.Float64()*jitter*float64(delay))
+}
+
+// retryableError は、再送によって成功する可能性があるエラーです。
+type retryableError struct {
+	err   error
+	after time.Duration // 再送までの待機時間の指定 (0 の場合は Policy の待機時間)
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// Retryable は、err を再送可能なエラーとしてラップします。
+func Retryable(err error) error {
+	return &retryableError{err: err}
+}
+
+// After は、再送までの待機時間を指定して err を再送可能なエラーとしてラップします。
+//
+// レート制限のレスポンスなど、外部サービスから待機時間が指定された場合に使用します。
+func After(err error, after time.Duration) error {
+	return &retryableError{err: err, after: max(after, 0)}
+}
+
+// IsRetryableStatus は、再送によって成功する可能性があるステータスコードかどうかを判定します。
+func IsRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	default:
+		return statusCode >= http.StatusInternalServerError && statusCode != http.StatusNotImplemented && statusCode != http.StatusHTTPVersionNotSupported
+	}
+}
+
+// IsUnprocessedStatus は、外部サービスがリクエストを処理していないことが明らかなステータスコード (429, 503) かどうかを判定します。
+//
+// メッセージの投稿など冪等でないリクエストは、他の 5xx では処理済みの可能性があり再送すると重複するため、このステータスコードの場合のみ再送します。
+func IsUnprocessedStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// IsConnectError は、名前解決や TCP 接続など、接続の確立に失敗した通信エラーかどうかを判定します。
+//
+// リクエストを送信する前に失敗しているため、冪等でないリクエストでも重複せずに再送できます。送信後の読み込みのタイムアウトなどは含みません。
+func IsConnectError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// IsNetworkError は、接続の失敗やタイムアウトなどの通信エラーかどうかを判定します。
+//
+// コンテキストのキャンセルによるエラーも含まれるため、再送するかどうかは Do がコンテキストの状態を確認して判断します。
+func IsNetworkError(err error) bool {
+	var ne net.Error
+	return errors.As(err, &ne) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// ParseRetryAfter は、Retry-After ヘッダーの値 (秒数または HTTP 日付) を待機時間に変換します。
+func ParseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
@@ -0,0 +1,151 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/retry"
+)
+
+// TestPolicy_Do: 再送可能なエラーの場合のみ最大の試行回数まで再実行することをテストします
+func TestPolicy_Do(t *testing.T) {
+	errTemporary := errors.New("temporary error")
+	errPermanent := errors.New("permanent error")
+
+	tests := []struct {
+		name         string
+		errs         []error // 試行ごとに返すエラー (超えた分は nil を返す)
+		wantAttempts int
+		wantErr      error
+	}{
+		{
+			name:         "正常系: 再送可能なエラーの場合は成功するまで再実行する",
+			errs:         []error{retry.Retryable(errTemporary), retry.Retryable(errTemporary)},
+			wantAttempts: 3,
+		},
+		{
+			name:         "異常系: 再送できないエラーの場合は再実行せずにエラーを返す",
+			errs:         []error{errPermanent},
+			wantAttempts: 1,
+			wantErr:      errPermanent,
+		},
+		{
+			name:         "異常系: 最大の試行回数に達した場合は最後のエラーを返す",
+			errs:         []error{retry.Retryable(errTemporary), retry.Retryable(errTemporary), retry.Retryable(errTemporary), retry.Retryable(errTemporary)},
+			wantAttempts: 3,
+			wantErr:      errTemporary,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := retry.Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, Jitter: 0.5}
+
+			attempts := 0
+			err := policy.Do(context.Background(), func(ctx context.Context) error {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return tt.errs[attempts-1]
+				}
+				return nil
+			})
+
+			assert.Equal(t, tt.wantAttempts, attempts)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestPolicy_DoDeadline: 待機時間がコンテキストの期限や Budget を超える場合は再送しないことをテストします
+func TestPolicy_DoDeadline(t *testing.T) {
+	t.Run("異常系: 指定された待機時間がコンテキストの期限を超える場合は再送しない", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		attempts := 0
+		err := retry.DefaultPolicy().Do(ctx, func(ctx context.Context) error {
+			attempts++
+			return retry.After(errors.New("rate limited"), time.Minute)
+		})
+		assert.ErrorContains(t, err, "no time left to retry")
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("異常系: Budget を期限としたコンテキストを渡し、期限を超える場合は再送しない", func(t *testing.T) {
+		policy := retry.Policy{MaxAttempts: 5, BaseDelay: 200 * time.Millisecond, MaxDelay: time.Second, Budget: 100 * time.Millisecond}
+
+		attempts := 0
+		err := policy.Do(context.Background(), func(ctx context.Context) error {
+			attempts++
+			deadline, ok := ctx.Deadline()
+			assert.True(t, ok)
+			assert.LessOrEqual(t, time.Until(deadline), 100*time.Millisecond)
+			return retry.Retryable(errors.New("temporary error"))
+		})
+		assert.ErrorContains(t, err, "no time left to retry")
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+// TestIsRetryableStatus: 再送の対象とするステータスコードを判定することをテストします
+func TestIsRetryableStatus(t *testing.T) {
+	for status, want := range map[int]bool{
+		http.StatusOK:                  false,
+		http.StatusBadRequest:          false,
+		http.StatusUnauthorized:        false,
+		http.StatusRequestTimeout:      true,
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: true,
+		http.StatusNotImplemented:      false,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusGatewayTimeout:      true,
+	} {
+		assert.Equal(t, want, retry.IsRetryableStatus(status), "status=%d", status)
+	}
+}
+
+// TestIsUnprocessedStatus: 冪等でないリクエストを再送できるステータスコードを判定することをテストします
+func TestIsUnprocessedStatus(t *testing.T) {
+	for status, want := range map[int]bool{
+		http.StatusOK:                  false,
+		http.StatusRequestTimeout:      false,
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: false,
+		http.StatusBadGateway:          false,
+		http.StatusServiceUnavailable:  true,
+		http.StatusGatewayTimeout:      false,
+	} {
+		assert.Equal(t, want, retry.IsUnprocessedStatus(status), "status=%d", status)
+	}
+}
+
+// TestIsConnectError: 接続の確立に失敗したエラーのみを判定し、送信後のタイムアウトは含まないことをテストします
+func TestIsConnectError(t *testing.T) {
+	assert.True(t, retry.IsConnectError(&url.Error{Op: "Post", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}))
+	assert.True(t, retry.IsConnectError(&url.Error{Op: "Post", Err: &net.DNSError{Err: "no such host", Name: "hooks.slack.com"}}))
+	assert.False(t, retry.IsConnectError(&url.Error{Op: "Post", Err: &net.OpError{Op: "read", Err: errors.New("i/o timeout")}}))
+	assert.False(t, retry.IsConnectError(io.ErrUnexpectedEOF))
+}
+
+// TestParseRetryAfter: Retry-After ヘッダーの秒数と HTTP 日付を待機時間に変換することをテストします
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, 3*time.Second, retry.ParseRetryAfter("3"))
+	assert.Equal(t, time.Duration(0), retry.ParseRetryAfter(""))
+	assert.Equal(t, time.Duration(0), retry.ParseRetryAfter("invalid"))
+
+	d := retry.ParseRetryAfter(time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat))
+	assert.Greater(t, d, 8*time.Second)
+	assert.LessOrEqual(t, d, 10*time.Second)
+}
@@ -0,0 +1,203 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/slack-go/slack"
+)
+
+// Block は、slackのBlock Kitのブロックを表す型です。
+//
+// 呼び出し側が slack-go のパッケージを直接参照せずにブロックを扱えるように、別名として定義します。
+type Block = slack.Block
+
+// Color は、強調表示するブロックの左側に表示されるバーの色を表す文字列型です。
+type Color string
+
+// 強調表示のバーの色として使用する定数です。
+const (
+	// ColorGood は、正常な値であることを表す緑色のバーです。
+	ColorGood Color = "good"
+
+	// ColorWarning は、注意が必要な値であることを表す黄色のバーです。
+	ColorWarning Color = "warning"
+
+	// ColorDanger は、閾値を超えた値であることを表す赤色のバーです。
+	ColorDanger Color = "danger"
+)
+
+// BlockMessage は、Block Kitで構成するメッセージを表す構造体です。
+//
+// Blocks はメッセージの本文（サマリー）として、Highlights は色付きのバーを伴って本文の下に表示されます。
+// Details はサービス別・アカウント別・タグ別などの内訳で、Bot トークンのクライアントではスレッドへの返信として、
+// Webhook のクライアントでは本文の末尾に区切り線を挟んで表示されます。
+type BlockMessage struct {
+	Blocks     []Block
+	Highlights []Highlight
+	Details    [][]Block
+}
+
+// flatten は、内訳を本文の末尾に連結したブロックを返します。
+func (bm BlockMessage) flatten() []Block {
+	blocks := append([]Block{}, bm.Blocks...)
+	for _, detail := range bm.Details {
+		blocks = append(blocks, DividerBlock())
+		blocks = append(blocks, detail...)
+	}
+	return blocks
+}
+
+// highlightAttachments は、強調表示するブロックを色付きのバーを表示するための添付ファイルに変換します。
+func (bm BlockMessage) highlightAttachments() []slack.Attachment {
+	attachments := make([]slack.Attachment, 0, len(bm.Highlights))
+	for _, h := range bm.Highlights {
+		attachments = append(attachments, slack.Attachment{
+			Color:  string(h.Color),
+			Blocks: slack.Blocks{BlockSet: h.Blocks},
+		})
+	}
+	return attachments
+}
+
+// Highlight は、閾値を超えた値などを色付きのバーで強調表示するためのブロックの集まりです。
+type Highlight struct {
+	Color  Color
+	Blocks []Block
+}
+
+// maxSectionFields は、1つのセクションブロックに表示できるフィールド数の上限です。
+const maxSectionFields = 10
+
+// Field は、セクションのフィールドに表示する項目名と値の組です。
+type Field struct {
+	Label string
+	Value string
+}
+
+// HeaderBlock は、メッセージの見出しを表示するブロックを生成します。
+func HeaderBlock(text string) Block {
+	return slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, text, true, false))
+}
+
+// TextBlock は、mrkdwn形式のテキストを表示するセクションブロックを生成します。
+func TextBlock(text string) Block {
+	return slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil)
+}
+
+// FieldsBlock は、項目名と値の組を2列で表示するセクションブロックを生成します。
+//
+// Slackの仕様上、1つのセクションに表示できるフィールドは10個までのため、超えた分は表示しません。
+func FieldsBlock(fields ...Field) Block {
+	if len(fields) > maxSectionFields {
+		fields = fields[:maxSectionFields]
+	}
+
+	objects := make([]*slack.TextBlockObject, 0, len(fields))
+	for _, f := range fields {
+		objects = append(objects, slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*%s*\n%s", f.Label, f.Value), false, false))
+	}
+	return slack.NewSectionBlock(nil, objects, nil)
+}
+
+// TableBlock は、見出しと表をコードブロックとして表示するセクションブロックを生成します。
+func TableBlock(title string, table Table) Block {
+	return TextBlock(fmt.Sprintf("*%s*\n```\n%s```", title, table.String()))
+}
+
+// ContextBlock は、メッセージの補足情報を小さな文字で表示するコンテキストブロックを生成します。
+func ContextBlock(text string) Block {
+	return slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType, text, false, false))
+}
+
+// DividerBlock は、ブロックの間に区切り線を表示するブロックを生成します。
+func DividerBlock() Block {
+	return slack.NewDividerBlock()
+}
+
+// ChangeEmoji は、比較対象に対する割合（%）から増減を表す絵文字を返します。
+//
+// 100% を超える場合は増加、100% 未満の場合は減少、100% の場合は横ばいとして扱います。
+func ChangeEmoji(percentage float64) string {
+	switch {
+	case percentage > 100:
+		return ":arrow_upper_right:"
+	case percentage < 100:
+		return ":arrow_lower_right:"
+	default:
+		return ":arrow_right:"
+	}
+}
+
+// Table は、コードブロック内に等幅で表示する表を表す構造体です。
+//
+// 1列目は左揃え、2列目以降は右揃えで表示し、全角文字は半角2文字分の幅として桁を揃えます。
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// String は、表の各列の幅を揃えた文字列を生成します。
+func (t Table) String() string {
+	widths := make([]int, len(t.Header))
+	for i, h := range t.Header {
+		widths[i] = displayWidth(h)
+	}
+	for _, row := range t.Rows {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], displayWidth(cell))
+			}
+		}
+	}
+
+	var sb strings.Builder
+	writeRow := func(cells []string) {
+		for i := range widths {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			padding := strings.Repeat(" ", widths[i]-displayWidth(cell))
+			if i > 0 {
+				sb.WriteString("  ")
+				sb.WriteString(padding)
+				sb.WriteString(cell)
+				continue
+			}
+			sb.WriteString(cell)
+			if len(widths) > 1 {
+				sb.WriteString(padding)
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	writeRow(t.Header)
+	for _, row := range t.Rows {
+		writeRow(row)
+	}
+	return sb.String()
+}
+
+// displayWidth は、等幅フォントで表示した場合の文字列の幅を算出します。
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		if isWide(r) {
+			width += 2
+			continue
+		}
+		width++
+	}
+	return width
+}
+
+// isWide は、全角で表示される文字かどうかを判定します。
+func isWide(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		(r >= 0x3000 && r <= 0x30FF) || // CJKの記号及び句読点、ひらがな、カタカナ (長音記号を含む)
+		(r >= 0xFF01 && r <= 0xFF60) || // 全角英数字・記号
+		(r >= 0xFFE0 && r <= 0xFFE6)
+}
@@ -0,0 +1,88 @@
+package slack_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/slack"
+)
+
+// TestTable_String: 全角文字を含む表の桁が揃うことをテストします
+func TestTable_String(t *testing.T) {
+	table := slack.Table{
+		Header: []string{"サービス", "(円)"},
+		Rows: [][]string{
+			{"1. Amazon EC2", "1234.50"},
+			{"2. 請求", "8.00"},
+		},
+	}
+
+	expected := "" +
+		"サービス          (円)\n" +
+		"1. Amazon EC2  1234.50\n" +
+		"2. 請求           8.00\n"
+	assert.Equal(t, expected, table.String())
+}
+
+// TestChangeEmoji: 比較対象に対する割合から増減を表す絵文字が返却されることをテストします
+func TestChangeEmoji(t *testing.T) {
+	tests := []struct {
+		name       string
+		percentage float64
+		expected   string
+	}{
+		{name: "正常系: 100% を超える場合は増加を表すこと", percentage: 120.5, expected: ":arrow_upper_right:"},
+		{name: "正常系: 100% 未満の場合は減少を表すこと", percentage: 80, expected: ":arrow_lower_right:"},
+		{name: "正常系: 100% の場合は横ばいを表すこと", percentage: 100, expected: ":arrow_right:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, slack.ChangeEmoji(tt.percentage))
+		})
+	}
+}
+
+// TestSendBlocks: ブロックと強調表示が Webhook に送信され、内訳が本文の末尾に連結されることをテストします
+func TestSendBlocks(t *testing.T) {
+	var payload struct {
+		Text        string           `json:"text"`
+		Blocks      []map[string]any `json:"blocks"`
+		Attachments []struct {
+			Color  string           `json:"color"`
+			Blocks []map[string]any `json:"blocks"`
+		} `json:"attachments"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sc := slack.NewSlackClient(server.URL, "cost-explorer")
+	err := sc.SendBlocks(context.Background(), slack.WeeklyReportTitle.String(), slack.BlockMessage{
+		Blocks: []slack.Block{slack.HeaderBlock("AWS 週次利用コストレポート"), slack.DividerBlock()},
+		Highlights: []slack.Highlight{
+			{Color: slack.ColorDanger, Blocks: []slack.Block{slack.TextBlock("threshold exceeded")}},
+		},
+		Details: [][]slack.Block{
+			{slack.TextBlock("service breakdown")},
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "weekly-cost-report", payload.Text)
+	assert.Len(t, payload.Blocks, 4)
+	assert.Equal(t, "header", payload.Blocks[0]["type"])
+	assert.Equal(t, "divider", payload.Blocks[1]["type"])
+	assert.Equal(t, "divider", payload.Blocks[2]["type"])
+	assert.Equal(t, "section", payload.Blocks[3]["type"])
+	assert.Len(t, payload.Attachments, 1)
+	assert.Equal(t, "danger", payload.Attachments[0].Color)
+	assert.Equal(t, "section", payload.Attachments[0].Blocks[0]["type"])
+}
@@ -0,0 +1,114 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/retry"
+)
+
+var _ ISlackClient = (*botClient)(nil)
+
+// botClient は、Bot トークンを使用して Slack の Web API (chat.postMessage) でメッセージを送信するための構造体です。
+//
+// Webhook と異なり、投稿したメッセージのタイムスタンプを利用してスレッドに返信できます。
+type botClient struct {
+	client    *slack.Client
+	channelID string
+	userName  string
+	retry     retry.Policy
+}
+
+// BotClientOption は、botClient の生成時に任意の設定を行うための関数型です。
+type BotClientOption func(*botClientOptions)
+
+type botClientOptions struct {
+	apiURL string
+}
+
+// WithAPIURL は、Slack の Web API の接続先を変更するオプションです。
+//
+// テスト時にローカルのサーバーへ接続するために使用します。
+func WithAPIURL(apiURL string) BotClientOption {
+	return func(o *botClientOptions) {
+		o.apiURL = apiURL
+	}
+}
+
+// NewBotClient は、Bot トークンを使用する Slack クライアントのインスタンスを初期化する関数です。
+//
+// Bot トークン、投稿先のチャンネルID、ユーザー名を引数として受け取り、それらを基に botClient を返します。
+func NewBotClient(token, channelID, userName string, opts ...BotClientOption) *botClient {
+	var o botClientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var clientOpts []slack.Option
+	if o.apiURL != "" {
+		clientOpts = append(clientOpts, slack.OptionAPIURL(o.apiURL))
+	}
+
+	return &botClient{
+		client:    slack.New(token, clientOpts...),
+		channelID: channelID,
+		userName:  userName,
+		retry:     retry.DefaultPolicy(),
+	}
+}
+
+// SendMessage は、Slackにメッセージを送信するメソッドです。
+//
+// 指定されたタイトルと添付ファイルを含むメッセージをチャンネルに投稿します。
+func (bc *botClient) SendMessage(ctx context.Context, title string, attachment Attachment) error {
+	if _, err := bc.postMessage(ctx,
+		slack.MsgOptionUsername(bc.userName),
+		slack.MsgOptionText(title, false),
+		slack.MsgOptionAttachments(slack.Attachment(attachment)),
+	); err != nil {
+		return fmt.Errorf("failed to post slack message: %w", err)
+	}
+	return nil
+}
+
+// SendBlocks は、Block Kitで構成したメッセージをSlackに送信するメソッドです。
+//
+// サマリーと強調表示するブロックを親メッセージとして投稿し、内訳はそれぞれ親メッセージのスレッドに返信します。
+// 再送は投稿ごとに行うため、スレッドへの返信に失敗しても親メッセージを重複して投稿しません。
+func (bc *botClient) SendBlocks(ctx context.Context, title string, message BlockMessage) error {
+	ts, err := bc.postMessage(ctx,
+		slack.MsgOptionUsername(bc.userName),
+		slack.MsgOptionText(title, false),
+		slack.MsgOptionBlocks(message.Blocks...),
+		slack.MsgOptionAttachments(message.highlightAttachments()...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to post slack message: %w", err)
+	}
+
+	for i, detail := range message.Details {
+		if _, err := bc.postMessage(ctx,
+			slack.MsgOptionUsername(bc.userName),
+			slack.MsgOptionText(title, false),
+			slack.MsgOptionBlocks(detail...),
+			slack.MsgOptionTS(ts),
+		); err != nil {
+			return fmt.Errorf("failed to post slack thread reply %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// postMessage は、チャンネルにメッセージを投稿し、投稿したメッセージのタイムスタンプを返します。
+//
+// レート制限 (429)、503、接続の確立に失敗した場合のみ再送し、投稿が重複する可能性がある場合は再送しません。
+func (bc *botClient) postMessage(ctx context.Context, options ...slack.MsgOption) (string, error) {
+	var ts string
+	err := bc.retry.Do(ctx, func(ctx context.Context) (err error) {
+		_, ts, err = bc.client.PostMessageContext(ctx, bc.channelID, options...)
+		return retryable(err)
+	})
+	return ts, err
+}
@@ -0,0 +1,109 @@
+package slack_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/slack"
+)
+
+// postedMessage: chat.postMessage に送信されたメッセージ
+type postedMessage struct {
+	channel  string
+	threadTS string
+	blocks   string
+}
+
+// TestBotClient_SendBlocks: サマリーを親メッセージとして投稿し、内訳をスレッドに返信することをテストします
+func TestBotClient_SendBlocks(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		messages []postedMessage
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat.postMessage", r.URL.Path)
+		assert.NoError(t, r.ParseForm())
+
+		mu.Lock()
+		messages = append(messages, postedMessage{
+			channel:  r.FormValue("channel"),
+			threadTS: r.FormValue("thread_ts"),
+			blocks:   r.FormValue("blocks"),
+		})
+		ts := fmt.Sprintf("1700000000.00000%d", len(messages))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"ok": true, "channel": "C0123456789", "ts": "%s"}`, ts)
+	}))
+	defer server.Close()
+
+	bc := slack.NewBotClient("xoxb-test", "C0123456789", "cost-explorer", slack.WithAPIURL(server.URL+"/"))
+	err := bc.SendBlocks(context.Background(), slack.DailyReportTitle.String(), slack.BlockMessage{
+		Blocks: []slack.Block{slack.HeaderBlock("summary")},
+		Details: [][]slack.Block{
+			{slack.TextBlock("service breakdown")},
+			{slack.TextBlock("account breakdown")},
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, messages, 3)
+	for _, m := range messages {
+		assert.Equal(t, "C0123456789", m.channel)
+	}
+	assert.Empty(t, messages[0].threadTS)
+	assert.Contains(t, messages[0].blocks, "summary")
+	assert.Equal(t, "1700000000.000001", messages[1].threadTS)
+	assert.Contains(t, messages[1].blocks, "service breakdown")
+	assert.Equal(t, "1700000000.000001", messages[2].threadTS)
+	assert.Contains(t, messages[2].blocks, "account breakdown")
+}
+
+// TestBotClient_SendBlocksError: 親メッセージの投稿に失敗した場合はエラーを返すことをテストします
+func TestBotClient_SendBlocksError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"ok": false, "error": "channel_not_found"}`)
+	}))
+	defer server.Close()
+
+	bc := slack.NewBotClient("xoxb-test", "C0123456789", "cost-explorer", slack.WithAPIURL(server.URL+"/"))
+	err := bc.SendBlocks(context.Background(), slack.DailyReportTitle.String(), slack.BlockMessage{
+		Blocks: []slack.Block{slack.HeaderBlock("summary")},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "channel_not_found")
+}
+
+// TestBotClient_SendBlocksRateLimited: レート制限を超えた場合 (429) は Retry-After の秒数だけ待機して同じ投稿を再送することをテストします
+func TestBotClient_SendBlocksRateLimited(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"ok": true, "channel": "C0123456789", "ts": "1725148800.000100"}`)
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	bc := slack.NewBotClient("xoxb-test", "C0123456789", "cost-explorer", slack.WithAPIURL(server.URL+"/"))
+	err := bc.SendBlocks(context.Background(), slack.DailyReportTitle.String(), slack.BlockMessage{
+		Blocks: []slack.Block{slack.HeaderBlock("summary")},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), attempts.Load())
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
@@ -41,6 +41,20 @@ func (m *MockISlackClient) EXPECT() *MockISlackClientMockRecorder {
 	return m.recorder
 }
 
+// SendBlocks mocks base method.
+func (m *MockISlackClient) SendBlocks(ctx context.Context, title string, message slack.BlockMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBlocks", ctx, title, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendBlocks indicates an expected call of SendBlocks.
+func (mr *MockISlackClientMockRecorder) SendBlocks(ctx, title, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBlocks", reflect.TypeOf((*MockISlackClient)(nil).SendBlocks), ctx, title, message)
+}
+
 // SendMessage mocks base method.
 func (m *MockISlackClient) SendMessage(ctx context.Context, title string, attachment slack.Attachment) error {
 	m.ctrl.T.Helper()
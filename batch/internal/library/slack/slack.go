@@ -2,9 +2,11 @@ package slack
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/slack-go/slack"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/retry"
 )
 
 // Attachment はslackのAttachment型をラップした型です。
@@ -19,6 +21,10 @@ type ISlackClient interface {
 	// SendMessage は、Slackにメッセージを送信するメソッドです。
 	// 引数として、コンテキスト、メッセージのタイトル、添付ファイル（Attachment）を受け取ります。
 	SendMessage(ctx context.Context, title string, attachment Attachment) error
+
+	// SendBlocks は、Block Kitで構成したメッセージをSlackに送信するメソッドです。
+	// 引数として、コンテキスト、メッセージのタイトル、ブロックで構成したメッセージ（BlockMessage）を受け取ります。
+	SendBlocks(ctx context.Context, title string, message BlockMessage) error
 }
 
 var _ ISlackClient = (*slackClient)(nil)
@@ -29,6 +35,7 @@ var _ ISlackClient = (*slackClient)(nil)
 type slackClient struct {
 	webhookURL string
 	userName   string
+	retry      retry.Policy
 }
 
 // NewSlackClient は、Slackクライアントのインスタンスを初期化する関数です。
@@ -38,6 +45,7 @@ func NewSlackClient(webhookURL string, userName string) *slackClient {
 	return &slackClient{
 		webhookURL: webhookURL,
 		userName:   userName,
+		retry:      retry.DefaultPolicy(),
 	}
 }
 
@@ -45,18 +53,62 @@ func NewSlackClient(webhookURL string, userName string) *slackClient {
 //
 // Webhook URL とユーザー名を使って、指定されたタイトルと添付ファイルを含むメッセージを送信します。
 func (sc *slackClient) SendMessage(ctx context.Context, title string, attachment Attachment) error {
-	if err := slack.PostWebhookContext(ctx, sc.webhookURL, &slack.WebhookMessage{
+	msg := &slack.WebhookMessage{
 		Username: sc.userName,
 		Text:     title,
 		Attachments: []slack.Attachment{
 			slack.Attachment(attachment),
 		},
+	}
+	if err := sc.retry.Do(ctx, func(ctx context.Context) error {
+		return retryable(slack.PostWebhookContext(ctx, sc.webhookURL, msg))
 	}); err != nil {
 		return fmt.Errorf("failed to send slack message: %w", err)
 	}
 	return nil
 }
 
+// SendBlocks は、Block Kitで構成したメッセージをSlackに送信するメソッドです。
+//
+// タイトルは通知やBlock Kitに対応していないクライアントで表示されるテキストとして送信し、
+// 強調表示するブロックは色付きのバーを表示するために添付ファイルとして送信します。
+// Webhook ではスレッドに返信できないため、内訳は本文の末尾に連結して送信します。
+func (sc *slackClient) SendBlocks(ctx context.Context, title string, message BlockMessage) error {
+	msg := &slack.WebhookMessage{
+		Username:    sc.userName,
+		Text:        title,
+		Blocks:      &slack.Blocks{BlockSet: message.flatten()},
+		Attachments: message.highlightAttachments(),
+	}
+	if err := sc.retry.Do(ctx, func(ctx context.Context) error {
+		return retryable(slack.PostWebhookContext(ctx, sc.webhookURL, msg))
+	}); err != nil {
+		return fmt.Errorf("failed to send slack blocks: %w", err)
+	}
+	return nil
+}
+
+// retryable は、Slack から返されたエラーのうち、再送しても投稿が重複しないものを再送可能なエラーとしてラップします。
+//
+// レート制限 (429) の場合は Retry-After の秒数だけ待機し、503 や接続の確立に失敗した場合は待機時間を延ばしながら再送します。
+// 投稿は冪等でないため、他の 5xx や送信後のタイムアウトなど、Slack が処理済みの可能性がある場合は再送しません。
+func retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var rle *slack.RateLimitedError
+	if errors.As(err, &rle) {
+		return retry.After(err, rle.RetryAfter)
+	}
+
+	var sce slack.StatusCodeError
+	if (errors.As(err, &sce) && retry.IsUnprocessedStatus(sce.Code)) || retry.IsConnectError(err) {
+		return retry.Retryable(err)
+	}
+	return err
+}
+
 // ReportTitle は、レポートのタイトルを表す文字列型です。
 //
 // daily-report や weekly-report のような異なるレポートのタイトルを管理するために使用されます。
@@ -69,6 +121,9 @@ const (
 
 	// WeeklyReportTitle は、週次レポートのタイトルを表します。
 	WeeklyReportTitle ReportTitle = "weekly-cost-report"
+
+	// MonthlyReportTitle は、月次レポートのタイトルを表します。
+	MonthlyReportTitle ReportTitle = "monthly-cost-report"
 )
 
 // String: レポートタイトル型を文字列型に変換
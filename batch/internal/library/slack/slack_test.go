@@ -2,6 +2,9 @@ package slack_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -33,3 +36,48 @@ func TestSendMessage(t *testing.T) {
 	err := mockClient.SendMessage(ctx, messageTitle, sa)
 	assert.NoError(t, err)
 }
+
+// TestSlackClient_SendMessageRetry: 投稿が重複しないよう、503 の場合のみ再送し、他の 5xx の場合は再送しないことをテストします
+func TestSlackClient_SendMessageRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{
+			name:         "正常系: 503 の場合は処理されていないため再送する",
+			status:       http.StatusServiceUnavailable,
+			wantAttempts: 2,
+		},
+		{
+			name:         "異常系: 500 の場合は投稿済みの可能性があるため再送しない",
+			status:       http.StatusInternalServerError,
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) == 1 {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			sc := slack.NewSlackClient(server.URL, "cost-explorer")
+			err := sc.SendMessage(context.Background(), slack.DailyReportTitle.String(), slack.Attachment{})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantAttempts, attempts.Load())
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package teams
+
+import "encoding/json"
+
+// adaptiveCardSchema は、Adaptive Card のスキーマのURLです。
+const adaptiveCardSchema = "http://adaptivecards.io/schemas/adaptive-card.json"
+
+// adaptiveCardVersion は、Teams が対応している Adaptive Card のバージョンです。
+//
+// Table 要素を利用するため、1.5 を指定します。
+const adaptiveCardVersion = "1.5"
+
+// Element は、Adaptive Card の本文に配置する要素を表すインターフェースです。
+type Element interface {
+	elementType() string
+}
+
+// Color は、TextBlock の文字色を表す文字列型です。
+type Color string
+
+// TextBlock の文字色として使用する定数です。
+const (
+	ColorDefault   Color = "Default"
+	ColorGood      Color = "Good"
+	ColorWarning   Color = "Warning"
+	ColorAttention Color = "Attention"
+)
+
+// AdaptiveCard は、Teams に送信する Adaptive Card を表す構造体です。
+type AdaptiveCard struct {
+	Body []Element
+}
+
+// MarshalJSON は、Adaptive Card をスキーマに沿った JSON に変換します。
+func (ac AdaptiveCard) MarshalJSON() ([]byte, error) {
+	body := ac.Body
+	if body == nil {
+		body = []Element{}
+	}
+
+	return json.Marshal(struct {
+		Type    string    `json:"type"`
+		Schema  string    `json:"$schema"`
+		Version string    `json:"version"`
+		Body    []Element `json:"body"`
+		MSTeams struct {
+			Width string `json:"width"`
+		} `json:"msteams"`
+	}{
+		Type:    "AdaptiveCard",
+		Schema:  adaptiveCardSchema,
+		Version: adaptiveCardVersion,
+		Body:    body,
+		MSTeams: struct {
+			Width string `json:"width"`
+		}{Width: "Full"},
+	})
+}
+
+// TextBlock は、テキストを表示する要素です。
+type TextBlock struct {
+	Text      string `json:"text"`
+	Size      string `json:"size,omitempty"`   // Small, Default, Medium, Large, ExtraLarge
+	Weight    string `json:"weight,omitempty"` // Lighter, Default, Bolder
+	Color     Color  `json:"color,omitempty"`
+	IsSubtle  bool   `json:"isSubtle,omitempty"`
+	Wrap      bool   `json:"wrap"`
+	Separator bool   `json:"separator,omitempty"`
+}
+
+func (TextBlock) elementType() string { return "TextBlock" }
+
+// MarshalJSON は、要素の種類を付与した JSON に変換します。
+func (tb TextBlock) MarshalJSON() ([]byte, error) {
+	type alias TextBlock
+	return marshalElement(tb, alias(tb))
+}
+
+// Fact は、FactSet に表示する項目名と値の組です。
+type Fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// FactSet は、項目名と値の組を一覧で表示する要素です。
+type FactSet struct {
+	Facts     []Fact `json:"facts"`
+	Separator bool   `json:"separator,omitempty"`
+}
+
+func (FactSet) elementType() string { return "FactSet" }
+
+// MarshalJSON は、要素の種類を付与した JSON に変換します。
+func (fs FactSet) MarshalJSON() ([]byte, error) {
+	type alias FactSet
+	return marshalElement(fs, alias(fs))
+}
+
+// Table は、表を表示する要素です。
+//
+// 1行目を見出しとして扱い、2列目以降の値は右揃えで表示します。
+type Table struct {
+	Header []string
+	Rows   [][]string
+	Title  string // 表の上に表示する見出し (空の場合は表示しない)
+}
+
+func (Table) elementType() string { return "Table" }
+
+// MarshalJSON は、表を Adaptive Card の Table 要素の JSON に変換します。
+//
+// 見出しが指定されている場合は、見出しと表を Container にまとめて変換します。
+func (t Table) MarshalJSON() ([]byte, error) {
+	type cell struct {
+		Type  string    `json:"type"`
+		Items []Element `json:"items"`
+	}
+	type row struct {
+		Type  string `json:"type"`
+		Cells []cell `json:"cells"`
+	}
+	type column struct {
+		Width               int    `json:"width"`
+		HorizontalAlignment string `json:"horizontalCellContentAlignment,omitempty"`
+	}
+
+	newRow := func(values []string, bold bool) row {
+		cells := make([]cell, 0, len(t.Header))
+		for i := range t.Header {
+			text := ""
+			if i < len(values) {
+				text = values[i]
+			}
+			tb := TextBlock{Text: text, Wrap: true}
+			if bold {
+				tb.Weight = "Bolder"
+			}
+			cells = append(cells, cell{Type: "TableCell", Items: []Element{tb}})
+		}
+		return row{Type: "TableRow", Cells: cells}
+	}
+
+	rows := make([]row, 0, len(t.Rows)+1)
+	rows = append(rows, newRow(t.Header, true))
+	for _, r := range t.Rows {
+		rows = append(rows, newRow(r, false))
+	}
+
+	columns := make([]column, 0, len(t.Header))
+	for i := range t.Header {
+		if i == 0 {
+			columns = append(columns, column{Width: 2})
+			continue
+		}
+		columns = append(columns, column{Width: 1, HorizontalAlignment: "Right"})
+	}
+
+	table := struct {
+		Type              string   `json:"type"`
+		Columns           []column `json:"columns"`
+		Rows              []row    `json:"rows"`
+		FirstRowAsHeaders bool     `json:"firstRowAsHeaders"`
+		ShowGridLines     bool     `json:"showGridLines"`
+	}{
+		Type:              "Table",
+		Columns:           columns,
+		Rows:              rows,
+		FirstRowAsHeaders: true,
+		ShowGridLines:     false,
+	}
+
+	if t.Title == "" {
+		return json.Marshal(table)
+	}
+
+	return json.Marshal(struct {
+		Type  string `json:"type"`
+		Items []any  `json:"items"`
+	}{
+		Type:  "Container",
+		Items: []any{TextBlock{Text: t.Title, Weight: "Bolder", Wrap: true, Separator: true}, table},
+	})
+}
+
+// marshalElement は、要素の種類 (type) を付与した JSON に変換します。
+func marshalElement(e Element, fields any) ([]byte, error) {
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	typed := []byte(`{"type":"` + e.elementType() + `"`)
+	if len(b) > len("{}") {
+		typed = append(typed, ',')
+	}
+	return append(typed, b[1:]...), nil
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/library/teams/teams.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/library/teams/teams.go -destination=./internal/library/teams/mock/teams.go -package=teams
+//
+
+// Package teams is a generated GoMock package.
+package teams
+
+import (
+	context "context"
+	reflect "reflect"
+
+	teams "github.com/tamaco489/cost_explorer/batch/internal/library/teams"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITeamsClient is a mock of ITeamsClient interface.
+type MockITeamsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockITeamsClientMockRecorder
+	isgomock struct{}
+}
+
+// MockITeamsClientMockRecorder is the mock recorder for MockITeamsClient.
+type MockITeamsClientMockRecorder struct {
+	mock *MockITeamsClient
+}
+
+// NewMockITeamsClient creates a new mock instance.
+func NewMockITeamsClient(ctrl *gomock.Controller) *MockITeamsClient {
+	mock := &MockITeamsClient{ctrl: ctrl}
+	mock.recorder = &MockITeamsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITeamsClient) EXPECT() *MockITeamsClientMockRecorder {
+	return m.recorder
+}
+
+// SendCard mocks base method.
+func (m *MockITeamsClient) SendCard(ctx context.Context, card teams.AdaptiveCard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCard", ctx, card)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendCard indicates an expected call of SendCard.
+func (mr *MockITeamsClientMockRecorder) SendCard(ctx, card any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCard", reflect.TypeOf((*MockITeamsClient)(nil).SendCard), ctx, card)
+}
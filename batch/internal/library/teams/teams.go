@@ -0,0 +1,103 @@
+package teams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/retry"
+)
+
+// ITeamsClient は、Teams にメッセージを送信するためのインターフェースを定義します。
+//
+// GoMockを使用してテスト時にモックを作成するためにこのインターフェースを定義します。
+type ITeamsClient interface {
+	// SendCard は、Adaptive Card を Teams に送信するメソッドです。
+	SendCard(ctx context.Context, card AdaptiveCard) error
+}
+
+var _ ITeamsClient = (*teamsClient)(nil)
+
+// defaultTimeout は、Teams の Incoming Webhook へのリクエストのタイムアウトです。
+const defaultTimeout = 10 * time.Second
+
+// teamsClient は、Teams の Incoming Webhook にメッセージを送信するための構造体です。
+type teamsClient struct {
+	webhookURL string
+	httpClient *http.Client
+	retry      retry.Policy
+}
+
+// NewTeamsClient は、Teams クライアントのインスタンスを初期化する関数です。
+//
+// webhookURL を引数として受け取り、それを基に teamsClient を返します。
+func NewTeamsClient(webhookURL string) *teamsClient {
+	return &teamsClient{
+		webhookURL: webhookURL,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		retry:      retry.DefaultPolicy(),
+	}
+}
+
+// message は、Incoming Webhook に送信するメッセージの形式です。
+type message struct {
+	Type        string       `json:"type"`
+	Attachments []attachment `json:"attachments"`
+}
+
+type attachment struct {
+	ContentType string       `json:"contentType"`
+	ContentURL  *string      `json:"contentUrl"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+// SendCard は、Adaptive Card を Teams に送信するメソッドです。
+//
+// Webhook URL に Adaptive Card を添付したメッセージを送信し、2xx 以外のステータスコードの場合はエラーを返します。
+// 通信エラー、429、5xx の場合は待機時間を延ばしながら再送します。
+func (tc *teamsClient) SendCard(ctx context.Context, card AdaptiveCard) error {
+	body, err := json.Marshal(message{
+		Type: "message",
+		Attachments: []attachment{
+			{
+				ContentType: "application/vnd.microsoft.card.adaptive",
+				Content:     card,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal teams message: %w", err)
+	}
+
+	if err := tc.retry.Do(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, tc.webhookURL, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create teams request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		res, err := tc.httpClient.Do(req)
+		if err != nil {
+			return retry.Retryable(err)
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+			b, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+			err := fmt.Errorf("status=%d body=%s", res.StatusCode, string(b))
+			if retry.IsRetryableStatus(res.StatusCode) {
+				return retry.After(err, retry.ParseRetryAfter(res.Header.Get("Retry-After")))
+			}
+			return err
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to send teams message: %w", err)
+	}
+
+	return nil
+}
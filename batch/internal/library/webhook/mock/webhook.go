@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/library/webhook/webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/library/webhook/webhook.go -destination=./internal/library/webhook/mock/webhook.go -package=webhook
+//
+
+// Package webhook is a generated GoMock package.
+package webhook
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIWebhookClient is a mock of IWebhookClient interface.
+type MockIWebhookClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookClientMockRecorder
+	isgomock struct{}
+}
+
+// MockIWebhookClientMockRecorder is the mock recorder for MockIWebhookClient.
+type MockIWebhookClientMockRecorder struct {
+	mock *MockIWebhookClient
+}
+
+// NewMockIWebhookClient creates a new mock instance.
+func NewMockIWebhookClient(ctrl *gomock.Controller) *MockIWebhookClient {
+	mock := &MockIWebhookClient{ctrl: ctrl}
+	mock.recorder = &MockIWebhookClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhookClient) EXPECT() *MockIWebhookClientMockRecorder {
+	return m.recorder
+}
+
+// Post mocks base method.
+func (m *MockIWebhookClient) Post(ctx context.Context, event string, body []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Post", ctx, event, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Post indicates an expected call of Post.
+func (mr *MockIWebhookClientMockRecorder) Post(ctx, event, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockIWebhookClient)(nil).Post), ctx, event, body)
+}
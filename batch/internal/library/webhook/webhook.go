@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/retry"
+)
+
+// 送信するリクエストに付与するヘッダーです。
+//
+// 受信側は、TimestampHeader の値と本文を "." で連結した文字列の HMAC-SHA256 を共有シークレットで算出し、
+// SignatureHeader の値と一致することを確認して送信元を検証します。
+const (
+	EventHeader     = "X-Cost-Explorer-Event"
+	TimestampHeader = "X-Cost-Explorer-Timestamp"
+	SignatureHeader = "X-Cost-Explorer-Signature"
+)
+
+// signaturePrefix は、署名の算出方法を表す接頭辞です。
+const signaturePrefix = "sha256="
+
+// IWebhookClient は、Webhook に JSON を送信するためのインターフェースを定義します。
+//
+// GoMockを使用してテスト時にモックを作成するためにこのインターフェースを定義します。
+type IWebhookClient interface {
+	// Post は、署名を付与した JSON を Webhook の URL に送信するメソッドです。
+	Post(ctx context.Context, event string, body []byte) error
+}
+
+var _ IWebhookClient = (*webhookClient)(nil)
+
+// 送信に失敗した場合の再送の既定値です。
+const (
+	defaultTimeout     = 10 * time.Second
+	defaultMaxAttempts = 4
+)
+
+// webhookClient は、Webhook に JSON を送信するための構造体です。
+type webhookClient struct {
+	url        string
+	secret     string
+	httpClient *http.Client
+	retry      retry.Policy
+}
+
+// Option は、webhookClient の設定を変更するための関数です。
+type Option func(*webhookClient)
+
+// WithHTTPClient は、送信に使用する HTTP クライアントを指定します。
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(wc *webhookClient) {
+		wc.httpClient = httpClient
+	}
+}
+
+// WithMaxAttempts は、初回の送信を含めた最大の送信回数を指定します。
+func WithMaxAttempts(maxAttempts int) Option {
+	return func(wc *webhookClient) {
+		wc.retry.MaxAttempts = max(maxAttempts, 1)
+	}
+}
+
+// WithBackoff は、再送までの待機時間の初期値と上限を指定します。
+func WithBackoff(baseDelay, maxDelay time.Duration) Option {
+	return func(wc *webhookClient) {
+		wc.retry.BaseDelay = baseDelay
+		wc.retry.MaxDelay = maxDelay
+	}
+}
+
+// NewWebhookClient は、Webhook クライアントのインスタンスを初期化する関数です。
+//
+// secret が空の場合は、署名のヘッダーを付与せずに送信します。
+func NewWebhookClient(url, secret string, opts ...Option) *webhookClient {
+	policy := retry.DefaultPolicy()
+	policy.MaxAttempts = defaultMaxAttempts
+
+	wc := &webhookClient{
+		url:        url,
+		secret:     secret,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		retry:      policy,
+	}
+	for _, opt := range opts {
+		opt(wc)
+	}
+	return wc
+}
+
+// Post は、署名を付与した JSON を Webhook の URL に送信するメソッドです。
+//
+// 通信エラー、429、5xx の場合は指数関数的に待機時間を延ばしながら再送し、Retry-After ヘッダーがある場合はその値だけ待機します。
+// 待機するとコンテキストの期限を超える場合は、再送せずにエラーを返します。
+func (wc *webhookClient) Post(ctx context.Context, event string, body []byte) error {
+	if err := wc.retry.Do(ctx, func(ctx context.Context) error {
+		return wc.post(ctx, event, body)
+	}); err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	return nil
+}
+
+// post は、リクエストを1回送信し、再送によって成功する可能性がある場合は再送可能なエラーを返します。
+func (wc *webhookClient) post(ctx context.Context, event string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wc.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event)
+	if wc.secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(wc.secret, timestamp, body))
+	}
+
+	res, err := wc.httpClient.Do(req)
+	if err != nil {
+		return retry.Retryable(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	b, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	err = fmt.Errorf("unexpected webhook response: status=%d body=%s", res.StatusCode, string(b))
+	if retry.IsRetryableStatus(res.StatusCode) {
+		return retry.After(err, retry.ParseRetryAfter(res.Header.Get("Retry-After")))
+	}
+	return err
+}
+
+// Sign は、タイムスタンプと本文を "." で連結した文字列の HMAC-SHA256 を算出し、署名のヘッダーの値を生成します。
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,112 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/webhook"
+)
+
+// TestWebhookClient_Post: 署名を付与して送信し、ステータスコードに応じて再送することをテストします
+func TestWebhookClient_Post(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int  // 送信回数ごとに返すステータスコード (超えた分は最後の値を返す)
+		retryAfter   string // 再送を求めるレスポンスに付与する Retry-After ヘッダー
+		wantAttempts int32
+		wantErr      bool
+		wantMinWait  time.Duration
+	}{
+		{
+			name:         "正常系: 初回の送信で成功した場合は再送しない",
+			statuses:     []int{http.StatusNoContent},
+			wantAttempts: 1,
+		},
+		{
+			name:         "正常系: 5xx の場合は再送し、成功した時点で送信を終了する",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			wantAttempts: 3,
+		},
+		{
+			name:         "正常系: 429 の場合は Retry-After の秒数だけ待機してから再送する",
+			statuses:     []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:   "1",
+			wantAttempts: 2,
+			wantMinWait:  1 * time.Second,
+		},
+		{
+			name:         "異常系: 4xx の場合は再送せずにエラーを返す",
+			statuses:     []int{http.StatusBadRequest},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "異常系: 最大の送信回数まで失敗した場合はエラーを返す",
+			statuses:     []int{http.StatusInternalServerError},
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := []byte(`{"schema_version":"1.0"}`)
+
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(attempts.Add(1))
+
+				b, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, body, b)
+				assert.Equal(t, "weekly", r.Header.Get(webhook.EventHeader))
+				assert.Equal(t, webhook.Sign("secret", r.Header.Get(webhook.TimestampHeader), b), r.Header.Get(webhook.SignatureHeader))
+
+				status := tt.statuses[min(n, len(tt.statuses))-1]
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			wc := webhook.NewWebhookClient(server.URL, "secret", webhook.WithMaxAttempts(3), webhook.WithBackoff(time.Millisecond, 10*time.Millisecond))
+
+			start := time.Now()
+			err := wc.Post(context.Background(), "weekly", body)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantAttempts, attempts.Load())
+			assert.GreaterOrEqual(t, time.Since(start), tt.wantMinWait)
+		})
+	}
+}
+
+// TestWebhookClient_PostDeadline: Retry-After の待機時間がコンテキストの期限を超える場合は再送しないことをテストします
+func TestWebhookClient_PostDeadline(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	wc := webhook.NewWebhookClient(server.URL, "secret")
+	err := wc.Post(ctx, "daily", []byte(`{}`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no time left to retry")
+	assert.Equal(t, int32(1), attempts.Load())
+}
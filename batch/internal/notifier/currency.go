@@ -0,0 +1,64 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// currencyNotifier: 通知先ごとに選択した通貨のレポートを通知する Notifier
+type currencyNotifier struct {
+	notifier   Notifier
+	currencies []string
+}
+
+var _ Notifier = (*currencyNotifier)(nil)
+
+// WithCurrencies: 指定した通貨ごとに変換したレポートを通知する Notifier を生成
+//
+// 通貨を指定しない場合は、レポートの通貨 (表示する通貨の先頭) のまま通知する
+func WithCurrencies(n Notifier, currencies []string) Notifier {
+	if len(currencies) == 0 {
+		return n
+	}
+	return &currencyNotifier{notifier: n, currencies: currencies}
+}
+
+// NotifyDaily: 日次利用コストレポートを指定した通貨ごとに通知
+func (cn *currencyNotifier) NotifyDaily(ctx context.Context, usage *service.DailyCostUsage) error {
+	return notifyInCurrencies(cn.currencies, ReportTypeDaily, usage.InCurrency, func(u *service.DailyCostUsage) error {
+		return cn.notifier.NotifyDaily(ctx, u)
+	})
+}
+
+// NotifyWeekly: 週次利用コストレポートを指定した通貨ごとに通知
+func (cn *currencyNotifier) NotifyWeekly(ctx context.Context, usage *service.WeeklyCostUsage) error {
+	return notifyInCurrencies(cn.currencies, ReportTypeWeekly, usage.InCurrency, func(u *service.WeeklyCostUsage) error {
+		return cn.notifier.NotifyWeekly(ctx, u)
+	})
+}
+
+// NotifyMonthly: 月次利用コストレポートを指定した通貨ごとに通知
+func (cn *currencyNotifier) NotifyMonthly(ctx context.Context, usage *service.MonthlyCostUsage) error {
+	return notifyInCurrencies(cn.currencies, ReportTypeMonthly, usage.InCurrency, func(u *service.MonthlyCostUsage) error {
+		return cn.notifier.NotifyMonthly(ctx, u)
+	})
+}
+
+// notifyInCurrencies: 通貨ごとのレポートを取得して通知 (一部の通貨で失敗した場合も残りの通貨の通知を継続する)
+func notifyInCurrencies[T any](currencies []string, reportType ReportType, inCurrency func(currency string) *T, notify func(usage *T) error) error {
+	var errs []error
+	for _, currency := range currencies {
+		usage := inCurrency(currency)
+		if usage == nil {
+			errs = append(errs, fmt.Errorf("%s cost report in %s is not converted", reportType, currency))
+			continue
+		}
+		if err := notify(usage); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
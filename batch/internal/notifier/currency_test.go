@@ -0,0 +1,78 @@
+package notifier_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/notifier"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// currencyRecorder: 通知されたレポートの通貨と利用コストを記録する Notifier
+type currencyRecorder struct {
+	received []string
+}
+
+func (cr *currencyRecorder) NotifyDaily(ctx context.Context, usage *service.DailyCostUsage) error {
+	cr.received = append(cr.received, usage.Currency)
+	return nil
+}
+
+func (cr *currencyRecorder) NotifyWeekly(ctx context.Context, usage *service.WeeklyCostUsage) error {
+	cr.received = append(cr.received, usage.Currency)
+	return nil
+}
+
+func (cr *currencyRecorder) NotifyMonthly(ctx context.Context, usage *service.MonthlyCostUsage) error {
+	cr.received = append(cr.received, usage.Currency)
+	return nil
+}
+
+// TestWithCurrencies: 通知先ごとに選択した通貨に変換したレポートを通知することをテストします
+func TestWithCurrencies(t *testing.T) {
+	usage := &service.WeeklyCostUsage{
+		LastWeekCost: 1500,
+		Currency:     "JPY",
+		Conversions: map[string]*service.WeeklyCostUsage{
+			"EUR": {LastWeekCost: 9.5, Currency: "EUR"},
+			"USD": {LastWeekCost: 10, Currency: "USD"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		currencies []string
+		wanted     []string
+		wantErr    string
+	}{
+		{
+			name:   "正常系: 通貨を指定しない場合はレポートの通貨のまま通知すること",
+			wanted: []string{"JPY"},
+		},
+		{
+			name:       "正常系: 指定した通貨の順にそれぞれのレポートを通知すること",
+			currencies: []string{"USD", "JPY"},
+			wanted:     []string{"USD", "JPY"},
+		},
+		{
+			name:       "異常系: 変換していない通貨は通知せず、残りの通貨の通知を継続すること",
+			currencies: []string{"GBP", "EUR"},
+			wanted:     []string{"EUR"},
+			wantErr:    "weekly cost report in GBP is not converted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &currencyRecorder{}
+			err := notifier.WithCurrencies(recorder, tt.currencies).NotifyWeekly(context.Background(), usage)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wanted, recorder.received)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/discord"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// discordNotifier: 利用コストレポートを Discord に Embed として通知する Notifier
+type discordNotifier struct {
+	client         discord.IDiscordClient
+	alertThreshold float64
+}
+
+var _ Notifier = (*discordNotifier)(nil)
+
+// NewDiscordNotifier: discordNotifier のコンストラクタ
+func NewDiscordNotifier(client discord.IDiscordClient, alertThreshold float64) *discordNotifier {
+	return &discordNotifier{
+		client:         client,
+		alertThreshold: alertThreshold,
+	}
+}
+
+// NotifyDaily: 日次利用コストレポートを Discord に通知
+func (dn *discordNotifier) NotifyDaily(ctx context.Context, usage *service.DailyCostUsage) error {
+	return dn.sendEmbeds(ctx, usage.GenDailyDiscordEmbeds(dn.alertThreshold))
+}
+
+// NotifyWeekly: 週次利用コストレポートを Discord に通知
+func (dn *discordNotifier) NotifyWeekly(ctx context.Context, usage *service.WeeklyCostUsage) error {
+	return dn.sendEmbeds(ctx, usage.GenWeeklyDiscordEmbeds(dn.alertThreshold))
+}
+
+// NotifyMonthly: 月次利用コストレポートを Discord に通知
+func (dn *discordNotifier) NotifyMonthly(ctx context.Context, usage *service.MonthlyCostUsage) error {
+	return dn.sendEmbeds(ctx, usage.GenMonthlyDiscordEmbeds(dn.alertThreshold))
+}
+
+func (dn *discordNotifier) sendEmbeds(ctx context.Context, embeds []discord.Embed) error {
+	if err := dn.client.SendEmbeds(ctx, embeds); err != nil {
+		return fmt.Errorf("failed to send discord message: %w", err)
+	}
+	return nil
+}
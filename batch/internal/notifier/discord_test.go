@@ -0,0 +1,129 @@
+package notifier_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/discord"
+	"github.com/tamaco489/cost_explorer/batch/internal/notifier"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// discordMessage: Discord の Webhook に送信されたメッセージ
+type discordMessage struct {
+	Username string          `json:"username"`
+	Embeds   []discord.Embed `json:"embeds"`
+}
+
+// TestDiscordNotifier_NotifyWeekly: 週次利用コストレポートを先週の増減に応じた色の Embed として送信することをテストします
+func TestDiscordNotifier_NotifyWeekly(t *testing.T) {
+	tests := []struct {
+		name             string
+		percentageChange float64
+		weekBeforeLast   float64
+		wantColor        discord.Color
+		wantDescription  bool
+	}{
+		{
+			name:             "正常系: 先々週から減少している場合は緑色で表示する",
+			percentageChange: 80,
+			weekBeforeLast:   1000,
+			wantColor:        discord.ColorGood,
+		},
+		{
+			name:             "正常系: 先々週から増加している場合は黄色で表示する",
+			percentageChange: 110,
+			weekBeforeLast:   1000,
+			wantColor:        discord.ColorWarning,
+		},
+		{
+			name:             "正常系: 増加が閾値を超えている場合は赤色で表示し、警告を説明文に表示する",
+			percentageChange: 150,
+			weekBeforeLast:   1000,
+			wantColor:        discord.ColorDanger,
+			wantDescription:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received discordMessage
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			dn := notifier.NewDiscordNotifier(discord.NewDiscordClient(server.URL, "cost-explorer"), 120)
+			err := dn.NotifyWeekly(context.Background(), &service.WeeklyCostUsage{
+				LastWeekCost:       tt.weekBeforeLast * tt.percentageChange / 100,
+				WeekBeforeLastCost: tt.weekBeforeLast,
+				PercentageChange:   tt.percentageChange,
+				Metric:             service.CostMetricUnblended,
+				DailyCosts: []service.WeeklyDailyCost{
+					{LastWeekDate: "2024-09-02", LastWeekCost: 300, WeekBeforeLastDate: "2024-08-26", WeekBeforeLastCost: 200, PercentageChange: 150},
+				},
+			})
+			assert.NoError(t, err)
+
+			assert.Equal(t, "cost-explorer", received.Username)
+			assert.Len(t, received.Embeds, 1)
+
+			embed := received.Embeds[0]
+			assert.Equal(t, "AWS 週次利用コストレポート", embed.Title)
+			assert.Equal(t, tt.wantColor, embed.Color)
+			assert.Equal(t, tt.wantDescription, embed.Description != "")
+			assert.Len(t, embed.Fields, 4)
+			assert.Equal(t, "先週の利用コスト", embed.Fields[0].Name)
+			assert.True(t, embed.Fields[0].Inline)
+			assert.Equal(t, "日別の利用コスト", embed.Fields[3].Name)
+			assert.Contains(t, embed.Fields[3].Value, "```\n")
+			assert.Contains(t, embed.Fields[3].Value, "09-02")
+		})
+	}
+}
+
+// TestDiscordNotifier_RateLimited: レート制限を超えた場合 (429) は retry_after だけ待機して再送することをテストします
+func TestDiscordNotifier_RateLimited(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 0.2, "global": false}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	dn := notifier.NewDiscordNotifier(discord.NewDiscordClient(server.URL, "cost-explorer"), 120)
+	err := dn.NotifyMonthly(context.Background(), &service.MonthlyCostUsage{LastMonth: "2024-08", MonthBeforeLast: "2024-07"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), attempts.Load())
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+// TestDiscordNotifier_Error: レート制限以外のエラーの場合は再送せずにエラーを返すことをテストします
+func TestDiscordNotifier_Error(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message": "Invalid Form Body", "code": 50035}`))
+	}))
+	defer server.Close()
+
+	dn := notifier.NewDiscordNotifier(discord.NewDiscordClient(server.URL, "cost-explorer"), 120)
+	err := dn.NotifyDaily(context.Background(), &service.DailyCostUsage{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status=400")
+	assert.Equal(t, int32(1), attempts.Load())
+}
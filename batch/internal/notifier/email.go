@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/email"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// emailNotifier: 利用コストレポートを HTML とテキストの本文を含むメールとして通知する Notifier
+type emailNotifier struct {
+	client         email.IEmailClient
+	recipients     []string
+	alertThreshold float64
+}
+
+var _ Notifier = (*emailNotifier)(nil)
+
+// NewEmailNotifier: emailNotifier のコンストラクタ
+func NewEmailNotifier(client email.IEmailClient, recipients []string, alertThreshold float64) *emailNotifier {
+	return &emailNotifier{
+		client:         client,
+		recipients:     recipients,
+		alertThreshold: alertThreshold,
+	}
+}
+
+// NotifyDaily: 日次利用コストレポートをメールで通知
+func (en *emailNotifier) NotifyDaily(ctx context.Context, usage *service.DailyCostUsage) error {
+	return en.sendReport(ctx, usage.GenDailyMailReport(en.alertThreshold))
+}
+
+// NotifyWeekly: 週次利用コストレポートをメールで通知
+func (en *emailNotifier) NotifyWeekly(ctx context.Context, usage *service.WeeklyCostUsage) error {
+	return en.sendReport(ctx, usage.GenWeeklyMailReport(en.alertThreshold))
+}
+
+// NotifyMonthly: 月次利用コストレポートをメールで通知
+func (en *emailNotifier) NotifyMonthly(ctx context.Context, usage *service.MonthlyCostUsage) error {
+	return en.sendReport(ctx, usage.GenMonthlyMailReport(en.alertThreshold))
+}
+
+func (en *emailNotifier) sendReport(ctx context.Context, report email.Report) error {
+	htmlBody, err := report.HTML()
+	if err != nil {
+		return fmt.Errorf("failed to render email html body: %w", err)
+	}
+
+	if err := en.client.SendMail(ctx, email.Message{
+		To:       en.recipients,
+		Subject:  report.Title,
+		TextBody: report.Text(),
+		HTMLBody: htmlBody,
+	}); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package notifier_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/email"
+	"github.com/tamaco489/cost_explorer/batch/internal/notifier"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// smtpStub: 受信したメールを記録する SMTP サーバーのテスト用スタブ
+type smtpStub struct {
+	host       string
+	port       int
+	rejectRcpt bool // true の場合は RCPT コマンドを拒否する
+
+	mu     sync.Mutex
+	authed bool
+	from   string
+	rcpts  []string
+	data   []byte
+}
+
+// newSMTPStub: ローカルで待ち受ける SMTP サーバーのスタブを起動
+func newSMTPStub(t *testing.T, rejectRcpt bool) *smtpStub {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	host, port, err := net.SplitHostPort(ln.Addr().String())
+	assert.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	assert.NoError(t, err)
+
+	stub := &smtpStub{host: host, port: p, rejectRcpt: rejectRcpt}
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		stub.serve(textproto.NewConn(conn))
+	}()
+	return stub
+}
+
+// serve: SMTP のコマンドを1行ずつ読み取り、最低限の応答を返す
+func (s *smtpStub) serve(tp *textproto.Conn) {
+	_ = tp.PrintfLine("220 localhost ESMTP stub")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		switch cmd := strings.ToUpper(strings.Fields(line)[0]); cmd {
+		case "EHLO":
+			_ = tp.PrintfLine("250-localhost")
+			_ = tp.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			s.authed = true
+			_ = tp.PrintfLine("235 2.7.0 Authentication successful")
+		case "MAIL":
+			s.from = strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")
+			_ = tp.PrintfLine("250 OK")
+		case "RCPT":
+			if s.rejectRcpt {
+				_ = tp.PrintfLine("550 5.1.1 mailbox unavailable")
+				break
+			}
+			s.rcpts = append(s.rcpts, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+			_ = tp.PrintfLine("250 OK")
+		case "DATA":
+			_ = tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			s.data, _ = tp.ReadDotBytes()
+			_ = tp.PrintfLine("250 OK")
+		case "QUIT":
+			_ = tp.PrintfLine("221 Bye")
+			s.mu.Unlock()
+			return
+		default:
+			_ = tp.PrintfLine("502 command not implemented")
+		}
+		s.mu.Unlock()
+	}
+}
+
+// receivedMail: スタブが受信したメールのヘッダーと、テキスト・HTML の本文
+type receivedMail struct {
+	subject  string
+	to       string
+	textBody string
+	htmlBody string
+}
+
+// parse: 受信したメールを multipart/alternative として解析 (SMTP のセッションが終了した後に呼び出す)
+func (s *smtpStub) parse(t *testing.T) receivedMail {
+	t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msg, err := mail.ReadMessage(bytes.NewReader(s.data))
+	assert.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	assert.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	assert.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	received := receivedMail{subject: subject, to: msg.Header.Get("To")}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+
+		// quoted-printable のパートは multipart.Reader によりデコードされる
+		b, err := io.ReadAll(part)
+		assert.NoError(t, err)
+		switch part.Header.Get("Content-Type") {
+		case "text/plain; charset=UTF-8":
+			received.textBody = string(b)
+		case "text/html; charset=UTF-8":
+			received.htmlBody = string(b)
+		}
+	}
+	return received
+}
+
+// TestEmailNotifier_NotifyWeekly: 週次利用コストレポートを HTML とテキストの本文を含むメールとして送信することをテストします
+func TestEmailNotifier_NotifyWeekly(t *testing.T) {
+	stub := newSMTPStub(t, false)
+
+	client := email.NewSMTPClient(stub.host, stub.port, "user", "password", "cost-explorer@example.com")
+	en := notifier.NewEmailNotifier(client, []string{"manager@example.com", "finance@example.com"}, 120)
+	err := en.NotifyWeekly(context.Background(), &service.WeeklyCostUsage{
+		LastWeekCost:       1500,
+		WeekBeforeLastCost: 1000,
+		PercentageChange:   150,
+		Metric:             service.CostMetricUnblended,
+		Currency:           "JPY",
+		TagCosts: []service.WeeklyTagCostTable{
+			{TagKey: "team", Costs: []service.WeeklyTagCost{
+				{TagValue: "<platform>", LastWeekCost: 900, WeekBeforeLastCost: 600, PercentageChange: 150},
+			}},
+		},
+	})
+	assert.NoError(t, err)
+
+	received := stub.parse(t)
+	assert.True(t, stub.authed)
+	assert.Equal(t, "cost-explorer@example.com", stub.from)
+	assert.Equal(t, []string{"manager@example.com", "finance@example.com"}, stub.rcpts)
+	assert.Equal(t, "AWS 週次利用コストレポート", received.subject)
+	assert.Equal(t, "manager@example.com, finance@example.com", received.to)
+
+	// テキストの本文は、桁を揃えた表として表示される
+	assert.Contains(t, received.textBody, "• 先週の利用コスト: 1500 円")
+	assert.Contains(t, received.textBody, "⚠ ")
+	assert.Contains(t, received.textBody, "team        先週 (円)  先々週 (円)  増減 (%)\n")
+	assert.Contains(t, received.textBody, "<platform>        900          600    150.00\n")
+
+	// HTML の本文は、表として表示され、値はエスケープされる
+	assert.Contains(t, received.htmlBody, "<h2>AWS 週次利用コストレポート</h2>")
+	assert.Contains(t, received.htmlBody, "<h3>タグ別の利用コスト (team)</h3>")
+	assert.Contains(t, received.htmlBody, "&lt;platform&gt;")
+	assert.NotContains(t, received.htmlBody, "<platform>")
+}
+
+// TestEmailNotifier_NotifyDaily: 日次利用コストレポートをメールとして送信することをテストします
+func TestEmailNotifier_NotifyDaily(t *testing.T) {
+	stub := newSMTPStub(t, false)
+
+	client := email.NewSMTPClient(stub.host, stub.port, "", "", "cost-explorer@example.com")
+	en := notifier.NewEmailNotifier(client, []string{"manager@example.com"}, 120)
+	err := en.NotifyDaily(context.Background(), &service.DailyCostUsage{
+		YesterdayCost:    100,
+		ActualCost:       1000,
+		AverageDailyCost: 100,
+		ForecastCost:     3000,
+		Metric:           service.CostMetricUnblended,
+		YesterdayServiceCosts: []service.ServiceCost{
+			{ServiceName: "Amazon Elastic Compute Cloud - Compute", Cost: 80},
+		},
+	})
+	assert.NoError(t, err)
+
+	received := stub.parse(t)
+	assert.False(t, stub.authed) // ユーザー名が空の場合は認証しない
+	assert.Equal(t, "AWS 日次利用コストレポート", received.subject)
+	assert.Contains(t, received.textBody, "1. Amazon Elastic Compute Cloud - Compute")
+	assert.Contains(t, received.htmlBody, "1. Amazon Elastic Compute Cloud - Compute")
+	assert.NotContains(t, received.textBody, "⚠ ") // 閾値を超えていないため警告は表示されない
+}
+
+// TestEmailNotifier_Error: SMTP サーバーが宛先を拒否した場合はエラーを返すことをテストします
+func TestEmailNotifier_Error(t *testing.T) {
+	stub := newSMTPStub(t, true)
+
+	client := email.NewSMTPClient(stub.host, stub.port, "", "", "cost-explorer@example.com")
+	en := notifier.NewEmailNotifier(client, []string{"unknown@example.com"}, 120)
+	err := en.NotifyMonthly(context.Background(), &service.MonthlyCostUsage{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "550")
+}
@@ -0,0 +1,166 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// IncidentStore: PagerDuty に作成した未解決のインシデントの重複排除キーの保存先
+//
+// 利用コストが上限を下回った場合に、作成済みのインシデントのみを解決するために使用する
+type IncidentStore interface {
+	// OpenIncidents: 未解決のインシデントの重複排除キーを取得 (保存されていない場合は空)
+	OpenIncidents(ctx context.Context) ([]string, error)
+
+	// SaveOpenIncidents: 未解決のインシデントの重複排除キーを保存
+	SaveOpenIncidents(ctx context.Context, dedupKeys []string) error
+}
+
+// fileIncidentStore: ローカルのファイルに未解決のインシデントを JSON として保存する IncidentStore
+type fileIncidentStore struct {
+	path string
+}
+
+var _ IncidentStore = (*fileIncidentStore)(nil)
+
+// NewFileIncidentStore: dir 配下のキーに対応するファイルに未解決のインシデントを保存する IncidentStore を生成
+//
+// キーの "/" はディレクトリの区切りとして扱う
+func NewFileIncidentStore(dir, key string) IncidentStore {
+	return &fileIncidentStore{path: filepath.Join(dir, filepath.FromSlash(key)+".json")}
+}
+
+// OpenIncidents: 未解決のインシデントの重複排除キーをファイルから読み込む
+func (s *fileIncidentStore) OpenIncidents(ctx context.Context) ([]string, error) {
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeIncidents(b)
+}
+
+// SaveOpenIncidents: 未解決のインシデントの重複排除キーをファイルに書き込む
+//
+// 書き込み途中のファイルを読み込まないよう、一時ファイルに書き込んでから置き換える
+func (s *fileIncidentStore) SaveOpenIncidents(ctx context.Context, dedupKeys []string) error {
+	b, err := encodeIncidents(dedupKeys)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// IncidentS3API: s3IncidentStore が使用する S3 の API
+type IncidentS3API interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// s3IncidentStore: S3 のオブジェクトとして未解決のインシデントを JSON として保存する IncidentStore
+//
+// Lambda の一時領域は実行環境ごとに破棄されるため、実行をまたいで未解決のインシデントを保持する場合に使用する
+type s3IncidentStore struct {
+	client IncidentS3API
+	bucket string
+	key    string
+}
+
+var _ IncidentStore = (*s3IncidentStore)(nil)
+
+// NewS3IncidentStore: バケットの prefix 配下のキーに対応するオブジェクトに未解決のインシデントを保存する IncidentStore を生成
+func NewS3IncidentStore(client IncidentS3API, bucket, prefix, key string) IncidentStore {
+	return &s3IncidentStore{
+		client: client,
+		bucket: bucket,
+		key:    path.Join(prefix, key) + ".json",
+	}
+}
+
+// OpenIncidents: 未解決のインシデントの重複排除キーをオブジェクトから取得
+func (s *s3IncidentStore) OpenIncidents(ctx context.Context) ([]string, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get pagerduty incidents object: %w", err)
+	}
+	defer out.Body.Close()
+
+	b, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeIncidents(b)
+}
+
+// SaveOpenIncidents: 未解決のインシデントの重複排除キーをオブジェクトとして保存
+func (s *s3IncidentStore) SaveOpenIncidents(ctx context.Context, dedupKeys []string) error {
+	b, err := encodeIncidents(dedupKeys)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(s.key),
+		Body:        bytes.NewReader(b),
+		ContentType: aws.String("application/json"),
+	}); err != nil {
+		return fmt.Errorf("failed to put pagerduty incidents object: %w", err)
+	}
+	return nil
+}
+
+// decodeIncidents: JSON から未解決のインシデントの重複排除キーを復元
+func decodeIncidents(b []byte) ([]string, error) {
+	var dedupKeys []string
+	if err := json.Unmarshal(b, &dedupKeys); err != nil {
+		return nil, err
+	}
+	return dedupKeys, nil
+}
+
+// encodeIncidents: 未解決のインシデントの重複排除キーを JSON に変換 (未解決のインシデントがない場合は空の配列とする)
+func encodeIncidents(dedupKeys []string) ([]byte, error) {
+	if dedupKeys == nil {
+		dedupKeys = []string{}
+	}
+	return json.Marshal(dedupKeys)
+}
@@ -0,0 +1,79 @@
+package notifier_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/notifier"
+)
+
+// TestFileIncidentStore: 未解決のインシデントをファイルに保存し、保存されていない場合は空を返すことをテストします
+func TestFileIncidentStore(t *testing.T) {
+	ctx := context.Background()
+	store := notifier.NewFileIncidentStore(t.TempDir(), "cost-explorer-dev/pagerduty")
+
+	open, err := store.OpenIncidents(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, open)
+
+	assert.NoError(t, store.SaveOpenIncidents(ctx, []string{"cost-explorer-dev:yesterday-cost:2024-09-10"}))
+	open, err = store.OpenIncidents(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cost-explorer-dev:yesterday-cost:2024-09-10"}, open)
+
+	assert.NoError(t, store.SaveOpenIncidents(ctx, nil))
+	open, err = store.OpenIncidents(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, open)
+}
+
+// stubS3API: オブジェクトをメモリ上に保存する IncidentS3API
+type stubS3API struct {
+	objects map[string]string
+	err     error
+}
+
+func (s *stubS3API) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	body, ok := s.objects[*params.Bucket+"/"+*params.Key]
+	if !ok {
+		return nil, &types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func (s *stubS3API) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	b, _ := io.ReadAll(params.Body)
+	s.objects[*params.Bucket+"/"+*params.Key] = string(b)
+	return &s3.PutObjectOutput{}, nil
+}
+
+// TestS3IncidentStore: 接頭辞を付けたオブジェクトキーで保存し、オブジェクトが存在しない場合は空を返すことをテストします
+func TestS3IncidentStore(t *testing.T) {
+	ctx := context.Background()
+	client := &stubS3API{objects: map[string]string{}}
+	store := notifier.NewS3IncidentStore(client, "cost-explorer-dev", "pagerduty/incidents", "cost-explorer-dev/pagerduty")
+
+	open, err := store.OpenIncidents(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, open)
+
+	assert.NoError(t, store.SaveOpenIncidents(ctx, []string{"cost-explorer-dev:forecast-cost:2024-09-10"}))
+	assert.Equal(t, `["cost-explorer-dev:forecast-cost:2024-09-10"]`, client.objects["cost-explorer-dev/pagerduty/incidents/cost-explorer-dev/pagerduty.json"])
+
+	open, err = store.OpenIncidents(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cost-explorer-dev:forecast-cost:2024-09-10"}, open)
+
+	client.err = errors.New("access denied")
+	_, err = store.OpenIncidents(ctx)
+	assert.ErrorContains(t, err, "access denied")
+}
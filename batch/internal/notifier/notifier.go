@@ -0,0 +1,82 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// ReportType: 通知するレポートの種類
+type ReportType string
+
+const (
+	ReportTypeDaily   ReportType = "daily"
+	ReportTypeWeekly  ReportType = "weekly"
+	ReportTypeMonthly ReportType = "monthly"
+)
+
+// String: レポートの種類の型を文字列型に変換
+func (rt ReportType) String() string {
+	return string(rt)
+}
+
+// ParseReportType: 文字列からレポートの種類を取得
+func ParseReportType(s string) (ReportType, error) {
+	switch rt := ReportType(s); rt {
+	case ReportTypeDaily, ReportTypeWeekly, ReportTypeMonthly:
+		return rt, nil
+	default:
+		return "", fmt.Errorf("invalid report type: %s", s)
+	}
+}
+
+// Notifier: 利用コストレポートを外部サービスに通知するインターフェース
+//
+// 通知先ごとにメッセージの形式へ変換して送信する
+type Notifier interface {
+	NotifyDaily(ctx context.Context, usage *service.DailyCostUsage) error
+	NotifyWeekly(ctx context.Context, usage *service.WeeklyCostUsage) error
+	NotifyMonthly(ctx context.Context, usage *service.MonthlyCostUsage) error
+}
+
+// multiNotifier: 複数の通知先に同じレポートを通知する Notifier
+type multiNotifier []Notifier
+
+var _ Notifier = (multiNotifier)(nil)
+
+// Multi: 複数の通知先に同じレポートを通知する Notifier を生成
+//
+// 一部の通知先で失敗した場合も残りの通知先への送信を継続し、全てのエラーをまとめて返す
+func Multi(notifiers ...Notifier) Notifier {
+	if len(notifiers) == 1 {
+		return notifiers[0]
+	}
+	return multiNotifier(notifiers)
+}
+
+// NotifyDaily: 日次利用コストレポートを全ての通知先に通知
+func (mn multiNotifier) NotifyDaily(ctx context.Context, usage *service.DailyCostUsage) error {
+	return mn.each(func(n Notifier) error { return n.NotifyDaily(ctx, usage) })
+}
+
+// NotifyWeekly: 週次利用コストレポートを全ての通知先に通知
+func (mn multiNotifier) NotifyWeekly(ctx context.Context, usage *service.WeeklyCostUsage) error {
+	return mn.each(func(n Notifier) error { return n.NotifyWeekly(ctx, usage) })
+}
+
+// NotifyMonthly: 月次利用コストレポートを全ての通知先に通知
+func (mn multiNotifier) NotifyMonthly(ctx context.Context, usage *service.MonthlyCostUsage) error {
+	return mn.each(func(n Notifier) error { return n.NotifyMonthly(ctx, usage) })
+}
+
+func (mn multiNotifier) each(notify func(n Notifier) error) error {
+	var errs []error
+	for _, n := range mn {
+		if err := notify(n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
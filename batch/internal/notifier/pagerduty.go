@@ -0,0 +1,153 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/pagerduty"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// pagerDutyNotifier: 利用コストが上限を超えた場合に PagerDuty にアラートを送信する Notifier
+//
+// 日次レポートのみを監視対象とし、週次・月次レポートでは何もしない
+type pagerDutyNotifier struct {
+	client     pagerduty.IPagerDutyClient
+	routingKey string
+	source     string
+	severity   pagerduty.Severity
+	limit      service.SpendLimit
+	incidents  IncidentStore
+}
+
+var _ Notifier = (*pagerDutyNotifier)(nil)
+
+// NewPagerDutyNotifier: pagerDutyNotifier のコンストラクタ
+//
+// source はアラートの発生元として表示され、インシデントの重複排除キーの接頭辞にも使用する
+func NewPagerDutyNotifier(client pagerduty.IPagerDutyClient, routingKey, source string, severity pagerduty.Severity, limit service.SpendLimit, incidents IncidentStore) *pagerDutyNotifier {
+	return &pagerDutyNotifier{
+		client:     client,
+		routingKey: routingKey,
+		source:     source,
+		severity:   severity,
+		limit:      limit,
+		incidents:  incidents,
+	}
+}
+
+// NotifyDaily: 昨日の利用コストまたは今月の利用コストの予測値が上限を超えた場合に PagerDuty にアラートを送信
+//
+// インシデントは上限の項目と集計対象日ごとに作成し、上限を超えた日ごとに trigger を送信する。
+// 解決 (resolve) は上限を下回った項目の未解決のインシデントにのみ送信し、上限を超え続けている項目のインシデントは解決しない
+func (pn *pagerDutyNotifier) NotifyDaily(ctx context.Context, usage *service.DailyCostUsage) error {
+	reportDate := usage.YesterdayPeriod.Start
+
+	var (
+		errs      []error
+		breached  = make(map[service.SpendLimitKind]bool)
+		stillOpen []string
+	)
+	breaches := usage.ExceededSpendLimits(pn.limit)
+	if len(breaches) > 0 {
+		slog.WarnContext(ctx, "spend limit exceeded",
+			slog.String("report date", reportDate),
+			slog.Int("breaches", len(breaches)),
+		)
+	}
+	for _, b := range breaches {
+		dedupKey := pn.dedupKey(b.Kind, reportDate)
+		breached[b.Kind] = true
+		stillOpen = append(stillOpen, dedupKey)
+		if err := pn.client.SendEvent(ctx, pn.triggerEvent(dedupKey, reportDate, usage, b)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// 未解決のインシデントを取得できない場合は、保存済みのインシデントを失わないよう解決と保存を行わない
+	open, err := pn.incidents.OpenIncidents(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to load open pagerduty incidents: %w", err))
+		return fmt.Errorf("failed to send pagerduty event: %w", errors.Join(errs...))
+	}
+
+	for _, dedupKey := range open {
+		if slices.Contains(stillOpen, dedupKey) {
+			continue
+		}
+		if breached[pn.limitKind(dedupKey)] {
+			stillOpen = append(stillOpen, dedupKey)
+			continue
+		}
+		if err := pn.client.SendEvent(ctx, pagerduty.Event{
+			RoutingKey:  pn.routingKey,
+			EventAction: pagerduty.EventActionResolve,
+			DedupKey:    dedupKey,
+		}); err != nil {
+			// 解決できなかったインシデントは次回の実行で再度解決する
+			stillOpen = append(stillOpen, dedupKey)
+			errs = append(errs, err)
+		}
+	}
+
+	if err := pn.incidents.SaveOpenIncidents(ctx, stillOpen); err != nil {
+		errs = append(errs, fmt.Errorf("failed to save open pagerduty incidents: %w", err))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("failed to send pagerduty event: %w", err)
+	}
+	return nil
+}
+
+// NotifyWeekly: 週次利用コストレポートは監視対象外のため何もしない
+func (pn *pagerDutyNotifier) NotifyWeekly(ctx context.Context, usage *service.WeeklyCostUsage) error {
+	return nil
+}
+
+// NotifyMonthly: 月次利用コストレポートは監視対象外のため何もしない
+func (pn *pagerDutyNotifier) NotifyMonthly(ctx context.Context, usage *service.MonthlyCostUsage) error {
+	return nil
+}
+
+// triggerEvent: 上限を超えた項目からインシデントを作成するイベントを生成
+func (pn *pagerDutyNotifier) triggerEvent(dedupKey, reportDate string, usage *service.DailyCostUsage, breach service.SpendLimitBreach) pagerduty.Event {
+	now := time.Now().UTC()
+	return pagerduty.Event{
+		RoutingKey:  pn.routingKey,
+		EventAction: pagerduty.EventActionTrigger,
+		DedupKey:    dedupKey,
+		Payload: &pagerduty.Payload{
+			Summary:   fmt.Sprintf("AWS の利用コストが上限を超えています (%s): %s", reportDate, breach.String()),
+			Source:    pn.source,
+			Severity:  pn.severity,
+			Timestamp: &now,
+			Component: "aws-cost",
+			Class:     "spend-limit",
+			CustomDetails: map[string]any{
+				"report_date":    reportDate,
+				"limit":          breach.Kind,
+				"currency":       usage.Currency,
+				"yesterday_cost": usage.YesterdayCost,
+				"forecast_cost":  usage.ForecastCost,
+				"breach":         breach.String(),
+			},
+		},
+	}
+}
+
+// dedupKey: 上限の項目と集計対象日 (YYYY-MM-DD) ごとのインシデントの重複排除キーを生成
+func (pn *pagerDutyNotifier) dedupKey(kind service.SpendLimitKind, reportDate string) string {
+	return fmt.Sprintf("%s:%s:%s", pn.source, kind, reportDate)
+}
+
+// limitKind: インシデントの重複排除キーから上限の項目を取得
+func (pn *pagerDutyNotifier) limitKind(dedupKey string) service.SpendLimitKind {
+	kind, _, _ := strings.Cut(strings.TrimPrefix(dedupKey, pn.source+":"), ":")
+	return service.SpendLimitKind(kind)
+}
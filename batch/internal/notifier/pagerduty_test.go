@@ -0,0 +1,199 @@
+package notifier_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/pagerduty"
+	"github.com/tamaco489/cost_explorer/batch/internal/notifier"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// TestPagerDutyNotifier_NotifyDaily: 利用コストが上限を超えた場合は上限の項目と集計対象日ごとのインシデントを作成し、上限を下回った場合のみ作成済みのインシデントを解決することをテストします
+func TestPagerDutyNotifier_NotifyDaily(t *testing.T) {
+	limit := service.SpendLimit{YesterdayCost: 10000, ForecastCost: 300000}
+
+	tests := []struct {
+		name          string
+		open          []string
+		reportDate    string
+		yesterdayCost float64
+		forecastCost  float64
+		wantActions   []pagerduty.EventAction
+		wantDedupKeys []string
+		wantOpen      []string
+	}{
+		{
+			name:          "正常系: 昨日の利用コストが上限を超えている場合は集計対象日のインシデントを作成する",
+			reportDate:    "2024-09-10",
+			yesterdayCost: 12000,
+			forecastCost:  200000,
+			wantActions:   []pagerduty.EventAction{pagerduty.EventActionTrigger},
+			wantDedupKeys: []string{"cost-explorer-dev:yesterday-cost:2024-09-10"},
+			wantOpen:      []string{"cost-explorer-dev:yesterday-cost:2024-09-10"},
+		},
+		{
+			name:          "正常系: 両方の上限を超えている場合は上限の項目ごとに trigger を送信する",
+			reportDate:    "2024-09-10",
+			yesterdayCost: 12000,
+			forecastCost:  350000,
+			wantActions:   []pagerduty.EventAction{pagerduty.EventActionTrigger, pagerduty.EventActionTrigger},
+			wantDedupKeys: []string{"cost-explorer-dev:yesterday-cost:2024-09-10", "cost-explorer-dev:forecast-cost:2024-09-10"},
+			wantOpen:      []string{"cost-explorer-dev:yesterday-cost:2024-09-10", "cost-explorer-dev:forecast-cost:2024-09-10"},
+		},
+		{
+			name:          "正常系: 上限を超え続けている場合は当日分の trigger のみを送信し、前日分は resolve しない",
+			open:          []string{"cost-explorer-dev:yesterday-cost:2024-09-10"},
+			reportDate:    "2024-09-11",
+			yesterdayCost: 13000,
+			forecastCost:  200000,
+			wantActions:   []pagerduty.EventAction{pagerduty.EventActionTrigger},
+			wantDedupKeys: []string{"cost-explorer-dev:yesterday-cost:2024-09-11"},
+			wantOpen:      []string{"cost-explorer-dev:yesterday-cost:2024-09-11", "cost-explorer-dev:yesterday-cost:2024-09-10"},
+		},
+		{
+			name:          "正常系: 上限を下回った項目のインシデントのみを解決する",
+			open:          []string{"cost-explorer-dev:yesterday-cost:2024-09-10", "cost-explorer-dev:forecast-cost:2024-09-10"},
+			reportDate:    "2024-09-11",
+			yesterdayCost: 8000,
+			forecastCost:  350000,
+			wantActions:   []pagerduty.EventAction{pagerduty.EventActionTrigger, pagerduty.EventActionResolve},
+			wantDedupKeys: []string{"cost-explorer-dev:forecast-cost:2024-09-11", "cost-explorer-dev:yesterday-cost:2024-09-10"},
+			wantOpen:      []string{"cost-explorer-dev:forecast-cost:2024-09-11", "cost-explorer-dev:forecast-cost:2024-09-10"},
+		},
+		{
+			name:          "正常系: 上限を下回った場合はその項目の未解決のインシデントを全て解決する",
+			open:          []string{"cost-explorer-dev:yesterday-cost:2024-09-10", "cost-explorer-dev:yesterday-cost:2024-09-11"},
+			reportDate:    "2024-09-12",
+			yesterdayCost: 8000,
+			forecastCost:  200000,
+			wantActions:   []pagerduty.EventAction{pagerduty.EventActionResolve, pagerduty.EventActionResolve},
+			wantDedupKeys: []string{"cost-explorer-dev:yesterday-cost:2024-09-10", "cost-explorer-dev:yesterday-cost:2024-09-11"},
+			wantOpen:      []string{},
+		},
+		{
+			name:          "正常系: 上限を下回っていて未解決のインシデントがない場合は何も送信しない",
+			reportDate:    "2024-09-10",
+			yesterdayCost: 8000,
+			forecastCost:  200000,
+			wantOpen:      []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				received []pagerduty.Event
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var event pagerduty.Event
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+				mu.Lock()
+				received = append(received, event)
+				mu.Unlock()
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte(`{"status": "success", "message": "Event processed"}`))
+			}))
+			defer server.Close()
+
+			incidents := notifier.NewFileIncidentStore(t.TempDir(), "cost-explorer-dev/pagerduty")
+			if tt.open != nil {
+				assert.NoError(t, incidents.SaveOpenIncidents(context.Background(), tt.open))
+			}
+
+			pn := notifier.NewPagerDutyNotifier(pagerduty.NewPagerDutyClient(pagerduty.WithEventsURL(server.URL)), "test_routing_key", "cost-explorer-dev", pagerduty.SeverityCritical, limit, incidents)
+			err := pn.NotifyDaily(context.Background(), &service.DailyCostUsage{
+				YesterdayCost:   tt.yesterdayCost,
+				ForecastCost:    tt.forecastCost,
+				Currency:        "JPY",
+				YesterdayPeriod: service.Period{Start: tt.reportDate, End: tt.reportDate},
+			})
+			assert.NoError(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Len(t, received, len(tt.wantActions))
+			for i, event := range received {
+				assert.Equal(t, "test_routing_key", event.RoutingKey)
+				assert.Equal(t, tt.wantActions[i], event.EventAction)
+				assert.Equal(t, tt.wantDedupKeys[i], event.DedupKey)
+
+				if event.EventAction == pagerduty.EventActionResolve {
+					assert.Nil(t, event.Payload)
+					continue
+				}
+				if assert.NotNil(t, event.Payload) {
+					assert.Equal(t, "cost-explorer-dev", event.Payload.Source)
+					assert.Equal(t, pagerduty.SeverityCritical, event.Payload.Severity)
+					assert.Contains(t, event.Payload.Summary, tt.reportDate)
+				}
+			}
+
+			open, err := incidents.OpenIncidents(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantOpen, open)
+		})
+	}
+}
+
+// TestPagerDutyNotifier_NotifyDaily_ConsecutiveDays: 2日連続で上限を超えた場合は日ごとにインシデントを作成し、resolve を送信しないことをテストします
+func TestPagerDutyNotifier_NotifyDaily_ConsecutiveDays(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []pagerduty.Event
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event pagerduty.Event
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		mu.Lock()
+		received = append(received, event)
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status": "success", "message": "Event processed"}`))
+	}))
+	defer server.Close()
+
+	incidents := notifier.NewFileIncidentStore(t.TempDir(), "cost-explorer-dev/pagerduty")
+	pn := notifier.NewPagerDutyNotifier(pagerduty.NewPagerDutyClient(pagerduty.WithEventsURL(server.URL)), "test_routing_key", "cost-explorer-dev", pagerduty.SeverityCritical, service.SpendLimit{YesterdayCost: 10000}, incidents)
+	for _, reportDate := range []string{"2024-09-10", "2024-09-11"} {
+		err := pn.NotifyDaily(context.Background(), &service.DailyCostUsage{
+			YesterdayCost:   12000,
+			Currency:        "JPY",
+			YesterdayPeriod: service.Period{Start: reportDate, End: reportDate},
+		})
+		assert.NoError(t, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if assert.Len(t, received, 2) {
+		for _, event := range received {
+			assert.Equal(t, pagerduty.EventActionTrigger, event.EventAction)
+		}
+		assert.Equal(t, "cost-explorer-dev:yesterday-cost:2024-09-10", received[0].DedupKey)
+		assert.Equal(t, "cost-explorer-dev:yesterday-cost:2024-09-11", received[1].DedupKey)
+	}
+}
+
+// TestPagerDutyNotifier_Error: PagerDuty がイベントを受け付けなかった場合はエラーを返すことをテストします
+func TestPagerDutyNotifier_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status": "invalid event", "message": "Event object is invalid"}`))
+	}))
+	defer server.Close()
+
+	pn := notifier.NewPagerDutyNotifier(pagerduty.NewPagerDutyClient(pagerduty.WithEventsURL(server.URL)), "test_routing_key", "cost-explorer-dev", pagerduty.SeverityCritical, service.SpendLimit{YesterdayCost: 10000}, notifier.NewFileIncidentStore(t.TempDir(), "cost-explorer-dev/pagerduty"))
+	err := pn.NotifyDaily(context.Background(), &service.DailyCostUsage{
+		YesterdayCost:   12000,
+		YesterdayPeriod: service.Period{Start: "2024-09-10", End: "2024-09-10"},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status=400")
+}
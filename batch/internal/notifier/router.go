@@ -0,0 +1,210 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// Route: 利用コストレポートの通知先を振り分けるルール
+//
+// 条件を指定しない項目は全ての値に一致し、一致した全てのルールの通知先にレポートを通知する
+type Route struct {
+	Name       string
+	Reports    []ReportType       // レポートの種類
+	Envs       []string           // 実行環境
+	Severities []service.Severity // レポートの重要度
+	Accounts   []string           // レポートに利用コストが含まれる連結アカウントのIDまたはアカウント名
+	Tags       []string           // レポートに利用コストが含まれるタグ (例: team:platform)
+	Targets    []string           // 通知先の名前
+}
+
+// Target: ルールから参照する通知先
+type Target struct {
+	Name        string
+	Destination string // ログと dry-run で表示する送信先
+	Notifier    Notifier
+}
+
+// reportScope: ルールとの照合に使用するレポートの属性
+type reportScope struct {
+	severity service.Severity
+	accounts []string
+	tags     []string
+}
+
+// match: レポートの属性がルールの条件に一致するかを判定
+func (r Route) match(reportType ReportType, env string, scope reportScope) bool {
+	return matchAny(r.Reports, reportType) &&
+		matchAny(r.Envs, env) &&
+		matchAny(r.Severities, scope.severity) &&
+		overlaps(r.Accounts, scope.accounts) &&
+		overlaps(r.Tags, scope.tags)
+}
+
+// matchAny: 条件が未指定、または値が条件に含まれるかを判定
+func matchAny[T comparable](conditions []T, value T) bool {
+	return len(conditions) == 0 || slices.Contains(conditions, value)
+}
+
+// overlaps: 条件が未指定、または値のいずれかが条件に含まれるかを判定
+func overlaps(conditions, values []string) bool {
+	if len(conditions) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if slices.Contains(conditions, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// router: ルールに一致した通知先にレポートを振り分ける Notifier
+type router struct {
+	reportType ReportType
+	env        string
+	routes     []Route
+	targets    map[string]Target
+	rule       service.SeverityRule
+	dryRun     io.Writer
+}
+
+var _ Notifier = (*router)(nil)
+
+// RouterOption: router の設定を変更するための関数
+type RouterOption func(*router)
+
+// WithDryRun: レポートを送信せずに、振り分け先を w に出力する
+func WithDryRun(w io.Writer) RouterOption {
+	return func(r *router) {
+		r.dryRun = w
+	}
+}
+
+// NewRouter: router のコンストラクタ
+//
+// targets はレポートの種類に応じて生成した通知先で、送信先が設定されていない通知先は含まなくてよい
+func NewRouter(reportType ReportType, env string, routes []Route, targets []Target, rule service.SeverityRule, opts ...RouterOption) *router {
+	r := &router{
+		reportType: reportType,
+		env:        env,
+		routes:     routes,
+		targets:    make(map[string]Target, len(targets)),
+		rule:       rule,
+	}
+	for _, t := range targets {
+		r.targets[t.Name] = t
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// NotifyDaily: 日次利用コストレポートをルールに一致した通知先に通知
+func (r *router) NotifyDaily(ctx context.Context, usage *service.DailyCostUsage) error {
+	scope := reportScope{severity: usage.Severity(r.rule)}
+	for _, ac := range usage.AccountCosts {
+		scope.accounts = append(scope.accounts, ac.AccountID, ac.AccountName)
+	}
+	for _, table := range usage.TagCosts {
+		for _, tc := range table.Costs {
+			scope.tags = append(scope.tags, table.TagKey+":"+tc.TagValue)
+		}
+	}
+
+	return r.dispatch(ctx, scope, func(n Notifier) error { return n.NotifyDaily(ctx, usage) })
+}
+
+// NotifyWeekly: 週次利用コストレポートをルールに一致した通知先に通知
+func (r *router) NotifyWeekly(ctx context.Context, usage *service.WeeklyCostUsage) error {
+	scope := reportScope{severity: usage.Severity(r.rule)}
+	for _, ac := range usage.AccountCosts {
+		scope.accounts = append(scope.accounts, ac.AccountID, ac.AccountName)
+	}
+	for _, table := range usage.TagCosts {
+		for _, tc := range table.Costs {
+			scope.tags = append(scope.tags, table.TagKey+":"+tc.TagValue)
+		}
+	}
+
+	return r.dispatch(ctx, scope, func(n Notifier) error { return n.NotifyWeekly(ctx, usage) })
+}
+
+// NotifyMonthly: 月次利用コストレポートをルールに一致した通知先に通知
+//
+// 月次レポートにはアカウント別・タグ別の内訳がないため、アカウントやタグを条件とするルールには一致しない
+func (r *router) NotifyMonthly(ctx context.Context, usage *service.MonthlyCostUsage) error {
+	scope := reportScope{severity: usage.Severity(r.rule)}
+	return r.dispatch(ctx, scope, func(n Notifier) error { return n.NotifyMonthly(ctx, usage) })
+}
+
+// dispatch: ルールに一致した通知先を重複なく選択し、レポートを通知
+func (r *router) dispatch(ctx context.Context, scope reportScope, notify func(n Notifier) error) error {
+	var (
+		matched []string
+		names   []string
+	)
+	for _, route := range r.routes {
+		if !route.match(r.reportType, r.env, scope) {
+			continue
+		}
+		matched = append(matched, route.Name)
+		for _, name := range route.Targets {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	targets := make([]Target, 0, len(names))
+	for _, name := range names {
+		t, ok := r.targets[name]
+		if !ok {
+			// メールの宛先が未設定の場合など、このレポートの送信先が設定されていない通知先は送信しない
+			slog.InfoContext(ctx, "notification target skipped",
+				slog.String("report", r.reportType.String()),
+				slog.String("target", name),
+			)
+			continue
+		}
+		targets = append(targets, t)
+	}
+
+	slog.InfoContext(ctx, "notification routed",
+		slog.String("report", r.reportType.String()),
+		slog.String("env", r.env),
+		slog.String("severity", scope.severity.String()),
+		slog.Any("routes", matched),
+		slog.Any("targets", names),
+		slog.Bool("dry run", r.dryRun != nil),
+	)
+
+	if len(targets) == 0 {
+		slog.WarnContext(ctx, "no notification targets matched",
+			slog.String("report", r.reportType.String()),
+			slog.String("severity", scope.severity.String()),
+		)
+		return nil
+	}
+
+	if r.dryRun != nil {
+		for _, t := range targets {
+			if _, err := fmt.Fprintf(r.dryRun, "[dry-run] %s report (severity: %s) -> %s: %s\n", r.reportType, scope.severity, t.Name, t.Destination); err != nil {
+				return fmt.Errorf("failed to write dry-run output: %w", err)
+			}
+		}
+		return nil
+	}
+
+	notifiers := make([]Notifier, 0, len(targets))
+	for _, t := range targets {
+		notifiers = append(notifiers, t.Notifier)
+	}
+	return notify(Multi(notifiers...))
+}
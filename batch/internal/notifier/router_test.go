@@ -0,0 +1,177 @@
+package notifier_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/notifier"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// recordNotifier: 通知されたレポートの種類を記録する Notifier
+type recordNotifier struct {
+	name     string
+	received *[]string
+	err      error
+}
+
+func (rn recordNotifier) NotifyDaily(ctx context.Context, usage *service.DailyCostUsage) error {
+	*rn.received = append(*rn.received, rn.name+"/daily")
+	return rn.err
+}
+
+func (rn recordNotifier) NotifyWeekly(ctx context.Context, usage *service.WeeklyCostUsage) error {
+	*rn.received = append(*rn.received, rn.name+"/weekly")
+	return rn.err
+}
+
+func (rn recordNotifier) NotifyMonthly(ctx context.Context, usage *service.MonthlyCostUsage) error {
+	*rn.received = append(*rn.received, rn.name+"/monthly")
+	return rn.err
+}
+
+// TestRouter_NotifyDaily: 日次利用コストレポートを重要度・環境・アカウント・タグに一致したルールの通知先に振り分けることをテストします
+func TestRouter_NotifyDaily(t *testing.T) {
+	routes := []notifier.Route{
+		{Name: "critical", Severities: []service.Severity{service.SeverityCritical}, Targets: []string{"pagerduty", "slack"}},
+		{Name: "prd", Envs: []string{"prd"}, Targets: []string{"teams"}},
+		{Name: "platform", Accounts: []string{"platform"}, Tags: []string{"team:platform"}, Targets: []string{"platform-slack"}},
+		{Name: "weekly", Reports: []notifier.ReportType{notifier.ReportTypeWeekly}, Targets: []string{"email"}},
+	}
+	rule := service.SeverityRule{AlertThreshold: 120, SpendLimit: service.SpendLimit{YesterdayCost: 10000}}
+
+	tests := []struct {
+		name   string
+		env    string
+		usage  *service.DailyCostUsage
+		wanted []string
+	}{
+		{
+			name:   "正常系: 利用コストが上限を超えた場合は critical のルールの通知先に重複なく通知する",
+			env:    "dev",
+			usage:  &service.DailyCostUsage{YesterdayCost: 12000, AverageDailyCost: 12000},
+			wanted: []string{"pagerduty/daily", "slack/daily"},
+		},
+		{
+			name:   "正常系: 環境が一致するルールの通知先に通知する",
+			env:    "prd",
+			usage:  &service.DailyCostUsage{YesterdayCost: 12000, AverageDailyCost: 12000},
+			wanted: []string{"pagerduty/daily", "slack/daily", "teams/daily"},
+		},
+		{
+			name: "正常系: アカウントとタグが一致するルールの通知先に通知する",
+			env:  "dev",
+			usage: &service.DailyCostUsage{
+				YesterdayCost:    100,
+				AverageDailyCost: 100,
+				AccountCosts:     []service.DailyAccountCost{{AccountID: "123456789012", AccountName: "platform"}},
+				TagCosts:         []service.DailyTagCostTable{{TagKey: "team", Costs: []service.DailyTagCost{{TagValue: "platform"}}}},
+			},
+			wanted: []string{"platform-slack/daily"},
+		},
+		{
+			name: "正常系: アカウントのみ一致しタグが一致しない場合は通知しない",
+			env:  "dev",
+			usage: &service.DailyCostUsage{
+				YesterdayCost:    100,
+				AverageDailyCost: 100,
+				AccountCosts:     []service.DailyAccountCost{{AccountID: "123456789012", AccountName: "platform"}},
+				TagCosts:         []service.DailyTagCostTable{{TagKey: "team", Costs: []service.DailyTagCost{{TagValue: "data"}}}},
+			},
+			wanted: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received []string
+			targets := make([]notifier.Target, 0)
+			for _, name := range []string{"slack", "teams", "email", "pagerduty", "platform-slack"} {
+				targets = append(targets, notifier.Target{Name: name, Notifier: recordNotifier{name: name, received: &received}})
+			}
+
+			r := notifier.NewRouter(notifier.ReportTypeDaily, tt.env, routes, targets, rule)
+			assert.NoError(t, r.NotifyDaily(context.Background(), tt.usage))
+			assert.Equal(t, tt.wanted, received)
+		})
+	}
+}
+
+// TestRouter_NotifyWeekly: 週次利用コストレポートを重要度とレポートの種類に一致したルールの通知先に振り分けることをテストします
+func TestRouter_NotifyWeekly(t *testing.T) {
+	routes := []notifier.Route{
+		{Name: "warn", Severities: []service.Severity{service.SeverityWarn}, Targets: []string{"slack"}},
+		{Name: "weekly", Reports: []notifier.ReportType{notifier.ReportTypeWeekly}, Targets: []string{"email"}},
+	}
+
+	tests := []struct {
+		name   string
+		usage  *service.WeeklyCostUsage
+		wanted []string
+	}{
+		{
+			name:   "正常系: 閾値を超えた場合は warn のルールとレポートの種類が一致するルールの通知先に通知する",
+			usage:  &service.WeeklyCostUsage{LastWeekCost: 1500, WeekBeforeLastCost: 1000, PercentageChange: 150},
+			wanted: []string{"slack/weekly", "email/weekly"},
+		},
+		{
+			name:   "正常系: 閾値を超えていない場合はレポートの種類が一致するルールの通知先のみに通知する",
+			usage:  &service.WeeklyCostUsage{LastWeekCost: 1000, WeekBeforeLastCost: 1000, PercentageChange: 100},
+			wanted: []string{"email/weekly"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received []string
+			targets := []notifier.Target{
+				{Name: "slack", Notifier: recordNotifier{name: "slack", received: &received}},
+				{Name: "email", Notifier: recordNotifier{name: "email", received: &received}},
+			}
+
+			r := notifier.NewRouter(notifier.ReportTypeWeekly, "dev", routes, targets, service.SeverityRule{AlertThreshold: 120})
+			assert.NoError(t, r.NotifyWeekly(context.Background(), tt.usage))
+			assert.Equal(t, tt.wanted, received)
+		})
+	}
+}
+
+// TestRouter_DryRun: dry-run の場合はレポートを送信せずに振り分け先を出力することをテストします
+func TestRouter_DryRun(t *testing.T) {
+	var (
+		received []string
+		out      bytes.Buffer
+	)
+	routes := []notifier.Route{{Name: "default", Targets: []string{"slack", "email"}}}
+	targets := []notifier.Target{
+		{Name: "slack", Destination: "channel C0123456789", Notifier: recordNotifier{name: "slack", received: &received}},
+		{Name: "email", Destination: "a@example.com, b@example.com", Notifier: recordNotifier{name: "email", received: &received}},
+	}
+
+	r := notifier.NewRouter(notifier.ReportTypeMonthly, "dev", routes, targets, service.SeverityRule{}, notifier.WithDryRun(&out))
+	assert.NoError(t, r.NotifyMonthly(context.Background(), &service.MonthlyCostUsage{}))
+	assert.Empty(t, received)
+	assert.Equal(t,
+		"[dry-run] monthly report (severity: info) -> slack: channel C0123456789\n"+
+			"[dry-run] monthly report (severity: info) -> email: a@example.com, b@example.com\n",
+		out.String(),
+	)
+}
+
+// TestRouter_Error: 一部の通知先で失敗した場合も残りの通知先に通知し、エラーを返すことをテストします
+func TestRouter_Error(t *testing.T) {
+	var received []string
+	routes := []notifier.Route{{Name: "default", Targets: []string{"slack", "teams"}}}
+	targets := []notifier.Target{
+		{Name: "slack", Notifier: recordNotifier{name: "slack", received: &received, err: errors.New("failed to send slack message")}},
+		{Name: "teams", Notifier: recordNotifier{name: "teams", received: &received}},
+	}
+
+	r := notifier.NewRouter(notifier.ReportTypeMonthly, "dev", routes, targets, service.SeverityRule{})
+	err := r.NotifyMonthly(context.Background(), &service.MonthlyCostUsage{})
+	assert.ErrorContains(t, err, "failed to send slack message")
+	assert.Equal(t, []string{"slack/monthly", "teams/monthly"}, received)
+}
@@ -0,0 +1,73 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/slack"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// Slack に送信するメッセージの形式
+const (
+	SlackMessageFormatBlocks     = "blocks"
+	SlackMessageFormatAttachment = "attachment"
+)
+
+// slackNotifier: 利用コストレポートを Slack に通知する Notifier
+type slackNotifier struct {
+	client         slack.ISlackClient
+	messageFormat  string
+	alertThreshold float64
+}
+
+var _ Notifier = (*slackNotifier)(nil)
+
+// NewSlackNotifier: slackNotifier のコンストラクタ
+//
+// messageFormat が attachment の場合は従来の添付ファイル形式で、それ以外の場合は Block Kit で送信する
+func NewSlackNotifier(client slack.ISlackClient, messageFormat string, alertThreshold float64) *slackNotifier {
+	return &slackNotifier{
+		client:         client,
+		messageFormat:  messageFormat,
+		alertThreshold: alertThreshold,
+	}
+}
+
+// NotifyDaily: 日次利用コストレポートを Slack に通知
+func (sn *slackNotifier) NotifyDaily(ctx context.Context, usage *service.DailyCostUsage) error {
+	if sn.messageFormat == SlackMessageFormatAttachment {
+		return sn.sendMessage(ctx, slack.DailyReportTitle, usage.GenDailySlackMessage())
+	}
+	return sn.sendBlocks(ctx, slack.DailyReportTitle, usage.GenDailySlackBlocks(sn.alertThreshold))
+}
+
+// NotifyWeekly: 週次利用コストレポートを Slack に通知
+func (sn *slackNotifier) NotifyWeekly(ctx context.Context, usage *service.WeeklyCostUsage) error {
+	if sn.messageFormat == SlackMessageFormatAttachment {
+		return sn.sendMessage(ctx, slack.WeeklyReportTitle, usage.GenWeeklySlackMessage())
+	}
+	return sn.sendBlocks(ctx, slack.WeeklyReportTitle, usage.GenWeeklySlackBlocks(sn.alertThreshold))
+}
+
+// NotifyMonthly: 月次利用コストレポートを Slack に通知
+func (sn *slackNotifier) NotifyMonthly(ctx context.Context, usage *service.MonthlyCostUsage) error {
+	if sn.messageFormat == SlackMessageFormatAttachment {
+		return sn.sendMessage(ctx, slack.MonthlyReportTitle, usage.GenMonthlySlackMessage())
+	}
+	return sn.sendBlocks(ctx, slack.MonthlyReportTitle, usage.GenMonthlySlackBlocks(sn.alertThreshold))
+}
+
+func (sn *slackNotifier) sendMessage(ctx context.Context, title slack.ReportTitle, attachment slack.Attachment) error {
+	if err := sn.client.SendMessage(ctx, title.String(), attachment); err != nil {
+		return fmt.Errorf("failed to send slack message: %w", err)
+	}
+	return nil
+}
+
+func (sn *slackNotifier) sendBlocks(ctx context.Context, title slack.ReportTitle, message slack.BlockMessage) error {
+	if err := sn.client.SendBlocks(ctx, title.String(), message); err != nil {
+		return fmt.Errorf("failed to send slack message: %w", err)
+	}
+	return nil
+}
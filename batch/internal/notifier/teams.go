@@ -0,0 +1,47 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/teams"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// teamsNotifier: 利用コストレポートを Teams に Adaptive Card として通知する Notifier
+type teamsNotifier struct {
+	client         teams.ITeamsClient
+	alertThreshold float64
+}
+
+var _ Notifier = (*teamsNotifier)(nil)
+
+// NewTeamsNotifier: teamsNotifier のコンストラクタ
+func NewTeamsNotifier(client teams.ITeamsClient, alertThreshold float64) *teamsNotifier {
+	return &teamsNotifier{
+		client:         client,
+		alertThreshold: alertThreshold,
+	}
+}
+
+// NotifyDaily: 日次利用コストレポートを Teams に通知
+func (tn *teamsNotifier) NotifyDaily(ctx context.Context, usage *service.DailyCostUsage) error {
+	return tn.sendCard(ctx, usage.GenDailyTeamsCard(tn.alertThreshold))
+}
+
+// NotifyWeekly: 週次利用コストレポートを Teams に通知
+func (tn *teamsNotifier) NotifyWeekly(ctx context.Context, usage *service.WeeklyCostUsage) error {
+	return tn.sendCard(ctx, usage.GenWeeklyTeamsCard(tn.alertThreshold))
+}
+
+// NotifyMonthly: 月次利用コストレポートを Teams に通知
+func (tn *teamsNotifier) NotifyMonthly(ctx context.Context, usage *service.MonthlyCostUsage) error {
+	return tn.sendCard(ctx, usage.GenMonthlyTeamsCard(tn.alertThreshold))
+}
+
+func (tn *teamsNotifier) sendCard(ctx context.Context, card teams.AdaptiveCard) error {
+	if err := tn.client.SendCard(ctx, card); err != nil {
+		return fmt.Errorf("failed to send teams message: %w", err)
+	}
+	return nil
+}
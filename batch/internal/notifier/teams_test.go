@@ -0,0 +1,116 @@
+package notifier_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/teams"
+	"github.com/tamaco489/cost_explorer/batch/internal/notifier"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// teamsMessage: Teams の Incoming Webhook に送信されたメッセージ
+type teamsMessage struct {
+	Type        string `json:"type"`
+	Attachments []struct {
+		ContentType string `json:"contentType"`
+		Content     struct {
+			Type    string            `json:"type"`
+			Version string            `json:"version"`
+			Body    []json.RawMessage `json:"body"`
+		} `json:"content"`
+	} `json:"attachments"`
+}
+
+// newTeamsServer: 受信したメッセージを記録する Incoming Webhook のテスト用サーバーを生成
+func newTeamsServer(t *testing.T, status int, received *[]byte) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		*received = b
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestTeamsNotifier_NotifyWeekly: 週次利用コストレポートを Adaptive Card として送信することをテストします
+func TestTeamsNotifier_NotifyWeekly(t *testing.T) {
+	var received []byte
+	server := newTeamsServer(t, http.StatusOK, &received)
+
+	tn := notifier.NewTeamsNotifier(teams.NewTeamsClient(server.URL), 120)
+	err := tn.NotifyWeekly(context.Background(), &service.WeeklyCostUsage{
+		LastWeekCost:       1500,
+		WeekBeforeLastCost: 1000,
+		PercentageChange:   150,
+		Metric:             service.CostMetricUnblended,
+		Currency:           "EUR",
+		DailyCosts: []service.WeeklyDailyCost{
+			{LastWeekDate: "2024-09-02", LastWeekCost: 300, WeekBeforeLastDate: "2024-08-26", WeekBeforeLastCost: 200, PercentageChange: 150},
+		},
+	})
+	assert.NoError(t, err)
+
+	var msg teamsMessage
+	assert.NoError(t, json.Unmarshal(received, &msg))
+	assert.Equal(t, "message", msg.Type)
+	assert.Len(t, msg.Attachments, 1)
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", msg.Attachments[0].ContentType)
+	assert.Equal(t, "AdaptiveCard", msg.Attachments[0].Content.Type)
+	assert.Equal(t, "1.5", msg.Attachments[0].Content.Version)
+
+	body := string(received)
+	assert.Contains(t, body, "AWS 週次利用コストレポート")
+	assert.Contains(t, body, "€1500.00")
+	assert.Contains(t, body, `"text":"(€)"`)
+	assert.Contains(t, body, `"color":"Attention"`) // 閾値を超えているため警告が表示される
+	assert.Contains(t, body, `"type":"Table"`)
+	assert.Contains(t, body, "09-02")
+}
+
+// TestTeamsNotifier_NotifyDaily: 日次利用コストレポートを Adaptive Card として送信することをテストします
+func TestTeamsNotifier_NotifyDaily(t *testing.T) {
+	var received []byte
+	server := newTeamsServer(t, http.StatusOK, &received)
+
+	tn := notifier.NewTeamsNotifier(teams.NewTeamsClient(server.URL), 120)
+	err := tn.NotifyDaily(context.Background(), &service.DailyCostUsage{
+		YesterdayCost:    100,
+		ActualCost:       1000,
+		AverageDailyCost: 100,
+		ForecastCost:     3000,
+		Metric:           service.CostMetricUnblended,
+		YesterdayServiceCosts: []service.ServiceCost{
+			{ServiceName: "Amazon Elastic Compute Cloud - Compute", Cost: 80},
+		},
+	})
+	assert.NoError(t, err)
+
+	body := string(received)
+	assert.Contains(t, body, "AWS 日次利用コストレポート")
+	assert.Contains(t, body, "Amazon Elastic Compute Cloud - Compute")
+	assert.NotContains(t, body, `"color":"Attention"`) // 閾値を超えていないため警告は表示されない
+}
+
+// TestTeamsNotifier_Error: Incoming Webhook が 2xx 以外のステータスコードを返した場合はエラーを返すことをテストします
+func TestTeamsNotifier_Error(t *testing.T) {
+	var received []byte
+	server := newTeamsServer(t, http.StatusBadRequest, &received)
+
+	tn := notifier.NewTeamsNotifier(teams.NewTeamsClient(server.URL), 120)
+	err := tn.NotifyMonthly(context.Background(), &service.MonthlyCostUsage{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status=400")
+}
@@ -0,0 +1,52 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/webhook"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// webhookNotifier: 利用コストレポートをバージョン付きの JSON として Webhook に通知する Notifier
+type webhookNotifier struct {
+	client webhook.IWebhookClient
+}
+
+var _ Notifier = (*webhookNotifier)(nil)
+
+// NewWebhookNotifier: webhookNotifier のコンストラクタ
+func NewWebhookNotifier(client webhook.IWebhookClient) *webhookNotifier {
+	return &webhookNotifier{
+		client: client,
+	}
+}
+
+// NotifyDaily: 日次利用コストレポートを Webhook に通知
+func (wn *webhookNotifier) NotifyDaily(ctx context.Context, usage *service.DailyCostUsage) error {
+	return wn.post(ctx, ReportTypeDaily, usage.GenDailyReportPayload(time.Now()))
+}
+
+// NotifyWeekly: 週次利用コストレポートを Webhook に通知
+func (wn *webhookNotifier) NotifyWeekly(ctx context.Context, usage *service.WeeklyCostUsage) error {
+	return wn.post(ctx, ReportTypeWeekly, usage.GenWeeklyReportPayload(time.Now()))
+}
+
+// NotifyMonthly: 月次利用コストレポートを Webhook に通知
+func (wn *webhookNotifier) NotifyMonthly(ctx context.Context, usage *service.MonthlyCostUsage) error {
+	return wn.post(ctx, ReportTypeMonthly, usage.GenMonthlyReportPayload(time.Now()))
+}
+
+func (wn *webhookNotifier) post(ctx context.Context, reportType ReportType, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	if err := wn.client.Post(ctx, reportType.String(), body); err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	return nil
+}
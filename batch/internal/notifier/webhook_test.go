@@ -0,0 +1,88 @@
+package notifier_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/webhook"
+	"github.com/tamaco489/cost_explorer/batch/internal/notifier"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// TestWebhookNotifier_NotifyWeekly: 週次利用コストレポートをバージョン付きの JSON として署名を付与して送信することをテストします
+func TestWebhookNotifier_NotifyWeekly(t *testing.T) {
+	var (
+		received []byte
+		header   http.Header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		received, header = b, r.Header
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	wn := notifier.NewWebhookNotifier(webhook.NewWebhookClient(server.URL, "secret"))
+	err := wn.NotifyWeekly(context.Background(), &service.WeeklyCostUsage{
+		LastWeekCost:       1500,
+		WeekBeforeLastCost: 0,
+		PercentageChange:   0,
+		Metric:             service.CostMetricUnblended,
+		Currency:           "JPY",
+		ExchangeRate: service.ExchangeRate{
+			BaseCurrency: "USD",
+			Currency:     "JPY",
+			Rate:         150.5,
+			Timestamp:    time.Date(2024, 9, 9, 0, 0, 0, 0, time.UTC),
+			Source:       "ecb",
+		},
+		LastWeekPeriod:       service.Period{Start: "2024-09-02", End: "2024-09-08"},
+		WeekBeforeLastPeriod: service.Period{Start: "2024-08-26", End: "2024-09-01"},
+		AccountCosts: []service.WeeklyAccountCost{
+			{AccountID: "123456789012", AccountName: "production", LastWeekCost: 1000, WeekBeforeLastCost: 800, PercentageChange: 125},
+		},
+	})
+	assert.NoError(t, err)
+
+	// 署名は共有シークレットで検証できる
+	assert.Equal(t, "weekly", header.Get(webhook.EventHeader))
+	assert.Equal(t, webhook.Sign("secret", header.Get(webhook.TimestampHeader), received), header.Get(webhook.SignatureHeader))
+
+	var payload map[string]any
+	assert.NoError(t, json.Unmarshal(received, &payload))
+	assert.Equal(t, service.ReportSchemaVersion, payload["schema_version"])
+	assert.Equal(t, "weekly", payload["report_type"])
+	assert.Equal(t, "UnblendedCost", payload["metric"])
+	assert.Equal(t, "JPY", payload["currency"])
+	assert.NotEmpty(t, payload["generated_at"])
+	assert.Equal(t, []any{
+		map[string]any{"base": "USD", "quote": "JPY", "rate": 150.5, "timestamp": "2024-09-09T00:00:00Z", "source": "ecb"},
+	}, payload["exchange_rates"])
+	assert.Equal(t, map[string]any{
+		"last_week":        map[string]any{"start": "2024-09-02", "end": "2024-09-08"},
+		"week_before_last": map[string]any{"start": "2024-08-26", "end": "2024-09-01"},
+	}, payload["periods"])
+
+	// 比較対象のコストが0の場合は、増減率を null として扱う
+	assert.Equal(t, map[string]any{
+		"last_week_cost":        1500.0,
+		"week_before_last_cost": 0.0,
+		"percentage_change":     nil,
+	}, payload["summary"])
+
+	// 内訳が存在しない場合も、空の配列として出力する
+	assert.Equal(t, map[string]any{
+		"daily": []any{},
+		"accounts": []any{
+			map[string]any{"account_id": "123456789012", "account_name": "production", "last_week_cost": 1000.0, "week_before_last_cost": 800.0, "percentage_change": 125.0},
+		},
+		"tags": []any{},
+	}, payload["breakdowns"])
+}
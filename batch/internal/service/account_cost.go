@@ -0,0 +1,131 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+)
+
+// DailyAccountCost: 日次レポートに表示する連結アカウントごとの利用コスト
+type DailyAccountCost struct {
+	AccountID     string
+	AccountName   string
+	YesterdayCost float64 // 昨日の利用コスト
+	ActualCost    float64 // 本日時点での今月の利用コスト
+}
+
+// WeeklyAccountCost: 週次レポートに表示する連結アカウントごとの利用コスト
+type WeeklyAccountCost struct {
+	AccountID          string
+	AccountName        string
+	LastWeekCost       float64 // 先週の利用コスト
+	WeekBeforeLastCost float64 // 先々週の利用コスト
+	PercentageChange   float64 // 先々週のコストに対する先週のコスト（%）、先々週のコストが0の場合は0
+}
+
+// groupByLinkedAccount: 利用コストを連結アカウント単位で集計するための GroupBy 定義
+func groupByLinkedAccount() []types.GroupDefinition {
+	return []types.GroupDefinition{
+		{
+			Type: types.GroupDefinitionTypeDimension,
+			Key:  aws.String(string(types.DimensionLinkedAccount)),
+		},
+	}
+}
+
+// resolveAccountNames: 連結アカウントIDとアカウント名の対応表を生成
+//
+// 設定で指定した名前を優先し、未指定の場合は Cost Explorer が返却するアカウントの説明を利用する
+func resolveAccountNames(accountNames map[string]string, outputs ...*cost_explorer.GetCostAndUsageOutput) map[string]string {
+	names := make(map[string]string)
+	for _, output := range outputs {
+		for _, attr := range output.DimensionValueAttributes {
+			if attr.Value == nil {
+				continue
+			}
+			if description, ok := attr.Attributes["description"]; ok && description != "" {
+				names[*attr.Value] = description
+			}
+		}
+	}
+
+	for id, name := range accountNames {
+		names[id] = name
+	}
+
+	return names
+}
+
+// accountName: 連結アカウントIDに対応するアカウント名を取得 (対応する名前がない場合はIDをそのまま返す)
+func accountName(names map[string]string, accountID string) string {
+	if name, ok := names[accountID]; ok {
+		return name
+	}
+	return accountID
+}
+
+// toDailyAccountCosts: グループのキーごとの利用コストを連結アカウントごとの利用コストに変換
+func toDailyAccountCosts(groupCosts []dailyGroupCost, names map[string]string) []DailyAccountCost {
+	accountCosts := make([]DailyAccountCost, 0, len(groupCosts))
+	for _, gc := range groupCosts {
+		accountCosts = append(accountCosts, DailyAccountCost{
+			AccountID:     gc.key,
+			AccountName:   accountName(names, gc.key),
+			YesterdayCost: gc.yesterdayCost,
+			ActualCost:    gc.actualCost,
+		})
+	}
+	return accountCosts
+}
+
+// toWeeklyAccountCosts: グループのキーごとの利用コストを連結アカウントごとの利用コストに変換
+func toWeeklyAccountCosts(groupCosts []weeklyGroupCost, names map[string]string) []WeeklyAccountCost {
+	accountCosts := make([]WeeklyAccountCost, 0, len(groupCosts))
+	for _, gc := range groupCosts {
+		accountCosts = append(accountCosts, WeeklyAccountCost{
+			AccountID:          gc.key,
+			AccountName:        accountName(names, gc.key),
+			LastWeekCost:       gc.lastWeekCost,
+			WeekBeforeLastCost: gc.weekBeforeLastCost,
+			PercentageChange:   gc.percentageChange,
+		})
+	}
+	return accountCosts
+}
+
+// formatAccountLabel: レポートに表示するアカウントのラベルを生成
+func formatAccountLabel(accountID, accountName string) string {
+	if accountName == "" || accountName == accountID {
+		return accountID
+	}
+	return fmt.Sprintf("%s (%s)", accountName, accountID)
+}
+
+// formatDailyAccountCosts: アカウントごとの昨日と今月の利用コストをリストとして整形
+func formatDailyAccountCosts(currency string, accountCosts []DailyAccountCost) string {
+	var sb strings.Builder
+	for _, ac := range accountCosts {
+		sb.WriteString(fmt.Sprintf("    -  %s: 昨日 %s / 今月 %s\n",
+			formatAccountLabel(ac.AccountID, ac.AccountName), formatCost(currency, ac.YesterdayCost), formatCost(currency, ac.ActualCost),
+		))
+	}
+	return sb.String()
+}
+
+// formatWeeklyAccountCosts: アカウントごとの先週と先々週の利用コストをリストとして整形
+func formatWeeklyAccountCosts(currency string, accountCosts []WeeklyAccountCost) string {
+	var sb strings.Builder
+	for _, ac := range accountCosts {
+		change := "-"
+		if ac.WeekBeforeLastCost != 0 {
+			change = fmt.Sprintf("%.2f %%", ac.PercentageChange)
+		}
+		sb.WriteString(fmt.Sprintf("    -  %s: 先週 %s / 先々週 %s (%s)\n",
+			formatAccountLabel(ac.AccountID, ac.AccountName), formatCost(currency, ac.LastWeekCost), formatCost(currency, ac.WeekBeforeLastCost), change,
+		))
+	}
+	return sb.String()
+}
@@ -2,62 +2,369 @@ package service
 
 import (
 	"fmt"
+	"time"
 
-	"github.com/tamaco489/cost_explorer/batch/internal/library/calc"
 	"github.com/tamaco489/cost_explorer/batch/internal/library/exchange_rates"
 )
 
-// calcDailyCostInJPY: Open Exchange Rates APIのレスポンスから1$あたりの円を取得し、そのレートを使用して利用コストをUSDからJPYに変換
-func (dcu *DailyCostUsage) CalcDailyCostInJPY(res *exchange_rates.ExchangeRatesResponse) (*DailyCostUsage, error) {
-	rate, ok := res.Rates[exchange_rates.JPY.String()]
+// defaultCostCurrency: Cost Explorer から取得する利用コストの通貨 (請求通貨を指定しない場合)
+const defaultCostCurrency = "USD"
+
+// ExchangeRate: 利用コストの変換に使用した為替レート
+type ExchangeRate struct {
+	BaseCurrency string    // 変換元の通貨
+	Currency     string    // 変換先の通貨
+	Rate         float64   // 変換元の通貨1単位あたりの変換先の通貨の額
+	Timestamp    time.Time // 為替レートの公表時刻
+	Source       string    // 為替レートの取得元 (例: openexchangerates, ecb, static)
+
+	Period Period // 為替レートを適用した集計期間 (実行時点の最新の為替レートを適用した場合は空)
+	Basis  string // 集計期間に適用した為替レートの算出方法 (daily: 利用日ごとの加重平均, average: 利用日の平均)
+	Stale  bool   // 取得元で失敗したため、期限切れのキャッシュの為替レートを使用したか
+}
+
+// newExchangeRate: 為替レートの取得元のレスポンスから、変換に使用した為替レートを生成
+func newExchangeRate(res *exchange_rates.ExchangeRatesResponse, currency string, rate float64) ExchangeRate {
+	return ExchangeRate{
+		BaseCurrency: res.Base,
+		Currency:     currency,
+		Rate:         rate,
+		Timestamp:    time.Unix(res.Timestamp, 0).UTC(),
+		Source:       res.Source,
+		Stale:        res.Stale,
+	}
+}
+
+// exchangeRateOf: 為替レートの取得元のレスポンスから、基軸通貨1単位あたりの指定した通貨の額を取得 (基軸通貨の場合は1)
+func exchangeRateOf(res *exchange_rates.ExchangeRatesResponse, currency string) (float64, error) {
+	if currency == res.Base {
+		return 1, nil
+	}
+	rate, ok := res.Rates[currency]
 	if !ok {
-		return nil, fmt.Errorf("JPY exchange rate not found in the response: %+v", res.Rates)
+		return 0, fmt.Errorf("%s exchange rate not found in the response: %+v", currency, res.Rates)
+	}
+	return rate, nil
+}
+
+// footer: レポートのフッターに表示する為替レートと取得元を生成 (変換していない場合、基軸通貨のまま表示する場合は空文字)
+func (er ExchangeRate) footer() string {
+	if er.Currency == "" || er.Currency == er.BaseCurrency {
+		return ""
+	}
+
+	if er.Period != (Period{}) {
+		footer := fmt.Sprintf("exchange rate %s..%s (%s): 1 %s = %.2f %s", er.Period.Start, periodLastDate(er.Period), er.Basis, er.BaseCurrency, er.Rate, er.Currency)
+		if er.Source != "" {
+			footer += fmt.Sprintf(" (source: %s)", er.Source)
+		}
+		return footer + er.staleNotice()
+	}
+
+	footer := fmt.Sprintf("exchange rate: 1 %s = %.2f %s", er.BaseCurrency, er.Rate, er.Currency)
+	if er.Source != "" {
+		footer += fmt.Sprintf(" (source: %s, %s)", er.Source, er.Timestamp.Format("2006-01-02 15:04 MST"))
+	}
+	return footer + er.staleNotice()
+}
+
+// staleNotice: 期限切れのキャッシュの為替レートを使用した場合に、フッターに付記する警告
+func (er ExchangeRate) staleNotice() string {
+	if !er.Stale {
+		return ""
+	}
+	return " ⚠ stale: latest exchange rate is unavailable, cached rate is used"
+}
+
+// periodLastDate: フッターに表示するため、集計期間の最終日を取得 (日付が不正な場合は終了日付をそのまま返す)
+func periodLastDate(period Period) string {
+	date, err := period.LastDate()
+	if err != nil {
+		return period.End
+	}
+	return date
+}
+
+// CalcDailyCost: 為替レートの取得元のレスポンスから利用コストの通貨1単位あたりの指定した通貨の額を取得し、そのレートを使用して利用コストを指定した通貨に変換
+func (dcu *DailyCostUsage) CalcDailyCost(res *exchange_rates.ExchangeRatesResponse, currency string) (*DailyCostUsage, error) {
+	rate, err := exchangeRateOf(res, currency)
+	if err != nil {
+		return nil, err
 	}
 
-	// calc.RoundUpToTwoDecimalPlaces のエラーをチェック
-	yesterdayCost, err := calc.RoundUpToTwoDecimalPlaces(dcu.YesterdayCost * rate)
+	// roundUpCost のエラーをチェック
+	yesterdayCost, err := roundUpCost(currency, dcu.YesterdayCost*rate)
 	if err != nil {
 		return nil, fmt.Errorf("error rounding up YesterdayCost: %v", err)
 	}
 
-	actualCost, err := calc.RoundUpToTwoDecimalPlaces(dcu.ActualCost * rate)
+	actualCost, err := roundUpCost(currency, dcu.ActualCost*rate)
 	if err != nil {
 		return nil, fmt.Errorf("error rounding up ActualCost: %v", err)
 	}
 
-	forecastCost, err := calc.RoundUpToTwoDecimalPlaces(dcu.ForecastCost * rate)
+	averageDailyCost, err := roundUpCost(currency, dcu.AverageDailyCost*rate)
+	if err != nil {
+		return nil, fmt.Errorf("error rounding up AverageDailyCost: %v", err)
+	}
+
+	forecastCost, err := roundUpCost(currency, dcu.ForecastCost*rate)
 	if err != nil {
 		return nil, fmt.Errorf("error rounding up ForecastCost: %v", err)
 	}
 
+	// 予測区間の下限はクレジット等の影響で負の値になり得るため、0に丸める
+	forecastLowerBound, err := roundUpCost(currency, max(dcu.ForecastLowerBound, 0)*rate)
+	if err != nil {
+		return nil, fmt.Errorf("error rounding up ForecastLowerBound: %v", err)
+	}
+
+	forecastUpperBound, err := roundUpCost(currency, dcu.ForecastUpperBound*rate)
+	if err != nil {
+		return nil, fmt.Errorf("error rounding up ForecastUpperBound: %v", err)
+	}
+
+	yesterdayServiceCosts, err := convertServiceCosts(dcu.YesterdayServiceCosts, currency, rate)
+	if err != nil {
+		return nil, err
+	}
+
+	actualServiceCosts, err := convertServiceCosts(dcu.ActualServiceCosts, currency, rate)
+	if err != nil {
+		return nil, err
+	}
+
+	accountCosts := make([]DailyAccountCost, 0, len(dcu.AccountCosts))
+	for _, ac := range dcu.AccountCosts {
+		yesterday, err := roundUpCost(currency, ac.YesterdayCost*rate)
+		if err != nil {
+			return nil, fmt.Errorf("error rounding up %s YesterdayCost: %v", ac.AccountID, err)
+		}
+		actual, err := roundUpCost(currency, ac.ActualCost*rate)
+		if err != nil {
+			return nil, fmt.Errorf("error rounding up %s ActualCost: %v", ac.AccountID, err)
+		}
+		accountCosts = append(accountCosts, DailyAccountCost{
+			AccountID:     ac.AccountID,
+			AccountName:   ac.AccountName,
+			YesterdayCost: yesterday,
+			ActualCost:    actual,
+		})
+	}
+
+	tagCosts := make([]DailyTagCostTable, 0, len(dcu.TagCosts))
+	for _, table := range dcu.TagCosts {
+		costs := make([]DailyTagCost, 0, len(table.Costs))
+		for _, tc := range table.Costs {
+			yesterday, err := roundUpCost(currency, tc.YesterdayCost*rate)
+			if err != nil {
+				return nil, fmt.Errorf("error rounding up %s:%s YesterdayCost: %v", table.TagKey, tc.TagValue, err)
+			}
+			actual, err := roundUpCost(currency, tc.ActualCost*rate)
+			if err != nil {
+				return nil, fmt.Errorf("error rounding up %s:%s ActualCost: %v", table.TagKey, tc.TagValue, err)
+			}
+			costs = append(costs, DailyTagCost{TagValue: tc.TagValue, YesterdayCost: yesterday, ActualCost: actual})
+		}
+		tagCosts = append(tagCosts, DailyTagCostTable{TagKey: table.TagKey, Costs: costs})
+	}
+
 	return &DailyCostUsage{
-		YesterdayCost: yesterdayCost, // 昨日利用したコスト
-		ActualCost:    actualCost,    // 本日時点で利用した総コスト
-		ForecastCost:  forecastCost,  // 残り日数を考慮した今月の利用コスト
+		YesterdayCost:         yesterdayCost,         // 昨日利用したコスト
+		ActualCost:            actualCost,            // 本日時点で利用した総コスト
+		AverageDailyCost:      averageDailyCost,      // 本日時点での今月の1日あたりの平均利用コスト
+		ForecastCost:          forecastCost,          // 残り日数を考慮した今月の利用コスト
+		ForecastLowerBound:    forecastLowerBound,    // 今月の利用コストの予測区間の下限
+		ForecastUpperBound:    forecastUpperBound,    // 今月の利用コストの予測区間の上限
+		ForecastMode:          dcu.ForecastMode,      // 今月の利用コストの予測方法
+		YesterdayServiceCosts: yesterdayServiceCosts, // 昨日利用したコストのサービス別内訳
+		ActualServiceCosts:    actualServiceCosts,    // 本日時点で利用した総コストのサービス別内訳
+		AccountCosts:          accountCosts,          // 連結アカウント別の内訳
+		TagCosts:              tagCosts,              // コスト配分タグ別の内訳
+		Metric:                dcu.Metric,            // 集計した利用コストの指標
+
+		Currency:        currency,                             // 変換後の利用コストの通貨
+		ExchangeRate:    newExchangeRate(res, currency, rate), // 変換に使用した為替レート
+		YesterdayPeriod: dcu.YesterdayPeriod,                  // 昨日の集計期間
+		ActualPeriod:    dcu.ActualPeriod,                     // 今月の集計期間
 	}, nil
 }
 
-// calcWeeklyCostInJPY: Open Exchange Rates APIのレスポンスから1$あたりの円を取得し、そのレートを使用して利用コストをUSDからJPYに変換
-func (wcu *WeeklyCostUsage) CalcWeeklyCostInJPY(res *exchange_rates.ExchangeRatesResponse) (*WeeklyCostUsage, error) {
-	rate, ok := res.Rates[exchange_rates.JPY.String()]
-	if !ok {
-		return nil, fmt.Errorf("JPY exchange rate not found in the response: %+v", res.Rates)
+// CalcWeeklyCost: 為替レートの取得元のレスポンスから利用コストの通貨1単位あたりの指定した通貨の額を取得し、そのレートを使用して利用コストを指定した通貨に変換
+func (wcu *WeeklyCostUsage) CalcWeeklyCost(res *exchange_rates.ExchangeRatesResponse, currency string) (*WeeklyCostUsage, error) {
+	rate, err := exchangeRateOf(res, currency)
+	if err != nil {
+		return nil, err
 	}
 
-	// calc.RoundUpToTwoDecimalPlaces のエラーをチェック
-	lastWeekCost, err := calc.RoundUpToTwoDecimalPlaces(wcu.LastWeekCost * rate)
+	pr := periodRate{ExchangeRate: newExchangeRate(res, currency, rate)}
+	return wcu.convertCost(pr, pr)
+}
+
+// convertCost: 先週と先々週の利用コストを、それぞれの集計期間の為替レートで変換
+//
+// 集計期間ごとに為替レートが異なる場合は、為替レートの比率を掛けて増減率を変換後の利用コストに合わせる
+func (wcu *WeeklyCostUsage) convertCost(lastWeekRate, weekBeforeLastRate periodRate) (*WeeklyCostUsage, error) {
+	lastWeekDays, weekBeforeLastDays := wcu.dailyCostsByWeek()
+	rateRatio := lastWeekRate.Rate / weekBeforeLastRate.Rate
+	currency := lastWeekRate.Currency
+
+	// roundUpCost のエラーをチェック
+	lastWeekCost, err := roundUpCost(currency, lastWeekRate.convert(wcu.LastWeekCost, lastWeekDays))
 	if err != nil {
 		return nil, fmt.Errorf("error rounding up LastWeekCost: %v", err)
 	}
 
-	weekBeforeLastCost, err := calc.RoundUpToTwoDecimalPlaces(wcu.WeekBeforeLastCost * rate)
+	weekBeforeLastCost, err := roundUpCost(currency, weekBeforeLastRate.convert(wcu.WeekBeforeLastCost, weekBeforeLastDays))
 	if err != nil {
 		return nil, fmt.Errorf("error rounding up WeekBeforeLastCost: %v", err)
 	}
 
-	return &WeeklyCostUsage{
-		LastWeekCost:       lastWeekCost,         // 先週利用したコスト
-		WeekBeforeLastCost: weekBeforeLastCost,   // 先々週利用した総コスト
-		PercentageChange:   wcu.PercentageChange, // 先週と先々週のコスト増減（%）
-	}, nil
+	dailyCosts := make([]WeeklyDailyCost, 0, len(wcu.DailyCosts))
+	for _, dc := range wcu.DailyCosts {
+		lastWeekDayRate, weekBeforeLastDayRate := lastWeekRate.on(dc.LastWeekDate), weekBeforeLastRate.on(dc.WeekBeforeLastDate)
+		lastWeek, err := roundUpCost(currency, dc.LastWeekCost*lastWeekDayRate)
+		if err != nil {
+			return nil, fmt.Errorf("error rounding up %s LastWeekCost: %v", dc.LastWeekDate, err)
+		}
+		weekBeforeLast, err := roundUpCost(currency, dc.WeekBeforeLastCost*weekBeforeLastDayRate)
+		if err != nil {
+			return nil, fmt.Errorf("error rounding up %s WeekBeforeLastCost: %v", dc.WeekBeforeLastDate, err)
+		}
+		dailyCosts = append(dailyCosts, WeeklyDailyCost{
+			LastWeekDate:       dc.LastWeekDate,
+			LastWeekCost:       lastWeek,
+			WeekBeforeLastDate: dc.WeekBeforeLastDate,
+			WeekBeforeLastCost: weekBeforeLast,
+			PercentageChange:   dc.PercentageChange * (lastWeekDayRate / weekBeforeLastDayRate),
+		})
+	}
+
+	accountCosts := make([]WeeklyAccountCost, 0, len(wcu.AccountCosts))
+	for _, ac := range wcu.AccountCosts {
+		lastWeek, err := roundUpCost(currency, ac.LastWeekCost*lastWeekRate.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("error rounding up %s LastWeekCost: %v", ac.AccountID, err)
+		}
+		weekBeforeLast, err := roundUpCost(currency, ac.WeekBeforeLastCost*weekBeforeLastRate.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("error rounding up %s WeekBeforeLastCost: %v", ac.AccountID, err)
+		}
+		accountCosts = append(accountCosts, WeeklyAccountCost{
+			AccountID:          ac.AccountID,
+			AccountName:        ac.AccountName,
+			LastWeekCost:       lastWeek,
+			WeekBeforeLastCost: weekBeforeLast,
+			PercentageChange:   ac.PercentageChange * rateRatio,
+		})
+	}
+
+	tagCosts := make([]WeeklyTagCostTable, 0, len(wcu.TagCosts))
+	for _, table := range wcu.TagCosts {
+		costs := make([]WeeklyTagCost, 0, len(table.Costs))
+		for _, tc := range table.Costs {
+			lastWeek, err := roundUpCost(currency, tc.LastWeekCost*lastWeekRate.Rate)
+			if err != nil {
+				return nil, fmt.Errorf("error rounding up %s:%s LastWeekCost: %v", table.TagKey, tc.TagValue, err)
+			}
+			weekBeforeLast, err := roundUpCost(currency, tc.WeekBeforeLastCost*weekBeforeLastRate.Rate)
+			if err != nil {
+				return nil, fmt.Errorf("error rounding up %s:%s WeekBeforeLastCost: %v", table.TagKey, tc.TagValue, err)
+			}
+			costs = append(costs, WeeklyTagCost{
+				TagValue:           tc.TagValue,
+				LastWeekCost:       lastWeek,
+				WeekBeforeLastCost: weekBeforeLast,
+				PercentageChange:   tc.PercentageChange * rateRatio,
+			})
+		}
+		tagCosts = append(tagCosts, WeeklyTagCostTable{TagKey: table.TagKey, Costs: costs})
+	}
+
+	converted := &WeeklyCostUsage{
+		LastWeekCost:       lastWeekCost,                     // 先週利用したコスト
+		WeekBeforeLastCost: weekBeforeLastCost,               // 先々週利用した総コスト
+		PercentageChange:   wcu.PercentageChange * rateRatio, // 先週と先々週のコスト増減（%）
+		DailyCosts:         dailyCosts,                       // 先週と先々週の日別の利用コスト
+		AccountCosts:       accountCosts,                     // 連結アカウント別の内訳
+		TagCosts:           tagCosts,                         // コスト配分タグ別の内訳
+		Metric:             wcu.Metric,                       // 集計した利用コストの指標
+
+		Currency:             lastWeekRate.Currency,     // 変換後の利用コストの通貨
+		ExchangeRate:         lastWeekRate.ExchangeRate, // 変換に使用した為替レート
+		LastWeekPeriod:       wcu.LastWeekPeriod,        // 先週の集計期間
+		WeekBeforeLastPeriod: wcu.WeekBeforeLastPeriod,  // 先々週の集計期間
+	}
+	if weekBeforeLastRate.Period != (Period{}) {
+		converted.WeekBeforeLastExchangeRate = weekBeforeLastRate.ExchangeRate
+	}
+	return converted, nil
+}
+
+// CalcMonthlyCost: 為替レートの取得元のレスポンスから利用コストの通貨1単位あたりの指定した通貨の額を取得し、そのレートを使用して利用コストを指定した通貨に変換
+func (mcu *MonthlyCostUsage) CalcMonthlyCost(res *exchange_rates.ExchangeRatesResponse, currency string) (*MonthlyCostUsage, error) {
+	rate, err := exchangeRateOf(res, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := periodRate{ExchangeRate: newExchangeRate(res, currency, rate)}
+	return mcu.convertCost(pr, pr)
+}
+
+// convertCost: 先月と先々月の利用コストを、それぞれの集計期間の為替レートで変換
+//
+// 集計期間ごとに為替レートが異なる場合は、為替レートの比率を掛けて増減率を変換後の利用コストに合わせる
+func (mcu *MonthlyCostUsage) convertCost(lastMonthRate, monthBeforeLastRate periodRate) (*MonthlyCostUsage, error) {
+	rateRatio := lastMonthRate.Rate / monthBeforeLastRate.Rate
+	currency := lastMonthRate.Currency
+
+	// roundUpCost のエラーをチェック
+	lastMonthCost, err := roundUpCost(currency, lastMonthRate.convert(mcu.LastMonthCost, mcu.DailyCosts.LastMonth))
+	if err != nil {
+		return nil, fmt.Errorf("error rounding up LastMonthCost: %v", err)
+	}
+
+	monthBeforeLastCost, err := roundUpCost(currency, monthBeforeLastRate.convert(mcu.MonthBeforeLastCost, mcu.DailyCosts.MonthBeforeLast))
+	if err != nil {
+		return nil, fmt.Errorf("error rounding up MonthBeforeLastCost: %v", err)
+	}
+
+	topServices, err := convertServiceCosts(mcu.TopServices, currency, lastMonthRate.Rate)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := &MonthlyCostUsage{
+		LastMonth:           mcu.LastMonth,                       // 先月 (YYYY-MM)
+		MonthBeforeLast:     mcu.MonthBeforeLast,                 // 先々月 (YYYY-MM)
+		LastMonthCost:       lastMonthCost,                       // 先月利用したコスト
+		MonthBeforeLastCost: monthBeforeLastCost,                 // 先々月利用したコスト
+		CostDifference:      lastMonthCost - monthBeforeLastCost, // 先々月から先月にかけてのコスト増減額
+		PercentageChange:    mcu.PercentageChange * rateRatio,    // 先月と先々月のコスト増減（%）
+		TopServices:         topServices,                         // 先月の利用コスト上位サービス
+		Metric:              mcu.Metric,                          // 集計した利用コストの指標
+
+		Currency:              lastMonthRate.Currency,     // 変換後の利用コストの通貨
+		ExchangeRate:          lastMonthRate.ExchangeRate, // 変換に使用した為替レート
+		LastMonthPeriod:       mcu.LastMonthPeriod,        // 先月の集計期間
+		MonthBeforeLastPeriod: mcu.MonthBeforeLastPeriod,  // 先々月の集計期間
+	}
+	if monthBeforeLastRate.Period != (Period{}) {
+		converted.MonthBeforeLastExchangeRate = monthBeforeLastRate.ExchangeRate
+	}
+	return converted, nil
+}
+
+// convertServiceCosts: サービスごとの利用コストを指定したレートで変換
+func convertServiceCosts(serviceCosts []ServiceCost, currency string, rate float64) ([]ServiceCost, error) {
+	converted := make([]ServiceCost, 0, len(serviceCosts))
+	for _, sc := range serviceCosts {
+		cost, err := roundUpCost(currency, sc.Cost*rate)
+		if err != nil {
+			return nil, fmt.Errorf("error rounding up %s cost: %v", sc.ServiceName, err)
+		}
+		converted = append(converted, ServiceCost{ServiceName: sc.ServiceName, Cost: cost})
+	}
+	return converted, nil
 }
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+)
+
+// maxCostAndUsagePages: 1回の問い合わせで GetCostAndUsage のページングを辿るページ数の上限
+//
+// 想定外に大量のページが返却された場合に、Lambda のタイムアウトや Cost Explorer の課金が膨らむことを防ぐ
+const maxCostAndUsagePages = 20
+
+// costExplorerAPI: CostQuery が利用する Cost Explorer の API
+//
+// *cost_explorer.Client が満たすインターフェースで、テスト時にはスタブに差し替える
+type costExplorerAPI interface {
+	GetCostAndUsage(ctx context.Context, params *cost_explorer.GetCostAndUsageInput, optFns ...func(*cost_explorer.Options)) (*cost_explorer.GetCostAndUsageOutput, error)
+	GetCostForecast(ctx context.Context, params *cost_explorer.GetCostForecastInput, optFns ...func(*cost_explorer.Options)) (*cost_explorer.GetCostForecastOutput, error)
+}
+
+var _ costExplorerAPI = (*cost_explorer.Client)(nil)
+
+// CostQuery: 日次・週次・月次の各サービスで共通して利用する Cost Explorer への問い合わせ
+//
+// 集計する利用コストの指標と絞り込み条件は全ての問い合わせで共通の値を利用する
+type CostQuery struct {
+	client   costExplorerAPI
+	metric   CostMetric
+	filter   *types.Expression
+	maxPages int
+	currency string // Cost Explorer から取得する利用コストの通貨 (AWS アカウントの請求通貨)
+}
+
+// NewCostQuery: CostQuery のコンストラクタ
+//
+// currency には Cost Explorer が返す利用コストの通貨を指定する (空の場合は USD)
+func NewCostQuery(client *cost_explorer.Client, metric CostMetric, filter CostFilter, currency string) (*CostQuery, error) {
+	q, err := newCostQuery(client, metric, filter)
+	if err != nil {
+		return nil, err
+	}
+	if currency != "" {
+		q.currency = currency
+	}
+	return q, nil
+}
+
+func newCostQuery(client costExplorerAPI, metric CostMetric, filter CostFilter) (*CostQuery, error) {
+	expression, err := filter.Expression()
+	if err != nil {
+		return nil, err
+	}
+
+	return &CostQuery{
+		client:   client,
+		metric:   metric,
+		filter:   expression,
+		maxPages: maxCostAndUsagePages,
+		currency: defaultCostCurrency,
+	}, nil
+}
+
+// Metric: 集計する利用コストの指標を取得
+func (q *CostQuery) Metric() CostMetric {
+	return q.metric
+}
+
+// Currency: Cost Explorer から取得する利用コストの通貨を取得
+func (q *CostQuery) Currency() string {
+	return q.currency
+}
+
+// getCostAndUsage: NextPageToken がなくなるまで GetCostAndUsage を呼び出し、全ページの結果を1つのレスポンスに結合
+//
+// ページ数が上限を超えた場合は、集計漏れを防ぐためにエラーを返す
+// 問い合わせに Filter が指定されていない場合は、CostQuery の絞り込み条件を適用する
+func (q *CostQuery) getCostAndUsage(ctx context.Context, input *cost_explorer.GetCostAndUsageInput) (*cost_explorer.GetCostAndUsageOutput, error) {
+	params := *input
+	if params.Filter == nil {
+		params.Filter = q.filter
+	}
+	merged := &cost_explorer.GetCostAndUsageOutput{}
+
+	for page := 1; ; page++ {
+		if page > q.maxPages {
+			return nil, fmt.Errorf("cost and usage results exceeded the page limit: %d", q.maxPages)
+		}
+
+		output, err := q.client.GetCostAndUsage(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := q.checkResultsUnit(output.ResultsByTime); err != nil {
+			return nil, err
+		}
+
+		merged.ResultsByTime = append(merged.ResultsByTime, output.ResultsByTime...)
+		merged.DimensionValueAttributes = append(merged.DimensionValueAttributes, output.DimensionValueAttributes...)
+		merged.GroupDefinitions = output.GroupDefinitions
+
+		if output.NextPageToken == nil || *output.NextPageToken == "" {
+			return merged, nil
+		}
+		params.NextPageToken = output.NextPageToken
+	}
+}
+
+// getTotalCost: 指定期間の利用コストの合計を取得
+func (q *CostQuery) getTotalCost(ctx context.Context, startDate, endDate string, granularity types.Granularity) (float64, error) {
+	output, err := q.getCostAndUsage(ctx, &cost_explorer.GetCostAndUsageInput{
+		TimePeriod: &types.DateInterval{
+			Start: &startDate,
+			End:   &endDate,
+		},
+		Granularity: granularity,
+		Metrics:     []string{q.metric.String()},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	totalCost := 0.0
+	for _, result := range output.ResultsByTime {
+		if cost, ok := result.Total[q.metric.String()]; ok && cost.Amount != nil {
+			amount, err := strconv.ParseFloat(*cost.Amount, 64)
+			if err != nil {
+				return 0, err
+			}
+			totalCost += amount
+		}
+	}
+
+	return totalCost, nil
+}
+
+// getGroupedCostAndUsage: 指定期間の利用コストを GroupBy の単位で取得
+func (q *CostQuery) getGroupedCostAndUsage(ctx context.Context, startDate, endDate string, granularity types.Granularity, groupBy []types.GroupDefinition) (*cost_explorer.GetCostAndUsageOutput, error) {
+	return q.getCostAndUsage(ctx, &cost_explorer.GetCostAndUsageInput{
+		TimePeriod: &types.DateInterval{
+			Start: &startDate,
+			End:   &endDate,
+		},
+		Granularity: granularity,
+		Metrics:     []string{q.metric.String()},
+		GroupBy:     groupBy,
+	})
+}
+
+// getGroupCosts: 指定期間の利用コストを GroupBy の単位で取得し、グループのキーごとに合算
+func (q *CostQuery) getGroupCosts(ctx context.Context, startDate, endDate string, granularity types.Granularity, groupBy []types.GroupDefinition) (map[string]float64, error) {
+	output, err := q.getGroupedCostAndUsage(ctx, startDate, endDate, granularity, groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return sumGroupCosts(output, q.metric.String())
+}
+
+// getCostForecast: 指定期間の利用コストの予測値を取得 (実績値と同じ絞り込み条件を適用する)
+func (q *CostQuery) getCostForecast(ctx context.Context, input *cost_explorer.GetCostForecastInput) (*cost_explorer.GetCostForecastOutput, error) {
+	params := *input
+	if params.Filter == nil {
+		params.Filter = q.filter
+	}
+	output, err := q.client.GetCostForecast(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	if output.Total != nil {
+		if err := q.checkUnit(output.Total.Unit); err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+// checkResultsUnit: 合計とグループごとの利用コストの通貨が、設定した利用コストの通貨と一致するかを検証
+func (q *CostQuery) checkResultsUnit(results []types.ResultByTime) error {
+	for _, result := range results {
+		for _, cost := range result.Total {
+			if err := q.checkUnit(cost.Unit); err != nil {
+				return err
+			}
+		}
+		for _, group := range result.Groups {
+			for _, cost := range group.Metrics {
+				if err := q.checkUnit(cost.Unit); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkUnit: Cost Explorer が返した利用コストの通貨が、設定した利用コストの通貨と一致するかを検証
+//
+// 通貨が異なる場合は誤った為替レートで変換することになるため、エラーを返す (通貨が返却されない場合は検証しない)
+func (q *CostQuery) checkUnit(unit *string) error {
+	if unit == nil || *unit == "" || strings.EqualFold(*unit, q.currency) {
+		return nil
+	}
+	return fmt.Errorf("cost explorer returned costs in %s, but the cost currency is %s", *unit, q.currency)
+}
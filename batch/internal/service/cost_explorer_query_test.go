@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubCostExplorerAPI: NextPageToken をキーにページを返却する Cost Explorer のスタブ
+type stubCostExplorerAPI struct {
+	pages          map[string]*cost_explorer.GetCostAndUsageOutput
+	inputs         []cost_explorer.GetCostAndUsageInput
+	forecastInputs []cost_explorer.GetCostForecastInput
+	forecast       *cost_explorer.GetCostForecastOutput
+}
+
+func (s *stubCostExplorerAPI) GetCostAndUsage(ctx context.Context, params *cost_explorer.GetCostAndUsageInput, optFns ...func(*cost_explorer.Options)) (*cost_explorer.GetCostAndUsageOutput, error) {
+	s.inputs = append(s.inputs, *params)
+	return s.pages[aws.ToString(params.NextPageToken)], nil
+}
+
+func (s *stubCostExplorerAPI) GetCostForecast(ctx context.Context, params *cost_explorer.GetCostForecastInput, optFns ...func(*cost_explorer.Options)) (*cost_explorer.GetCostForecastOutput, error) {
+	s.forecastInputs = append(s.forecastInputs, *params)
+	if s.forecast != nil {
+		return s.forecast, nil
+	}
+	return &cost_explorer.GetCostForecastOutput{}, nil
+}
+
+func totalResult(start, amount string) types.ResultByTime {
+	return types.ResultByTime{
+		TimePeriod: &types.DateInterval{Start: aws.String(start)},
+		Total:      map[string]types.MetricValue{"UnblendedCost": {Amount: aws.String(amount)}},
+	}
+}
+
+func TestCostQuery_GetTotalCost(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: NextPageToken がなくなるまで全ページの利用コストを合算すること", func(t *testing.T) {
+		stub := &stubCostExplorerAPI{
+			pages: map[string]*cost_explorer.GetCostAndUsageOutput{
+				"": {
+					ResultsByTime: []types.ResultByTime{totalResult("2024-12-01", "1.25")},
+					NextPageToken: aws.String("page-2"),
+				},
+				"page-2": {
+					ResultsByTime: []types.ResultByTime{totalResult("2024-12-02", "2.5")},
+					NextPageToken: aws.String("page-3"),
+				},
+				"page-3": {
+					ResultsByTime: []types.ResultByTime{totalResult("2024-12-03", "0.25")},
+				},
+			},
+		}
+
+		query, err := newCostQuery(stub, CostMetricUnblended, CostFilter{})
+		assert.NoError(t, err)
+
+		result, err := query.getTotalCost(ctx, "2024-12-01", "2024-12-04", types.GranularityDaily)
+		assert.NoError(t, err)
+		assert.Equal(t, 4.0, result)
+		assert.Len(t, stub.inputs, 3)
+		assert.Equal(t, "page-3", aws.ToString(stub.inputs[2].NextPageToken))
+	})
+
+	t.Run("異常系: ページ数が上限を超えた場合はエラーを返すこと", func(t *testing.T) {
+		stub := &stubCostExplorerAPI{
+			pages: map[string]*cost_explorer.GetCostAndUsageOutput{
+				"": {
+					ResultsByTime: []types.ResultByTime{totalResult("2024-12-01", "1")},
+					NextPageToken: aws.String("loop"),
+				},
+				"loop": {
+					ResultsByTime: []types.ResultByTime{totalResult("2024-12-01", "1")},
+					NextPageToken: aws.String("loop"),
+				},
+			},
+		}
+
+		query, err := newCostQuery(stub, CostMetricUnblended, CostFilter{})
+		assert.NoError(t, err)
+		query.maxPages = 3
+
+		_, err = query.getTotalCost(ctx, "2024-12-01", "2024-12-02", types.GranularityDaily)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "exceeded the page limit: 3")
+		assert.Len(t, stub.inputs, 3)
+	})
+}
+
+func TestCostQuery_Filter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 絞り込み条件を全ページの問い合わせと予測値の問い合わせに適用すること", func(t *testing.T) {
+		stub := &stubCostExplorerAPI{
+			pages: map[string]*cost_explorer.GetCostAndUsageOutput{
+				"": {
+					ResultsByTime: []types.ResultByTime{totalResult("2024-12-01", "1")},
+					NextPageToken: aws.String("page-2"),
+				},
+				"page-2": {
+					ResultsByTime: []types.ResultByTime{totalResult("2024-12-02", "1")},
+				},
+			},
+		}
+
+		query, err := newCostQuery(stub, CostMetricUnblended, CostFilter{ExcludeRecordTypes: []string{RecordTypeCredit}})
+		assert.NoError(t, err)
+
+		_, err = query.getTotalCost(ctx, "2024-12-01", "2024-12-03", types.GranularityDaily)
+		assert.NoError(t, err)
+
+		_, err = query.getCostForecast(ctx, &cost_explorer.GetCostForecastInput{})
+		assert.NoError(t, err)
+
+		assert.Len(t, stub.inputs, 2)
+		for _, input := range stub.inputs {
+			assert.Equal(t, []string{RecordTypeCredit}, input.Filter.Not.Dimensions.Values)
+		}
+		assert.Len(t, stub.forecastInputs, 1)
+		assert.Equal(t, []string{RecordTypeCredit}, stub.forecastInputs[0].Filter.Not.Dimensions.Values)
+	})
+
+	t.Run("異常系: 無効なディメンション名が指定された場合はエラーを返すこと", func(t *testing.T) {
+		_, err := newCostQuery(&stubCostExplorerAPI{}, CostMetricUnblended, CostFilter{
+			IncludeDimensions: map[string][]string{"UNKNOWN": {"value"}},
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid cost filter dimension: UNKNOWN")
+	})
+}
+
+func TestCostQuery_CheckUnit(t *testing.T) {
+	ctx := context.Background()
+
+	withUnit := func(result types.ResultByTime, unit string) types.ResultByTime {
+		for key, cost := range result.Total {
+			cost.Unit = aws.String(unit)
+			result.Total[key] = cost
+		}
+		for i := range result.Groups {
+			for key, cost := range result.Groups[i].Metrics {
+				cost.Unit = aws.String(unit)
+				result.Groups[i].Metrics[key] = cost
+			}
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		currency string
+		result   types.ResultByTime
+		forecast *cost_explorer.GetCostForecastOutput
+		wantErr  string
+	}{
+		{
+			name:     "正常系: 利用コストの通貨が設定した通貨と一致する場合はエラーを返さないこと",
+			currency: "USD",
+			result:   withUnit(totalResult("2024-12-01", "1"), "USD"),
+			forecast: &cost_explorer.GetCostForecastOutput{Total: &types.MetricValue{Amount: aws.String("1"), Unit: aws.String("USD")}},
+		},
+		{
+			name:     "正常系: 利用コストの通貨が返却されない場合は検証しないこと",
+			currency: "EUR",
+			result:   totalResult("2024-12-01", "1"),
+		},
+		{
+			name:     "異常系: 合計の利用コストの通貨が設定した通貨と異なる場合はエラーを返すこと",
+			currency: "EUR",
+			result:   withUnit(totalResult("2024-12-01", "1"), "USD"),
+			wantErr:  "cost explorer returned costs in USD, but the cost currency is EUR",
+		},
+		{
+			name:     "異常系: グループごとの利用コストの通貨が設定した通貨と異なる場合はエラーを返すこと",
+			currency: "USD",
+			result:   withUnit(groupResult("2024-12-01", map[string]string{"111111111111": "1"}), "JPY"),
+			wantErr:  "cost explorer returned costs in JPY, but the cost currency is USD",
+		},
+		{
+			name:     "異常系: 予測値の通貨が設定した通貨と異なる場合はエラーを返すこと",
+			currency: "USD",
+			result:   totalResult("2024-12-01", "1"),
+			forecast: &cost_explorer.GetCostForecastOutput{Total: &types.MetricValue{Amount: aws.String("1"), Unit: aws.String("EUR")}},
+			wantErr:  "cost explorer returned costs in EUR, but the cost currency is USD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubCostExplorerAPI{
+				pages:    map[string]*cost_explorer.GetCostAndUsageOutput{"": {ResultsByTime: []types.ResultByTime{tt.result}}},
+				forecast: tt.forecast,
+			}
+			query, err := newCostQuery(stub, CostMetricUnblended, CostFilter{})
+			assert.NoError(t, err)
+			query.currency = tt.currency
+
+			_, err = query.getCostAndUsage(ctx, &cost_explorer.GetCostAndUsageInput{})
+			if err == nil {
+				_, err = query.getCostForecast(ctx, &cost_explorer.GetCostForecastInput{})
+			}
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+)
+
+// RECORD_TYPE ディメンションで指定する主な料金の種別
+const (
+	RecordTypeUsage   = "Usage"
+	RecordTypeCredit  = "Credit"
+	RecordTypeRefund  = "Refund"
+	RecordTypeTax     = "Tax"
+	RecordTypeSupport = "Support"
+)
+
+// CostFilter: Cost Explorer の問い合わせで利用コストを絞り込む条件
+//
+// 指定された条件は全て AND で結合し、各条件の値は OR で評価される
+type CostFilter struct {
+	IncludeRecordTypes []string            // 集計対象とする料金の種別
+	ExcludeRecordTypes []string            // 集計対象から除外する料金の種別 (例: Credit, Refund, Tax)
+	IncludeDimensions  map[string][]string // 集計対象とするディメンションの値 (キー: REGION, SERVICE などのディメンション名)
+	ExcludeDimensions  map[string][]string // 集計対象から除外するディメンションの値
+	IncludeTags        map[string][]string // 集計対象とするタグの値 (キー: タグのキー)
+	ExcludeTags        map[string][]string // 集計対象から除外するタグの値
+}
+
+// Expression: 絞り込み条件を Cost Explorer の Filter 式に変換
+//
+// 条件が1つも指定されていない場合は nil を返す
+func (f CostFilter) Expression() (*types.Expression, error) {
+	expressions := make([]types.Expression, 0)
+
+	if len(f.IncludeRecordTypes) > 0 {
+		expressions = append(expressions, dimensionExpression(types.DimensionRecordType, f.IncludeRecordTypes))
+	}
+	if len(f.ExcludeRecordTypes) > 0 {
+		expressions = append(expressions, notExpression(dimensionExpression(types.DimensionRecordType, f.ExcludeRecordTypes)))
+	}
+
+	include, err := dimensionExpressions(f.IncludeDimensions)
+	if err != nil {
+		return nil, err
+	}
+	expressions = append(expressions, include...)
+
+	exclude, err := dimensionExpressions(f.ExcludeDimensions)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range exclude {
+		expressions = append(expressions, notExpression(e))
+	}
+
+	for _, key := range sortedKeys(f.IncludeTags) {
+		expressions = append(expressions, tagExpression(key, f.IncludeTags[key]))
+	}
+	for _, key := range sortedKeys(f.ExcludeTags) {
+		expressions = append(expressions, notExpression(tagExpression(key, f.ExcludeTags[key])))
+	}
+
+	switch len(expressions) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &expressions[0], nil
+	default:
+		return &types.Expression{And: expressions}, nil
+	}
+}
+
+// dimensionExpressions: ディメンション名と値の対応から Filter 式を生成 (無効なディメンション名が指定された場合はエラーを返す)
+func dimensionExpressions(dimensions map[string][]string) ([]types.Expression, error) {
+	expressions := make([]types.Expression, 0, len(dimensions))
+	for _, key := range sortedKeys(dimensions) {
+		dimension := types.Dimension(key)
+		if !slices.Contains(dimension.Values(), dimension) {
+			return nil, fmt.Errorf("invalid cost filter dimension: %s", key)
+		}
+		expressions = append(expressions, dimensionExpression(dimension, dimensions[key]))
+	}
+	return expressions, nil
+}
+
+func dimensionExpression(dimension types.Dimension, values []string) types.Expression {
+	return types.Expression{
+		Dimensions: &types.DimensionValues{
+			Key:          dimension,
+			Values:       values,
+			MatchOptions: []types.MatchOption{types.MatchOptionEquals},
+		},
+	}
+}
+
+func tagExpression(key string, values []string) types.Expression {
+	return types.Expression{
+		Tags: &types.TagValues{
+			Key:          aws.String(key),
+			Values:       values,
+			MatchOptions: []types.MatchOption{types.MatchOptionEquals},
+		},
+	}
+}
+
+func notExpression(e types.Expression) types.Expression {
+	return types.Expression{Not: &e}
+}
+
+// sortedKeys: Filter 式の生成結果を一定にするため、キーを昇順に並べて返す
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
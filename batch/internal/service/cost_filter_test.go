@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+func TestCostFilter_Expression(t *testing.T) {
+	equals := []types.MatchOption{types.MatchOptionEquals}
+
+	tests := []struct {
+		name    string
+		filter  service.CostFilter
+		want    *types.Expression
+		wantErr bool
+	}{
+		{
+			name:   "正常系: 絞り込み条件が指定されていない場合は nil を返すこと",
+			filter: service.CostFilter{},
+			want:   nil,
+		},
+		{
+			name:   "正常系: 条件が1つの場合は And で結合せずにそのまま返すこと",
+			filter: service.CostFilter{ExcludeRecordTypes: []string{service.RecordTypeCredit, service.RecordTypeRefund}},
+			want: &types.Expression{
+				Not: &types.Expression{
+					Dimensions: &types.DimensionValues{
+						Key:          types.DimensionRecordType,
+						Values:       []string{"Credit", "Refund"},
+						MatchOptions: equals,
+					},
+				},
+			},
+		},
+		{
+			name: "正常系: 複数の条件を And で結合し、除外条件は Not で囲むこと",
+			filter: service.CostFilter{
+				IncludeRecordTypes: []string{service.RecordTypeUsage},
+				IncludeDimensions:  map[string][]string{"REGION": {"ap-northeast-1"}},
+				ExcludeTags:        map[string][]string{"env": {"sandbox"}},
+			},
+			want: &types.Expression{
+				And: []types.Expression{
+					{
+						Dimensions: &types.DimensionValues{
+							Key:          types.DimensionRecordType,
+							Values:       []string{"Usage"},
+							MatchOptions: equals,
+						},
+					},
+					{
+						Dimensions: &types.DimensionValues{
+							Key:          types.DimensionRegion,
+							Values:       []string{"ap-northeast-1"},
+							MatchOptions: equals,
+						},
+					},
+					{
+						Not: &types.Expression{
+							Tags: &types.TagValues{
+								Key:          aws.String("env"),
+								Values:       []string{"sandbox"},
+								MatchOptions: equals,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "異常系: 無効なディメンション名が指定された場合はエラーを返すこと",
+			filter:  service.CostFilter{ExcludeDimensions: map[string][]string{"unknown": {"value"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.Expression()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+)
+
+// CostMetric: Cost Explorer で集計する利用コストの指標
+type CostMetric string
+
+const (
+	// CostMetricUnblended: 請求時点の利用コスト (RI・Savings Plans の前払い料金は購入月に計上)
+	CostMetricUnblended CostMetric = "UnblendedCost"
+
+	// CostMetricAmortized: RI・Savings Plans の前払い料金を期間内に按分した利用コスト
+	CostMetricAmortized CostMetric = "AmortizedCost"
+
+	// CostMetricNetAmortized: 割引適用後の按分された利用コスト
+	CostMetricNetAmortized CostMetric = "NetAmortizedCost"
+
+	// CostMetricBlended: 組織内で平均化された単価による利用コスト
+	CostMetricBlended CostMetric = "BlendedCost"
+
+	// CostMetricNetUnblended: 割引適用後の請求時点の利用コスト
+	CostMetricNetUnblended CostMetric = "NetUnblendedCost"
+)
+
+// String: 利用コストの指標の型を文字列型に変換
+func (cm CostMetric) String() string {
+	return string(cm)
+}
+
+// Valid: 指定された利用コストの指標が正しいかを検証
+func (cm CostMetric) Valid() bool {
+	_, ok := forecastMetrics[cm]
+	return ok
+}
+
+// ForecastMetric: GetCostForecast で指定する指標に変換
+func (cm CostMetric) ForecastMetric() types.Metric {
+	return forecastMetrics[cm]
+}
+
+// forecastMetrics: GetCostAndUsage と GetCostForecast で指標の表記が異なるため、その対応を保持
+var forecastMetrics = map[CostMetric]types.Metric{
+	CostMetricUnblended:    types.MetricUnblendedCost,
+	CostMetricAmortized:    types.MetricAmortizedCost,
+	CostMetricNetAmortized: types.MetricNetAmortizedCost,
+	CostMetricBlended:      types.MetricBlendedCost,
+	CostMetricNetUnblended: types.MetricNetUnblendedCost,
+}
+
+// footer: レポートのフッターに表示する指標名を生成
+func (cm CostMetric) footer() string {
+	return fmt.Sprintf("cost metric: %s", cm)
+}
+
+// ParseCostMetric: 文字列から利用コストの指標を生成し、無効な値が指定されている場合はエラーを返す
+func ParseCostMetric(s string) (CostMetric, error) {
+	cm := CostMetric(s)
+	if !cm.Valid() {
+		return "", fmt.Errorf("invalid cost metric: %s", s)
+	}
+	return cm, nil
+}
@@ -0,0 +1,41 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+func TestParseCostMetric(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected service.CostMetric
+		forecast types.Metric
+		hasError bool
+	}{
+		"UnblendedCostは有効な指標":    {input: "UnblendedCost", expected: service.CostMetricUnblended, forecast: types.MetricUnblendedCost},
+		"AmortizedCostは有効な指標":    {input: "AmortizedCost", expected: service.CostMetricAmortized, forecast: types.MetricAmortizedCost},
+		"NetAmortizedCostは有効な指標": {input: "NetAmortizedCost", expected: service.CostMetricNetAmortized, forecast: types.MetricNetAmortizedCost},
+		"BlendedCostは有効な指標":      {input: "BlendedCost", expected: service.CostMetricBlended, forecast: types.MetricBlendedCost},
+		"NetUnblendedCostは有効な指標": {input: "NetUnblendedCost", expected: service.CostMetricNetUnblended, forecast: types.MetricNetUnblendedCost},
+		"UsageQuantityは無効な指標":    {input: "UsageQuantity", hasError: true},
+		"大文字小文字が異なる場合は無効な指標":     {input: "unblendedcost", hasError: true},
+		"空文字は無効な指標":              {input: "", hasError: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := service.ParseCostMetric(tt.input)
+			if tt.hasError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.forecast, result.ForecastMetric())
+		})
+	}
+}
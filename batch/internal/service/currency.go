@@ -0,0 +1,115 @@
+package service
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/calc"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/exchange_rates"
+)
+
+// currencyUnit: レポートに表示する通貨の記号と小数点以下の桁数
+type currencyUnit struct {
+	symbol   string // 通貨記号
+	prefix   bool   // 金額の前に通貨記号を表示するか (false の場合は金額の後に表示)
+	decimals int    // 小数点以下の桁数 (ISO 4217 の補助通貨単位の桁数)
+}
+
+// currencyUnits: 通貨コードごとの表示形式 (定義していない通貨は通貨コードを金額の後に、ISO 4217 の補助単位の桁数で表示する)
+var currencyUnits = map[string]currencyUnit{
+	"JPY": {symbol: "円", decimals: 0},
+	"USD": {symbol: "$", prefix: true, decimals: 2},
+	"EUR": {symbol: "€", prefix: true, decimals: 2},
+	"GBP": {symbol: "£", prefix: true, decimals: 2},
+	"AUD": {symbol: "A$", prefix: true, decimals: 2},
+}
+
+// lookupCurrencyUnit: 通貨コードから表示形式を取得
+func lookupCurrencyUnit(currency string) currencyUnit {
+	if unit, ok := currencyUnits[currency]; ok {
+		return unit
+	}
+	decimals, ok := exchange_rates.ExchangeRatesCurrencyCode(currency).MinorUnits()
+	if !ok {
+		decimals = 2
+	}
+	return currencyUnit{symbol: currency, decimals: decimals}
+}
+
+// formatAmount: 金額を通貨の小数点以下の桁数で整形 (通貨記号は付けない)
+func formatAmount(currency string, cost float64) string {
+	return fmt.Sprintf("%.*f", lookupCurrencyUnit(currency).decimals, cost)
+}
+
+// roundUpCost: 変換した金額を通貨の小数点以下の桁数で切り上げる (例: JPY は整数、KWD は小数点以下3桁)
+func roundUpCost(currency string, cost float64) (float64, error) {
+	return calc.RoundUpToDecimalPlaces(cost, lookupCurrencyUnit(currency).decimals)
+}
+
+// formatCost: 金額を通貨記号付きの文字列に整形 (例: 1500 円, $10.25)
+func formatCost(currency string, cost float64) string {
+	if cost < 0 {
+		return formatUnit(currency, "-", math.Abs(cost))
+	}
+	return formatUnit(currency, "", cost)
+}
+
+// formatSignedCost: 増減額を符号と通貨記号付きの文字列に整形 (例: +1500 円, -$10.25)
+func formatSignedCost(currency string, cost float64) string {
+	sign := "+"
+	if cost < 0 {
+		sign = "-"
+	}
+	return formatUnit(currency, sign, math.Abs(cost))
+}
+
+// formatUnit: 符号、通貨記号、金額を通貨の表示形式に従って連結
+func formatUnit(currency, sign string, cost float64) string {
+	unit := lookupCurrencyUnit(currency)
+	amount := formatAmount(currency, cost)
+	switch {
+	case unit.symbol == "":
+		return sign + amount
+	case unit.prefix:
+		return sign + unit.symbol + amount
+	default:
+		return fmt.Sprintf("%s%s %s", sign, amount, unit.symbol)
+	}
+}
+
+// costHeader: 表の見出しに通貨記号を付記 (例: 昨日 (円)、見出しが空の場合は (円))
+func costHeader(label, currency string) string {
+	unit := lookupCurrencyUnit(currency)
+	switch {
+	case unit.symbol == "":
+		return label
+	case label == "":
+		return fmt.Sprintf("(%s)", unit.symbol)
+	default:
+		return fmt.Sprintf("%s (%s)", label, unit.symbol)
+	}
+}
+
+// InCurrency: 指定した通貨の日次利用コストレポートを取得 (変換していない通貨の場合は nil)
+func (dcu *DailyCostUsage) InCurrency(currency string) *DailyCostUsage {
+	if dcu.Currency == currency {
+		return dcu
+	}
+	return dcu.Conversions[currency]
+}
+
+// InCurrency: 指定した通貨の週次利用コストレポートを取得 (変換していない通貨の場合は nil)
+func (wcu *WeeklyCostUsage) InCurrency(currency string) *WeeklyCostUsage {
+	if wcu.Currency == currency {
+		return wcu
+	}
+	return wcu.Conversions[currency]
+}
+
+// InCurrency: 指定した通貨の月次利用コストレポートを取得 (変換していない通貨の場合は nil)
+func (mcu *MonthlyCostUsage) InCurrency(currency string) *MonthlyCostUsage {
+	if mcu.Currency == currency {
+		return mcu
+	}
+	return mcu.Conversions[currency]
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/exchange_rates"
+)
+
+func TestFormatCost(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		cost     float64
+		want     string
+		signed   string
+		header   string
+	}{
+		{name: "正常系: 円は小数点以下を表示せず、金額の後に記号を表示すること", currency: "JPY", cost: 1234.56, want: "1235 円", signed: "+1235 円", header: "今月 (円)"},
+		{name: "正常系: ドルは小数点以下2桁で、金額の前に記号を表示すること", currency: "USD", cost: 10.255, want: "$10.26", signed: "+$10.26", header: "今月 ($)"},
+		{name: "正常系: 減少額は記号の前に符号を表示すること", currency: "EUR", cost: -8.5, want: "-€8.50", signed: "-€8.50", header: "今月 (€)"},
+		{name: "正常系: 表示形式を定義していない通貨は通貨コードを金額の後に表示すること", currency: "CHF", cost: 12, want: "12.00 CHF", signed: "+12.00 CHF", header: "今月 (CHF)"},
+		{name: "正常系: 表示形式を定義していない通貨は ISO 4217 の補助単位の桁数で表示すること", currency: "KWD", cost: 12, want: "12.000 KWD", signed: "+12.000 KWD", header: "今月 (KWD)"},
+		{name: "正常系: 通貨が空の場合は金額のみを表示すること", currency: "", cost: 12, want: "12.00", signed: "+12.00", header: "今月"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatCost(tt.currency, tt.cost))
+			assert.Equal(t, tt.signed, formatSignedCost(tt.currency, tt.cost))
+			assert.Equal(t, tt.header, costHeader("今月", tt.currency))
+		})
+	}
+}
+
+func TestDailyCostUsage_CalcDailyCost(t *testing.T) {
+	dcu := &DailyCostUsage{
+		YesterdayCost: 10,
+		ActualCost:    100,
+		Metric:        CostMetricUnblended,
+		Currency:      defaultCostCurrency,
+		ActualServiceCosts: []ServiceCost{
+			{ServiceName: "Amazon EC2", Cost: 60},
+		},
+	}
+	res := &exchange_rates.ExchangeRatesResponse{
+		Base:   "USD",
+		Rates:  map[string]float64{"JPY": 150, "EUR": 0.95, "KWD": 0.3071},
+		Source: "openexchangerates",
+	}
+
+	t.Run("正常系: 指定した通貨の為替レートで変換すること", func(t *testing.T) {
+		eur, err := dcu.CalcDailyCost(res, "EUR")
+		assert.NoError(t, err)
+		assert.Equal(t, "EUR", eur.Currency)
+		assert.Equal(t, 95.0, eur.ActualCost)
+		assert.Equal(t, 57.0, eur.ActualServiceCosts[0].Cost)
+		assert.Equal(t, 0.95, eur.ExchangeRate.Rate)
+	})
+
+	t.Run("正常系: 変換した金額を通貨の小数点以下の桁数で切り上げること", func(t *testing.T) {
+		kwd, err := dcu.CalcDailyCost(res, "KWD")
+		assert.NoError(t, err)
+		assert.Equal(t, 3.071, kwd.YesterdayCost)
+		assert.Equal(t, 18.426, kwd.ActualServiceCosts[0].Cost)
+		assert.Equal(t, "3.071 KWD", formatCost(kwd.Currency, kwd.YesterdayCost))
+	})
+
+	t.Run("正常系: 基軸通貨を指定した場合は変換せず、フッターに為替レートを表示しないこと", func(t *testing.T) {
+		usd, err := dcu.CalcDailyCost(res, "USD")
+		assert.NoError(t, err)
+		assert.Equal(t, "USD", usd.Currency)
+		assert.Equal(t, 100.0, usd.ActualCost)
+		assert.Equal(t, CostMetricUnblended.footer(), reportFooter(usd.Metric, usd.ExchangeRate))
+	})
+
+	t.Run("異常系: レスポンスに含まれない通貨を指定した場合はエラーを返すこと", func(t *testing.T) {
+		_, err := dcu.CalcDailyCost(res, "GBP")
+		assert.ErrorContains(t, err, "GBP exchange rate not found in the response")
+	})
+
+	t.Run("正常系: 変換した通貨ごとのレポートを取得できること", func(t *testing.T) {
+		jpy, _ := dcu.CalcDailyCost(res, "JPY")
+		eur, _ := dcu.CalcDailyCost(res, "EUR")
+		jpy.Conversions = map[string]*DailyCostUsage{"EUR": eur}
+
+		assert.Same(t, jpy, jpy.InCurrency("JPY"))
+		assert.Same(t, eur, jpy.InCurrency("EUR"))
+		assert.Nil(t, jpy.InCurrency("USD"))
+	})
+}
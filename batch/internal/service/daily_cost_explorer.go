@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
-	"strconv"
+	"errors"
+	"log/slog"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
 
 	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
@@ -12,88 +14,124 @@ import (
 type IDailyCostExplorerClient interface {
 	GetYesterdayCost(ctx context.Context, yesterday, endDate string) (float64, error)
 	GetActualCost(ctx context.Context, startDate, endDate string) (float64, error)
-	GetForecastCost(ctx context.Context, actualCost float64, currentDay, daysInMonth int) (float64, error)
+	GetForecastCost(ctx context.Context, actualCost float64, startDate, endDate string, currentDay, daysInMonth int) (Forecast, error)
+	GetYesterdayServiceCosts(ctx context.Context, yesterday, endDate string) ([]ServiceCost, error)
+	GetActualServiceCosts(ctx context.Context, startDate, endDate string) ([]ServiceCost, error)
+	GetAccountCosts(ctx context.Context, yesterday, startDate, endDate string) ([]DailyAccountCost, error)
+	GetTagCosts(ctx context.Context, yesterday, startDate, endDate string) ([]DailyTagCostTable, error)
 }
 
 var _ IDailyCostExplorerClient = (*DailyCostExplorerService)(nil)
 
 type DailyCostExplorerService struct {
-	client *cost_explorer.Client
+	query        *CostQuery
+	accountNames map[string]string
+	tagKeys      []string
+	forecastMode ForecastMode
 }
 
-func NewDailyCostExplorerService(client *cost_explorer.Client) *DailyCostExplorerService {
-	return &DailyCostExplorerService{client: client}
+func NewDailyCostExplorerService(query *CostQuery, accountNames map[string]string, tagKeys []string, forecastMode ForecastMode) *DailyCostExplorerService {
+	return &DailyCostExplorerService{
+		query:        query,
+		accountNames: accountNames,
+		tagKeys:      tagKeys,
+		forecastMode: forecastMode,
+	}
 }
 
 // GetYesterdayCost: 昨日の利用コストを取得
 func (s *DailyCostExplorerService) GetYesterdayCost(ctx context.Context, yesterday, endDate string) (float64, error) {
+	return s.query.getTotalCost(ctx, yesterday, endDate, types.GranularityDaily)
+}
+
+// GetActualCost: 本日時点での今月の利用コストを取得
+func (s *DailyCostExplorerService) GetActualCost(ctx context.Context, startDate, endDate string) (float64, error) {
+	return s.query.getTotalCost(ctx, startDate, endDate, types.GranularityDaily)
+}
+
+// GetForecastCost: 今月の利用コストの予測値を算出
+//
+// ForecastModeAPI の場合は本日から来月の開始日付までの利用コストを GetCostForecast で予測し、本日時点での利用コストに加算する。
+// Cost Explorer に予測に必要なデータが不足している (DataUnavailableException) 場合は日割りによる予測にフォールバックする
+func (s *DailyCostExplorerService) GetForecastCost(ctx context.Context, actualCost float64, startDate, endDate string, currentDay, daysInMonth int) (Forecast, error) {
+	if s.forecastMode == ForecastModeLinear {
+		return newLinearForecast(actualCost, currentDay, daysInMonth), nil
+	}
 
-	input := &cost_explorer.GetCostAndUsageInput{
+	output, err := s.query.getCostForecast(ctx, &cost_explorer.GetCostForecastInput{
 		TimePeriod: &types.DateInterval{
-			Start: &yesterday,
+			Start: &startDate,
 			End:   &endDate,
 		},
-		Granularity: types.GranularityDaily,
-		Metrics:     []string{"UnblendedCost"},
-	}
-
-	output, err := s.client.GetCostAndUsage(ctx, input)
+		Granularity:             types.GranularityMonthly,
+		Metric:                  s.query.metric.ForecastMetric(),
+		PredictionIntervalLevel: aws.Int32(forecastPredictionIntervalLevel),
+	})
 	if err != nil {
-		return 0, err
-	}
-
-	if len(output.ResultsByTime) > 0 && len(output.ResultsByTime[0].Total) > 0 {
-		cost, ok := output.ResultsByTime[0].Total["UnblendedCost"]
-		if ok && cost.Amount != nil {
-			return s.parseFloat(*cost.Amount)
+		var dataUnavailable *types.DataUnavailableException
+		if errors.As(err, &dataUnavailable) {
+			slog.WarnContext(ctx, "cost forecast data is unavailable, fall back to linear forecast",
+				slog.String("error", err.Error()),
+			)
+			return newLinearForecast(actualCost, currentDay, daysInMonth), nil
 		}
+		return Forecast{}, err
 	}
 
-	return 0, nil
+	return newAPIForecast(actualCost, output)
 }
 
-// GetActualCost: 本日時点での今月の利用コストを取得
-func (s *DailyCostExplorerService) GetActualCost(ctx context.Context, startDate, endDate string) (float64, error) {
-	input := &cost_explorer.GetCostAndUsageInput{
-		TimePeriod: &types.DateInterval{
-			Start: &startDate,
-			End:   &endDate,
-		},
-		Granularity: types.GranularityDaily,
-		Metrics:     []string{"UnblendedCost"},
+// GetYesterdayServiceCosts: 昨日の利用コストをサービス単位で取得 (上位以外は "Others" にまとめる)
+func (s *DailyCostExplorerService) GetYesterdayServiceCosts(ctx context.Context, yesterday, endDate string) ([]ServiceCost, error) {
+	return s.getServiceCosts(ctx, yesterday, endDate)
+}
+
+// GetActualServiceCosts: 本日時点での今月の利用コストをサービス単位で取得 (上位以外は "Others" にまとめる)
+func (s *DailyCostExplorerService) GetActualServiceCosts(ctx context.Context, startDate, endDate string) ([]ServiceCost, error) {
+	return s.getServiceCosts(ctx, startDate, endDate)
+}
+
+// getServiceCosts: 指定期間の利用コストをサービス単位で集計し、コストの高い順に並べる
+func (s *DailyCostExplorerService) getServiceCosts(ctx context.Context, startDate, endDate string) ([]ServiceCost, error) {
+	costs, err := s.query.getGroupCosts(ctx, startDate, endDate, types.GranularityDaily, groupByService())
+	if err != nil {
+		return nil, err
 	}
 
-	output, err := s.client.GetCostAndUsage(ctx, input)
+	return rankServiceCosts(costs, topServicesLimit), nil
+}
+
+// GetAccountCosts: 連結アカウントごとに昨日の利用コストと本日時点での今月の利用コストを取得
+func (s *DailyCostExplorerService) GetAccountCosts(ctx context.Context, yesterday, startDate, endDate string) ([]DailyAccountCost, error) {
+	output, err := s.query.getGroupedCostAndUsage(ctx, startDate, endDate, types.GranularityDaily, groupByLinkedAccount())
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	totalCost := 0.0
-	for _, result := range output.ResultsByTime {
-		if cost, ok := result.Total["UnblendedCost"]; ok && cost.Amount != nil {
-			amount, err := s.parseFloat(*cost.Amount)
-			if err != nil {
-				return 0, err
-			}
-			totalCost += amount
-		}
+	groupCosts, err := sumDailyGroupCosts(output, s.query.metric.String(), yesterday)
+	if err != nil {
+		return nil, err
 	}
 
-	return totalCost, nil
+	return toDailyAccountCosts(groupCosts, resolveAccountNames(s.accountNames, output)), nil
 }
 
-// GetForecastCost: 今月の利用コストの予測値を算出
-func (s *DailyCostExplorerService) GetForecastCost(ctx context.Context, actualCost float64, currentDay, daysInMonth int) (float64, error) {
-
-	// 1日あたりの平均コスト
-	averageCostPerDay := actualCost / float64(currentDay)
+// GetTagCosts: 設定したコスト配分タグのキーごとに、タグの値単位で昨日の利用コストと本日時点での今月の利用コストを取得
+func (s *DailyCostExplorerService) GetTagCosts(ctx context.Context, yesterday, startDate, endDate string) ([]DailyTagCostTable, error) {
+	tables := make([]DailyTagCostTable, 0, len(s.tagKeys))
+	for _, tagKey := range s.tagKeys {
+		output, err := s.query.getGroupedCostAndUsage(ctx, startDate, endDate, types.GranularityDaily, groupByTag(tagKey))
+		if err != nil {
+			return nil, err
+		}
 
-	// 予測コスト
-	forecastCost := averageCostPerDay * float64(daysInMonth)
+		groupCosts, err := sumDailyGroupCosts(output, s.query.metric.String(), yesterday)
+		if err != nil {
+			return nil, err
+		}
 
-	return forecastCost, nil
-}
+		tables = append(tables, toDailyTagCostTable(tagKey, groupCosts))
+	}
 
-func (s *DailyCostExplorerService) parseFloat(value string) (float64, error) {
-	return strconv.ParseFloat(value, 64)
+	return tables, nil
 }
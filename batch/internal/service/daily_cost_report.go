@@ -8,28 +8,144 @@ import (
 
 // DailyCostUsage: 日次レポートに必要な要素を含む構造体
 type DailyCostUsage struct {
-	YesterdayCost float64
-	ActualCost    float64
-	ForecastCost  float64
+	YesterdayCost    float64
+	ActualCost       float64
+	AverageDailyCost float64 // 本日時点での今月の1日あたりの平均利用コスト
+	ForecastCost     float64
+
+	Metric       CostMetric   // 集計した利用コストの指標
+	Currency     string       // 利用コストの通貨
+	ExchangeRate ExchangeRate // 利用コストの変換に使用した為替レート (変換前は空)
+
+	YesterdayPeriod Period // 昨日の集計期間
+	ActualPeriod    Period // 今月の集計期間 (今月の1日から本日まで)
+
+	ForecastLowerBound float64
+	ForecastUpperBound float64
+	ForecastMode       ForecastMode
+
+	YesterdayServiceCosts []ServiceCost
+	ActualServiceCosts    []ServiceCost
+
+	AccountCosts []DailyAccountCost
+	TagCosts     []DailyTagCostTable
+
+	Conversions map[string]*DailyCostUsage // 他の表示する通貨に変換した利用コスト (通貨コードごと、Currency の利用コストは含まない)
 }
 
 // NewDailyCostUsage: DailyCostUsage のコンストラクタ
-func (dcs *DailyCostExplorerService) NewDailyCostUsage(yesterdayCost, actualCost, forecastCost float64) *DailyCostUsage {
+func (dcs *DailyCostExplorerService) NewDailyCostUsage(fd DailyReportDateFormatter, yesterdayCost, actualCost float64, forecast Forecast, yesterdayServiceCosts, actualServiceCosts []ServiceCost, accountCosts []DailyAccountCost, tagCosts []DailyTagCostTable) *DailyCostUsage {
 	return &DailyCostUsage{
-		YesterdayCost: yesterdayCost,
-		ActualCost:    actualCost,
-		ForecastCost:  forecastCost,
+		YesterdayCost:         yesterdayCost,
+		ActualCost:            actualCost,
+		AverageDailyCost:      actualCost / float64(fd.CurrentDay),
+		ForecastCost:          forecast.Cost,
+		ForecastLowerBound:    forecast.LowerBound,
+		ForecastUpperBound:    forecast.UpperBound,
+		ForecastMode:          forecast.Mode,
+		YesterdayServiceCosts: yesterdayServiceCosts,
+		ActualServiceCosts:    actualServiceCosts,
+		AccountCosts:          accountCosts,
+		TagCosts:              tagCosts,
+		Metric:                dcs.query.Metric(),
+
+		Currency:        dcs.query.Currency(),
+		YesterdayPeriod: Period{Start: fd.Yesterday, End: fd.EndDate},
+		ActualPeriod:    Period{Start: fd.StartDate, End: fd.EndDate},
 	}
 }
 
 // genSlackMessage: 日次利用コストレポートのメッセージを生成
 func (dcu DailyCostUsage) GenDailySlackMessage() slack.Attachment {
+	pretext := fmt.Sprintf(`
+• 昨日の利用コスト: %s
+%s• 本日時点での今月の利用コスト: %s
+%s• 今月の利用コストの予測値: %s %s
+`,
+		formatCost(dcu.Currency, dcu.YesterdayCost), formatServiceCostRanking(dcu.Currency, dcu.YesterdayServiceCosts),
+		formatCost(dcu.Currency, dcu.ActualCost), formatServiceCostRanking(dcu.Currency, dcu.ActualServiceCosts),
+		formatCost(dcu.Currency, dcu.ForecastCost), dcu.formatForecastDetail(),
+	)
+
+	// 連結アカウントが複数存在する場合のみ、アカウント別の内訳を表示
+	if len(dcu.AccountCosts) > 1 {
+		pretext += fmt.Sprintf("• アカウント別の利用コスト:\n%s", formatDailyAccountCosts(dcu.Currency, dcu.AccountCosts))
+	}
+
+	for _, table := range dcu.TagCosts {
+		pretext += fmt.Sprintf("• タグ別の利用コスト (%s):\n%s", table.TagKey, formatDailyTagCostTable(dcu.Currency, table))
+	}
+
 	return slack.Attachment{
-		Pretext: fmt.Sprintf(`
-• 昨日の利用コスト: %.2f 円
-• 本日時点での今月の利用コスト: %.2f 円
-• 今月の利用コストの予測値: %.2f 円
-`, dcu.YesterdayCost, dcu.ActualCost, dcu.ForecastCost,
-		),
+		Pretext: pretext,
+		Footer:  reportFooter(dcu.Metric, dcu.ExchangeRate),
+	}
+}
+
+// formatForecastDetail: 予測値の算出方法と予測区間を整形
+func (dcu DailyCostUsage) formatForecastDetail() string {
+	if dcu.ForecastMode != ForecastModeAPI {
+		return "(日割りによる推定)"
 	}
+	return fmt.Sprintf("(%d%% 予測区間: %s 〜 %s)", forecastPredictionIntervalLevel, formatCost(dcu.Currency, dcu.ForecastLowerBound), formatCost(dcu.Currency, dcu.ForecastUpperBound))
+}
+
+// GenDailySlackBlocks: 日次利用コストレポートを Block Kit のメッセージとして生成
+//
+// 昨日の利用コストが今月の1日あたりの平均利用コストに対して閾値（%）以上の場合は、赤色のバーで強調表示する
+func (dcu DailyCostUsage) GenDailySlackBlocks(alertThreshold float64) slack.BlockMessage {
+	yesterdayChange := 0.0
+	if dcu.AverageDailyCost != 0 {
+		yesterdayChange = dcu.YesterdayCost / dcu.AverageDailyCost * 100
+	}
+
+	forecast := formatCost(dcu.Currency, dcu.ForecastCost)
+	if dcu.ForecastMode == ForecastModeAPI {
+		forecast += fmt.Sprintf("\n(%d%% 予測区間: %s 〜 %s)", forecastPredictionIntervalLevel, formatCost(dcu.Currency, dcu.ForecastLowerBound), formatCost(dcu.Currency, dcu.ForecastUpperBound))
+	} else {
+		forecast += "\n(日割りによる推定)"
+	}
+
+	message := slack.BlockMessage{
+		Blocks: []slack.Block{
+			slack.HeaderBlock("AWS 日次利用コストレポート"),
+			slack.FieldsBlock(
+				slack.Field{Label: "昨日の利用コスト", Value: formatCost(dcu.Currency, dcu.YesterdayCost)},
+				slack.Field{Label: "今月の1日あたりの平均に対する昨日のコスト", Value: formatChange(yesterdayChange, dcu.AverageDailyCost)},
+				slack.Field{Label: "本日時点での今月の利用コスト", Value: formatCost(dcu.Currency, dcu.ActualCost)},
+				slack.Field{Label: "今月の利用コストの予測値", Value: forecast},
+			),
+			slack.ContextBlock(reportFooter(dcu.Metric, dcu.ExchangeRate)),
+		},
+		Details: [][]slack.Block{
+			{
+				slack.TableBlock("サービス別の利用コスト (昨日)", serviceCostTable(dcu.Currency, dcu.YesterdayServiceCosts)),
+				slack.TableBlock("サービス別の利用コスト (今月)", serviceCostTable(dcu.Currency, dcu.ActualServiceCosts)),
+			},
+		},
+	}
+
+	// 連結アカウントが複数存在する場合のみ、アカウント別の内訳を表示
+	if len(dcu.AccountCosts) > 1 {
+		message.Details = append(message.Details, []slack.Block{
+			slack.TableBlock("アカウント別の利用コスト", dailyAccountCostTable(dcu.Currency, dcu.AccountCosts)),
+		})
+	}
+
+	if len(dcu.TagCosts) > 0 {
+		tagBlocks := make([]slack.Block, 0, len(dcu.TagCosts))
+		for _, table := range dcu.TagCosts {
+			tagBlocks = append(tagBlocks, slack.TableBlock(fmt.Sprintf("タグ別の利用コスト (%s)", table.TagKey), dailyTagCostTable(dcu.Currency, table)))
+		}
+		message.Details = append(message.Details, tagBlocks)
+	}
+
+	if exceedsThreshold(yesterdayChange, dcu.AverageDailyCost, alertThreshold) {
+		message.Highlights = append(message.Highlights, thresholdHighlight(
+			fmt.Sprintf("昨日の利用コストが今月の1日あたりの平均の %.0f %% を超えています", alertThreshold),
+			[]string{fmt.Sprintf("昨日: %s / 1日あたりの平均: %s (%.2f %%)", formatCost(dcu.Currency, dcu.YesterdayCost), formatCost(dcu.Currency, dcu.AverageDailyCost), yesterdayChange)},
+		))
+	}
+
+	return message
 }
@@ -2,6 +2,37 @@ package service
 
 import "time"
 
+// Period: 利用コストの集計期間
+//
+// Cost Explorer の仕様に合わせて、終了日付は期間に含まない
+type Period struct {
+	Start string // 開始日付 (YYYY-MM-DD)
+	End   string // 終了日付 (YYYY-MM-DD)
+}
+
+// Dates: 集計期間に含まれる日付 (YYYY-MM-DD) を昇順に生成 (終了日付は期間に含まない)
+func (p Period) Dates() ([]string, error) {
+	series, err := dailyCostSeries(nil, p.Start, p.End)
+	if err != nil {
+		return nil, err
+	}
+
+	dates := make([]string, 0, len(series))
+	for _, dc := range series {
+		dates = append(dates, dc.Date)
+	}
+	return dates, nil
+}
+
+// LastDate: 集計期間の最終日 (終了日付の前日) を取得
+func (p Period) LastDate() (string, error) {
+	end, err := time.Parse("2006-01-02", p.End)
+	if err != nil {
+		return "", err
+	}
+	return end.AddDate(0, 0, -1).Format("2006-01-02"), nil
+}
+
 // DailyReportDateFormatter: 日次コストレポートのための日時情報を保持する構造体
 type DailyReportDateFormatter struct {
 	Yesterday   string // 昨日の日付
@@ -9,6 +40,8 @@ type DailyReportDateFormatter struct {
 	EndDate     string // 今月の終了日付
 	CurrentDay  int    // 今日までの日数
 	DaysInMonth int    // 今月の総日数
+
+	NextMonthStartDate string // 来月の開始日付
 }
 
 // WeeklyReportDateFormatter: 週次コストレポートのための日時情報を保持する構造体
@@ -19,6 +52,16 @@ type WeeklyReportDateFormatter struct {
 	WeekBeforeLastEndDate   string // 先々週の終了日付
 }
 
+// MonthlyReportDateFormatter: 月次コストレポートのための日時情報を保持する構造体
+type MonthlyReportDateFormatter struct {
+	LastMonth                string // 先月 (YYYY-MM)
+	LastMonthStartDate       string // 先月の開始日付
+	LastMonthEndDate         string // 先月の終了日付
+	MonthBeforeLast          string // 先々月 (YYYY-MM)
+	MonthBeforeLastStartDate string // 先々月の開始日付
+	MonthBeforeLastEndDate   string // 先々月の終了日付
+}
+
 // NewDailyReportDateFormatter: DailyReportDateFormatter のコンストラクタ
 //
 // 実行日時からコスト算出に必要な各基準日を取得
@@ -32,6 +75,8 @@ type WeeklyReportDateFormatter struct {
 // CurrentDay: 今日までの日数 (int)
 //
 // DaysInMonth: 今月の総日数 (int)
+//
+// NextMonthStartDate: 来月の開始日付 (string)
 func (ds *DailyCostExplorerService) NewDailyReportDateFormatter(execTime time.Time) DailyReportDateFormatter {
 	currentYear, currentMonth, _ := execTime.Date()
 	daysInMonth := time.Date(currentYear, currentMonth+1, 0, 0, 0, 0, 0, time.UTC).Day()
@@ -42,6 +87,8 @@ func (ds *DailyCostExplorerService) NewDailyReportDateFormatter(execTime time.Ti
 		EndDate:     execTime.Format("2006-01-02"),
 		CurrentDay:  execTime.Day(),
 		DaysInMonth: daysInMonth,
+
+		NextMonthStartDate: time.Date(execTime.Year(), execTime.Month()+1, 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02"),
 	}
 }
 
@@ -62,3 +109,33 @@ func (ws *WeeklyCostExplorerService) NewWeeklyReportDateFormatter(execTime time.
 		WeekBeforeLastEndDate:   execTime.AddDate(0, 0, -14).Format("2006-01-02"),
 	}
 }
+
+// NewMonthlyReportDateFormatter: MonthlyReportDateFormatter のコンストラクタ
+//
+// 終了日付は Cost Explorer の仕様上期間に含まれないため、各月の終了日付には翌月の1日を設定する
+//
+// lastMonth: 先月 (string)
+//
+// lastMonthStartDate: 先月の開始日付 (string)
+//
+// lastMonthEndDate: 先月の終了日付 (string)
+//
+// monthBeforeLast: 先々月 (string)
+//
+// monthBeforeLastStartDate: 先々月の開始日付 (string)
+//
+// monthBeforeLastEndDate: 先々月の終了日付 (string)
+func (ms *MonthlyCostExplorerService) NewMonthlyReportDateFormatter(execTime time.Time) MonthlyReportDateFormatter {
+	currentMonthStart := time.Date(execTime.Year(), execTime.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastMonthStart := currentMonthStart.AddDate(0, -1, 0)
+	monthBeforeLastStart := currentMonthStart.AddDate(0, -2, 0)
+
+	return MonthlyReportDateFormatter{
+		LastMonth:                lastMonthStart.Format("2006-01"),
+		LastMonthStartDate:       lastMonthStart.Format("2006-01-02"),
+		LastMonthEndDate:         currentMonthStart.Format("2006-01-02"),
+		MonthBeforeLast:          monthBeforeLastStart.Format("2006-01"),
+		MonthBeforeLastStartDate: monthBeforeLastStart.Format("2006-01-02"),
+		MonthBeforeLastEndDate:   lastMonthStart.Format("2006-01-02"),
+	}
+}
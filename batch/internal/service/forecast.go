@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+
+	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
+)
+
+// forecastPredictionIntervalLevel: GetCostForecast で取得する予測区間の信頼水準 (%)
+const forecastPredictionIntervalLevel int32 = 80
+
+// ForecastMode: 今月の利用コストの予測方法
+type ForecastMode string
+
+const (
+	// ForecastModeAPI: Cost Explorer の GetCostForecast を利用して予測する (データ不足の場合は ForecastModeLinear にフォールバック)
+	ForecastModeAPI ForecastMode = "api"
+
+	// ForecastModeLinear: 本日時点での利用コストを日割りし、今月の総日数分に引き延ばして予測する
+	ForecastModeLinear ForecastMode = "linear"
+)
+
+// String: 予測方法の型を文字列型に変換
+func (fm ForecastMode) String() string {
+	return string(fm)
+}
+
+// Valid: 指定された予測方法が正しいかを検証
+func (fm ForecastMode) Valid() bool {
+	switch fm {
+	case ForecastModeAPI, ForecastModeLinear:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseForecastMode: 文字列から予測方法を生成し、無効な値が指定されている場合はエラーを返す
+func ParseForecastMode(s string) (ForecastMode, error) {
+	fm := ForecastMode(s)
+	if !fm.Valid() {
+		return "", fmt.Errorf("invalid forecast mode: %s", s)
+	}
+	return fm, nil
+}
+
+// Forecast: 今月の利用コストの予測値
+type Forecast struct {
+	Cost       float64      // 今月の利用コストの予測値
+	LowerBound float64      // 予測区間の下限 (ForecastModeLinear の場合は予測値と同じ)
+	UpperBound float64      // 予測区間の上限 (ForecastModeLinear の場合は予測値と同じ)
+	Mode       ForecastMode // 実際に使用した予測方法
+}
+
+// newLinearForecast: 本日時点での利用コストを日割りして今月の利用コストを予測
+func newLinearForecast(actualCost float64, currentDay, daysInMonth int) Forecast {
+	// 1日あたりの平均コスト
+	averageCostPerDay := actualCost / float64(currentDay)
+
+	// 予測コスト
+	forecastCost := averageCostPerDay * float64(daysInMonth)
+
+	return Forecast{
+		Cost:       forecastCost,
+		LowerBound: forecastCost,
+		UpperBound: forecastCost,
+		Mode:       ForecastModeLinear,
+	}
+}
+
+// newAPIForecast: 本日時点での利用コストに GetCostForecast で取得した残り期間の予測値を加算し、今月の利用コストを予測
+func newAPIForecast(actualCost float64, output *cost_explorer.GetCostForecastOutput) (Forecast, error) {
+	forecast := Forecast{
+		Cost:       actualCost,
+		LowerBound: actualCost,
+		UpperBound: actualCost,
+		Mode:       ForecastModeAPI,
+	}
+
+	if output.Total != nil && output.Total.Amount != nil {
+		total, err := strconv.ParseFloat(*output.Total.Amount, 64)
+		if err != nil {
+			return Forecast{}, err
+		}
+		forecast.Cost += total
+	}
+
+	for _, result := range output.ForecastResultsByTime {
+		if result.PredictionIntervalLowerBound != nil {
+			lower, err := strconv.ParseFloat(*result.PredictionIntervalLowerBound, 64)
+			if err != nil {
+				return Forecast{}, err
+			}
+			forecast.LowerBound += lower
+		}
+		if result.PredictionIntervalUpperBound != nil {
+			upper, err := strconv.ParseFloat(*result.PredictionIntervalUpperBound, 64)
+			if err != nil {
+				return Forecast{}, err
+			}
+			forecast.UpperBound += upper
+		}
+	}
+
+	return forecast, nil
+}
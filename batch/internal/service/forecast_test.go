@@ -0,0 +1,70 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseForecastMode(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected ForecastMode
+		hasError bool
+	}{
+		"apiは有効な予測方法":    {input: "api", expected: ForecastModeAPI},
+		"linearは有効な予測方法": {input: "linear", expected: ForecastModeLinear},
+		"空文字は無効な予測方法":    {input: "", hasError: true},
+		"未定義の値は無効な予測方法":  {input: "prophet", hasError: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := ParseForecastMode(tt.input)
+			if tt.hasError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestNewLinearForecast(t *testing.T) {
+	t.Run("正常系: 日割りした利用コストを今月の総日数分に引き延ばすこと", func(t *testing.T) {
+		assert.Equal(t, Forecast{
+			Cost:       31,
+			LowerBound: 31,
+			UpperBound: 31,
+			Mode:       ForecastModeLinear,
+		}, newLinearForecast(10, 10, 31))
+	})
+}
+
+func TestNewAPIForecast(t *testing.T) {
+	t.Run("正常系: 本日時点での利用コストに残り期間の予測値と予測区間を加算すること", func(t *testing.T) {
+		output := &cost_explorer.GetCostForecastOutput{
+			Total: &types.MetricValue{Amount: aws.String("5.5")},
+			ForecastResultsByTime: []types.ForecastResult{
+				{
+					MeanValue:                    aws.String("5.5"),
+					PredictionIntervalLowerBound: aws.String("4.5"),
+					PredictionIntervalUpperBound: aws.String("7"),
+				},
+			},
+		}
+
+		result, err := newAPIForecast(10, output)
+		assert.NoError(t, err)
+		assert.Equal(t, Forecast{
+			Cost:       15.5,
+			LowerBound: 14.5,
+			UpperBound: 17,
+			Mode:       ForecastModeAPI,
+		}, result)
+	})
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
+)
+
+// dailyGroupCost: グループのキーごとの昨日と今月の利用コスト
+type dailyGroupCost struct {
+	key           string
+	yesterdayCost float64
+	actualCost    float64
+}
+
+// weeklyGroupCost: グループのキーごとの先週と先々週の利用コスト
+type weeklyGroupCost struct {
+	key                string
+	lastWeekCost       float64
+	weekBeforeLastCost float64
+	percentageChange   float64
+}
+
+// sumDailyGroupCosts: 今月の利用コストをグループ単位・日単位で集計したレスポンスから、グループのキーごとに昨日と今月の利用コストを算出
+//
+// 結果は昨日のコストの降順、同額の場合はキーの昇順で並び替える
+func sumDailyGroupCosts(output *cost_explorer.GetCostAndUsageOutput, metric, yesterday string) ([]dailyGroupCost, error) {
+	indexes := make(map[string]int)
+	groupCosts := make([]dailyGroupCost, 0)
+	for _, result := range output.ResultsByTime {
+		isYesterday := result.TimePeriod != nil && aws.ToString(result.TimePeriod.Start) == yesterday
+		for _, group := range result.Groups {
+			if len(group.Keys) == 0 {
+				continue
+			}
+			cost, ok := group.Metrics[metric]
+			if !ok || cost.Amount == nil {
+				continue
+			}
+			amount, err := strconv.ParseFloat(*cost.Amount, 64)
+			if err != nil {
+				return nil, err
+			}
+
+			key := group.Keys[0]
+			idx, ok := indexes[key]
+			if !ok {
+				idx = len(groupCosts)
+				indexes[key] = idx
+				groupCosts = append(groupCosts, dailyGroupCost{key: key})
+			}
+
+			groupCosts[idx].actualCost += amount
+			if isYesterday {
+				groupCosts[idx].yesterdayCost += amount
+			}
+		}
+	}
+
+	sort.Slice(groupCosts, func(i, j int) bool {
+		if groupCosts[i].yesterdayCost == groupCosts[j].yesterdayCost {
+			return groupCosts[i].key < groupCosts[j].key
+		}
+		return groupCosts[i].yesterdayCost > groupCosts[j].yesterdayCost
+	})
+
+	return groupCosts, nil
+}
+
+// splitWeeklyGroupCosts: 先々週から先週までをグループ単位・日単位で集計したレスポンスを、日付を基に先週と先々週のグループのキーごとの利用コストに振り分ける
+//
+// どちらの週にも含まれない日は無視する
+func splitWeeklyGroupCosts(output *cost_explorer.GetCostAndUsageOutput, metric, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate string) (lastWeek, weekBeforeLast map[string]float64, err error) {
+	lastWeek = make(map[string]float64)
+	weekBeforeLast = make(map[string]float64)
+	for _, result := range output.ResultsByTime {
+		if result.TimePeriod == nil {
+			continue
+		}
+
+		// 日付は YYYY-MM-DD 形式のため、文字列の比較で期間 (開始日を含み終了日を含まない) に含まれるかを判定できる
+		var costs map[string]float64
+		switch date := aws.ToString(result.TimePeriod.Start); {
+		case lastWeekStartDate <= date && date < lastWeekEndDate:
+			costs = lastWeek
+		case weekBeforeLastStartDate <= date && date < weekBeforeLastEndDate:
+			costs = weekBeforeLast
+		default:
+			continue
+		}
+
+		for _, group := range result.Groups {
+			if len(group.Keys) == 0 {
+				continue
+			}
+			cost, ok := group.Metrics[metric]
+			if !ok || cost.Amount == nil {
+				continue
+			}
+			amount, err := strconv.ParseFloat(*cost.Amount, 64)
+			if err != nil {
+				return nil, nil, err
+			}
+			costs[group.Keys[0]] += amount
+		}
+	}
+
+	return lastWeek, weekBeforeLast, nil
+}
+
+// mergeWeeklyGroupCosts: 先週と先々週のグループのキーごとの利用コストを突き合わせ、キーごとの増減率を算出
+//
+// 結果は先週のコストの降順、同額の場合はキーの昇順で並び替える。先々週のコストが0の場合、増減率は0とする
+func mergeWeeklyGroupCosts(lastWeek, weekBeforeLast map[string]float64) []weeklyGroupCost {
+	indexes := make(map[string]int)
+	groupCosts := make([]weeklyGroupCost, 0, len(lastWeek))
+
+	for key, cost := range lastWeek {
+		indexes[key] = len(groupCosts)
+		groupCosts = append(groupCosts, weeklyGroupCost{key: key, lastWeekCost: cost})
+	}
+
+	for key, cost := range weekBeforeLast {
+		idx, ok := indexes[key]
+		if !ok {
+			idx = len(groupCosts)
+			indexes[key] = idx
+			groupCosts = append(groupCosts, weeklyGroupCost{key: key})
+		}
+		groupCosts[idx].weekBeforeLastCost = cost
+	}
+
+	for i := range groupCosts {
+		if groupCosts[i].weekBeforeLastCost != 0 {
+			groupCosts[i].percentageChange = groupCosts[i].lastWeekCost / groupCosts[i].weekBeforeLastCost * 100
+		}
+	}
+
+	sort.Slice(groupCosts, func(i, j int) bool {
+		if groupCosts[i].lastWeekCost == groupCosts[j].lastWeekCost {
+			return groupCosts[i].key < groupCosts[j].key
+		}
+		return groupCosts[i].lastWeekCost > groupCosts[j].lastWeekCost
+	})
+
+	return groupCosts
+}
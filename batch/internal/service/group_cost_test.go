@@ -0,0 +1,74 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSumDailyGroupCosts(t *testing.T) {
+	output := &cost_explorer.GetCostAndUsageOutput{
+		ResultsByTime: []types.ResultByTime{
+			{
+				TimePeriod: &types.DateInterval{Start: aws.String("2024-12-27"), End: aws.String("2024-12-28")},
+				Groups: []types.Group{
+					{Keys: []string{"111111111111"}, Metrics: map[string]types.MetricValue{"UnblendedCost": {Amount: aws.String("1.5")}}},
+					{Keys: []string{"222222222222"}, Metrics: map[string]types.MetricValue{"UnblendedCost": {Amount: aws.String("0.5")}}},
+				},
+			},
+			{
+				TimePeriod: &types.DateInterval{Start: aws.String("2024-12-28"), End: aws.String("2024-12-29")},
+				Groups: []types.Group{
+					{Keys: []string{"111111111111"}, Metrics: map[string]types.MetricValue{"UnblendedCost": {Amount: aws.String("0.25")}}},
+					{Keys: []string{"222222222222"}, Metrics: map[string]types.MetricValue{"UnblendedCost": {Amount: aws.String("0.75")}}},
+				},
+			},
+		},
+		DimensionValueAttributes: []types.DimensionValuesWithAttributes{
+			{Value: aws.String("111111111111"), Attributes: map[string]string{"description": "management"}},
+			{Value: aws.String("222222222222"), Attributes: map[string]string{"description": "member"}},
+		},
+	}
+
+	t.Run("正常系: 昨日のコストの降順でキーごとの利用コストを返すこと", func(t *testing.T) {
+		result, err := sumDailyGroupCosts(output, "UnblendedCost", "2024-12-28")
+		assert.NoError(t, err)
+		assert.Equal(t, []dailyGroupCost{
+			{key: "222222222222", yesterdayCost: 0.75, actualCost: 1.25},
+			{key: "111111111111", yesterdayCost: 0.25, actualCost: 1.75},
+		}, result)
+	})
+
+	t.Run("正常系: 設定したアカウント名をCost Explorerの説明より優先すること", func(t *testing.T) {
+		groupCosts, err := sumDailyGroupCosts(output, "UnblendedCost", "2024-12-28")
+		assert.NoError(t, err)
+
+		names := resolveAccountNames(map[string]string{"222222222222": "production"}, output)
+		assert.Equal(t, []DailyAccountCost{
+			{AccountID: "222222222222", AccountName: "production", YesterdayCost: 0.75, ActualCost: 1.25},
+			{AccountID: "111111111111", AccountName: "management", YesterdayCost: 0.25, ActualCost: 1.75},
+		}, toDailyAccountCosts(groupCosts, names))
+	})
+}
+
+func TestMergeWeeklyGroupCosts(t *testing.T) {
+	lastWeek := map[string]float64{
+		"111111111111": 30,
+		"333333333333": 5,
+	}
+	weekBeforeLast := map[string]float64{
+		"111111111111": 20,
+		"222222222222": 10,
+	}
+
+	t.Run("正常系: 先週と先々週のコストを突き合わせて増減率を算出すること", func(t *testing.T) {
+		assert.Equal(t, []weeklyGroupCost{
+			{key: "111111111111", lastWeekCost: 30, weekBeforeLastCost: 20, percentageChange: 150},
+			{key: "333333333333", lastWeekCost: 5, weekBeforeLastCost: 0, percentageChange: 0},
+			{key: "222222222222", lastWeekCost: 0, weekBeforeLastCost: 10, percentageChange: 0},
+		}, mergeWeeklyGroupCosts(lastWeek, weekBeforeLast))
+	})
+}
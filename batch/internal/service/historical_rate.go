@@ -0,0 +1,186 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/exchange_rates"
+)
+
+// RateMode: 利用コストの変換に使用する為替レートの種類
+type RateMode string
+
+const (
+	RateModeLatest  RateMode = "latest"  // 実行時点の最新の為替レートで変換
+	RateModeDaily   RateMode = "daily"   // 利用日ごとの為替レートで変換
+	RateModeAverage RateMode = "average" // 集計期間の為替レートの平均で変換
+)
+
+func (rm RateMode) String() string {
+	return string(rm)
+}
+
+// Valid: 指定された為替レートの種類が正しいかを検証
+func (rm RateMode) Valid() bool {
+	switch rm {
+	case RateModeLatest, RateModeDaily, RateModeAverage:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseRateMode: 文字列から為替レートの種類を生成し、無効な値が指定されている場合はエラーを返す
+func ParseRateMode(s string) (RateMode, error) {
+	rm := RateMode(s)
+	if !rm.Valid() {
+		return "", fmt.Errorf("invalid rate mode: %s", s)
+	}
+	return rm, nil
+}
+
+// HistoricalRates: 日付 (YYYY-MM-DD) ごとの為替レートの取得元のレスポンス
+type HistoricalRates map[string]*exchange_rates.ExchangeRatesResponse
+
+// periodRate: 集計期間の利用コストの変換に使用する為替レート
+type periodRate struct {
+	ExchangeRate                    // 集計期間の実効レート
+	daily        map[string]float64 // 利用日ごとの為替レート (nil の場合は全ての利用日に実効レートを適用)
+}
+
+// on: 指定した利用日の利用コストに適用する為替レートを取得
+func (pr periodRate) on(date string) float64 {
+	if rate, ok := pr.daily[date]; ok {
+		return rate
+	}
+	return pr.Rate
+}
+
+// convert: 集計期間の利用コストの合計を変換 (利用日ごとの為替レートの場合は日ごとに変換して合計)
+func (pr periodRate) convert(total float64, dailyCosts []DailyCost) float64 {
+	if pr.daily == nil {
+		return total * pr.Rate
+	}
+
+	converted := 0.0
+	for _, dc := range dailyCosts {
+		converted += dc.Cost * pr.on(dc.Date)
+	}
+	return converted
+}
+
+// newPeriodRate: 利用日ごとの為替レートから、集計期間に適用する為替レートを生成
+//
+// RateModeDaily の場合は利用日ごとの為替レートを適用し、利用コストで加重平均した実効レートを集計期間の為替レートとする。
+// RateModeAverage の場合は利用日ごとの為替レートの平均を全ての利用日に適用する。
+// 集計期間の利用コストが0の場合は、実効レートとして為替レートの平均を使用する。
+func newPeriodRate(rates HistoricalRates, mode RateMode, currency string, period Period, dailyCosts []DailyCost) (periodRate, error) {
+	if len(dailyCosts) == 0 {
+		return periodRate{}, fmt.Errorf("no daily costs in the period %s - %s", period.Start, period.End)
+	}
+
+	var (
+		daily     = make(map[string]float64, len(dailyCosts))
+		sources   []string
+		stale     bool
+		base      string
+		timestamp int64
+		sum       float64
+		cost      float64
+		converted float64
+	)
+	for _, dc := range dailyCosts {
+		res, ok := rates[dc.Date]
+		if !ok {
+			return periodRate{}, fmt.Errorf("exchange rates on %s not found", dc.Date)
+		}
+		rate, err := exchangeRateOf(res, currency)
+		if err != nil {
+			return periodRate{}, fmt.Errorf("exchange rates on %s: %w", dc.Date, err)
+		}
+
+		daily[dc.Date] = rate
+		sum += rate
+		cost += dc.Cost
+		converted += dc.Cost * rate
+
+		base = res.Base
+		stale = stale || res.Stale
+		timestamp = max(timestamp, res.Timestamp)
+		if res.Source != "" && !slices.Contains(sources, res.Source) {
+			sources = append(sources, res.Source)
+		}
+	}
+
+	average := sum / float64(len(dailyCosts))
+	pr := periodRate{
+		ExchangeRate: ExchangeRate{
+			BaseCurrency: base,
+			Currency:     currency,
+			Rate:         average,
+			Timestamp:    time.Unix(timestamp, 0).UTC(),
+			Source:       strings.Join(sources, ","),
+			Period:       period,
+			Basis:        mode.String(),
+			Stale:        stale,
+		},
+	}
+	if mode == RateModeDaily {
+		pr.daily = daily
+		if cost > 0 {
+			pr.Rate = converted / cost
+		}
+	}
+	return pr, nil
+}
+
+// CalcWeeklyCostWithHistoricalRates: 利用日ごとの為替レートを使用して、利用コストを指定した通貨に変換
+//
+// 先週と先々週で異なる為替レートを適用するため、増減率は変換後の利用コストから算出し直す
+func (wcu *WeeklyCostUsage) CalcWeeklyCostWithHistoricalRates(rates HistoricalRates, mode RateMode, currency string) (*WeeklyCostUsage, error) {
+	lastWeekDays, weekBeforeLastDays := wcu.dailyCostsByWeek()
+
+	lastWeek, err := newPeriodRate(rates, mode, currency, wcu.LastWeekPeriod, lastWeekDays)
+	if err != nil {
+		return nil, err
+	}
+
+	weekBeforeLast, err := newPeriodRate(rates, mode, currency, wcu.WeekBeforeLastPeriod, weekBeforeLastDays)
+	if err != nil {
+		return nil, err
+	}
+
+	return wcu.convertCost(lastWeek, weekBeforeLast)
+}
+
+// CalcMonthlyCostWithHistoricalRates: 利用日ごとの為替レートを使用して、利用コストを指定した通貨に変換
+//
+// 先月と先々月の日ごとの利用コスト (DailyCosts) を基に、集計期間ごとに適用する為替レートを算出する
+func (mcu *MonthlyCostUsage) CalcMonthlyCostWithHistoricalRates(rates HistoricalRates, mode RateMode, currency string) (*MonthlyCostUsage, error) {
+	lastMonth, err := newPeriodRate(rates, mode, currency, mcu.LastMonthPeriod, mcu.DailyCosts.LastMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	monthBeforeLast, err := newPeriodRate(rates, mode, currency, mcu.MonthBeforeLastPeriod, mcu.DailyCosts.MonthBeforeLast)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcu.convertCost(lastMonth, monthBeforeLast)
+}
+
+// dailyCostsByWeek: 日別の比較表から、先週と先々週の日ごとの利用コストを取得
+func (wcu *WeeklyCostUsage) dailyCostsByWeek() (lastWeek, weekBeforeLast []DailyCost) {
+	for _, dc := range wcu.DailyCosts {
+		if dc.LastWeekDate != "" {
+			lastWeek = append(lastWeek, DailyCost{Date: dc.LastWeekDate, Cost: dc.LastWeekCost})
+		}
+		if dc.WeekBeforeLastDate != "" {
+			weekBeforeLast = append(weekBeforeLast, DailyCost{Date: dc.WeekBeforeLastDate, Cost: dc.WeekBeforeLastCost})
+		}
+	}
+	return lastWeek, weekBeforeLast
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/exchange_rates"
+)
+
+func historicalRates(source string, rates map[string]float64) HistoricalRates {
+	hr := make(HistoricalRates, len(rates))
+	for date, rate := range rates {
+		d, _ := time.Parse("2006-01-02", date)
+		hr[date] = &exchange_rates.ExchangeRatesResponse{
+			Timestamp: d.Unix(),
+			Base:      "USD",
+			Rates:     map[string]float64{"JPY": rate},
+			Source:    source,
+		}
+	}
+	return hr
+}
+
+func TestParseRateMode(t *testing.T) {
+	rm, err := ParseRateMode("average")
+	assert.NoError(t, err)
+	assert.Equal(t, RateModeAverage, rm)
+
+	_, err = ParseRateMode("monthly")
+	assert.Error(t, err)
+}
+
+func TestWeeklyCostUsage_CalcWeeklyCostWithHistoricalRates(t *testing.T) {
+	wcu := &WeeklyCostUsage{
+		LastWeekCost:       40,
+		WeekBeforeLastCost: 20,
+		PercentageChange:   200,
+		DailyCosts: []WeeklyDailyCost{
+			{LastWeekDate: "2024-12-16", LastWeekCost: 10, WeekBeforeLastDate: "2024-12-09", WeekBeforeLastCost: 10, PercentageChange: 100},
+			{LastWeekDate: "2024-12-17", LastWeekCost: 30, WeekBeforeLastDate: "2024-12-10", WeekBeforeLastCost: 10, PercentageChange: 300},
+		},
+		AccountCosts: []WeeklyAccountCost{
+			{AccountID: "111111111111", LastWeekCost: 40, WeekBeforeLastCost: 20, PercentageChange: 200},
+		},
+		Metric:               CostMetricUnblended,
+		Currency:             defaultCostCurrency,
+		LastWeekPeriod:       Period{Start: "2024-12-16", End: "2024-12-18"},
+		WeekBeforeLastPeriod: Period{Start: "2024-12-09", End: "2024-12-11"},
+	}
+	rates := historicalRates("ecb", map[string]float64{
+		"2024-12-09": 100, "2024-12-10": 100,
+		"2024-12-16": 150, "2024-12-17": 160,
+	})
+
+	t.Run("正常系: 利用日ごとの為替レートで変換し、利用コストで加重平均した実効レートを集計期間ごとに保持すること", func(t *testing.T) {
+		jpy, err := wcu.CalcWeeklyCostWithHistoricalRates(rates, RateModeDaily, "JPY")
+		assert.NoError(t, err)
+
+		assert.Equal(t, 6300.0, jpy.LastWeekCost) // 10 * 150 + 30 * 160
+		assert.Equal(t, 2000.0, jpy.WeekBeforeLastCost)
+		assert.InDelta(t, 315, jpy.PercentageChange, 1e-9)
+
+		assert.Equal(t, 1500.0, jpy.DailyCosts[0].LastWeekCost)
+		assert.InDelta(t, 150, jpy.DailyCosts[0].PercentageChange, 1e-9)
+		assert.Equal(t, 4800.0, jpy.DailyCosts[1].LastWeekCost)
+		assert.InDelta(t, 480, jpy.DailyCosts[1].PercentageChange, 1e-9)
+
+		assert.Equal(t, 6300.0, jpy.AccountCosts[0].LastWeekCost)
+		assert.InDelta(t, 315, jpy.AccountCosts[0].PercentageChange, 1e-9)
+
+		assert.Equal(t, 157.5, jpy.ExchangeRate.Rate)
+		assert.Equal(t, wcu.LastWeekPeriod, jpy.ExchangeRate.Period)
+		assert.Equal(t, 100.0, jpy.WeekBeforeLastExchangeRate.Rate)
+		assert.Equal(t, "ecb", jpy.WeekBeforeLastExchangeRate.Source)
+
+		footer := reportFooter(jpy.Metric, jpy.exchangeRates()...)
+		assert.Contains(t, footer, "exchange rate 2024-12-16..2024-12-17 (daily): 1 USD = 157.50 JPY (source: ecb)")
+		assert.Contains(t, footer, "exchange rate 2024-12-09..2024-12-10 (daily): 1 USD = 100.00 JPY (source: ecb)")
+	})
+
+	t.Run("正常系: 集計期間の為替レートの平均で全ての利用日を変換すること", func(t *testing.T) {
+		jpy, err := wcu.CalcWeeklyCostWithHistoricalRates(rates, RateModeAverage, "JPY")
+		assert.NoError(t, err)
+
+		assert.Equal(t, 6200.0, jpy.LastWeekCost) // 40 * 155
+		assert.Equal(t, 1550.0, jpy.DailyCosts[0].LastWeekCost)
+		assert.Equal(t, 4650.0, jpy.DailyCosts[1].LastWeekCost)
+		assert.InDelta(t, 310, jpy.PercentageChange, 1e-9)
+		assert.Equal(t, 155.0, jpy.ExchangeRate.Rate)
+		assert.Equal(t, "average", jpy.ExchangeRate.Basis)
+	})
+
+	t.Run("異常系: 為替レートを取得していない利用日がある場合はエラーを返すこと", func(t *testing.T) {
+		_, err := wcu.CalcWeeklyCostWithHistoricalRates(historicalRates("ecb", map[string]float64{"2024-12-16": 150}), RateModeDaily, "JPY")
+		assert.ErrorContains(t, err, "exchange rates on 2024-12-17 not found")
+	})
+}
+
+func TestMonthlyCostUsage_CalcMonthlyCostWithHistoricalRates(t *testing.T) {
+	mcu := &MonthlyCostUsage{
+		LastMonthCost:         40,
+		MonthBeforeLastCost:   40,
+		PercentageChange:      100,
+		TopServices:           []ServiceCost{{ServiceName: "Amazon EC2", Cost: 60}},
+		Metric:                CostMetricUnblended,
+		Currency:              defaultCostCurrency,
+		LastMonthPeriod:       Period{Start: "2024-11-01", End: "2024-11-03"},
+		MonthBeforeLastPeriod: Period{Start: "2024-10-30", End: "2024-11-01"},
+		DailyCosts: MonthlyDailyCosts{
+			LastMonth:       []DailyCost{{Date: "2024-11-01", Cost: 10}, {Date: "2024-11-02", Cost: 30}},
+			MonthBeforeLast: []DailyCost{{Date: "2024-10-30", Cost: 10}, {Date: "2024-10-31", Cost: 30}},
+		},
+	}
+	rates := historicalRates("openexchangerates", map[string]float64{
+		"2024-10-30": 100, "2024-10-31": 110,
+		"2024-11-01": 150, "2024-11-02": 160,
+	})
+
+	tests := []struct {
+		name                    string
+		mode                    RateMode
+		wantLastMonthCost       float64
+		wantMonthBeforeLastCost float64
+		wantTopServiceCost      float64
+		wantRate                float64
+		wantMonthBeforeLastRate float64
+	}{
+		{
+			name:                    "正常系: 利用日ごとの為替レートで変換し、利用コストで加重平均した実効レートを月ごとに保持すること",
+			mode:                    RateModeDaily,
+			wantLastMonthCost:       6300, // 10 * 150 + 30 * 160
+			wantMonthBeforeLastCost: 4300, // 10 * 100 + 30 * 110
+			wantTopServiceCost:      9450, // 60 * 157.5
+			wantRate:                157.5,
+			wantMonthBeforeLastRate: 107.5,
+		},
+		{
+			name:                    "正常系: 月ごとの為替レートの平均で変換すること",
+			mode:                    RateModeAverage,
+			wantLastMonthCost:       6200, // 40 * 155
+			wantMonthBeforeLastCost: 4200, // 40 * 105
+			wantTopServiceCost:      9300,
+			wantRate:                155,
+			wantMonthBeforeLastRate: 105,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jpy, err := mcu.CalcMonthlyCostWithHistoricalRates(rates, tt.mode, "JPY")
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.wantLastMonthCost, jpy.LastMonthCost)
+			assert.Equal(t, tt.wantMonthBeforeLastCost, jpy.MonthBeforeLastCost)
+			assert.Equal(t, tt.wantLastMonthCost-tt.wantMonthBeforeLastCost, jpy.CostDifference)
+			assert.InDelta(t, tt.wantLastMonthCost/tt.wantMonthBeforeLastCost*100, jpy.PercentageChange, 1e-9)
+			assert.Equal(t, tt.wantTopServiceCost, jpy.TopServices[0].Cost)
+			assert.Equal(t, tt.wantRate, jpy.ExchangeRate.Rate)
+			assert.Equal(t, tt.mode.String(), jpy.ExchangeRate.Basis)
+			assert.Equal(t, tt.wantMonthBeforeLastRate, jpy.MonthBeforeLastExchangeRate.Rate)
+		})
+	}
+
+	t.Run("正常系: 実行時点の最新の為替レートで両月を変換すること", func(t *testing.T) {
+		jpy, err := mcu.CalcMonthlyCost(&exchange_rates.ExchangeRatesResponse{Base: "USD", Rates: map[string]float64{"JPY": 150}}, "JPY")
+		assert.NoError(t, err)
+
+		assert.Equal(t, 6000.0, jpy.LastMonthCost)
+		assert.Equal(t, 6000.0, jpy.MonthBeforeLastCost)
+		assert.InDelta(t, 100, jpy.PercentageChange, 1e-9)
+		assert.Equal(t, 9000.0, jpy.TopServices[0].Cost)
+	})
+
+	t.Run("異常系: 日ごとの利用コストがない場合はエラーを返すこと", func(t *testing.T) {
+		_, err := (&MonthlyCostUsage{LastMonthPeriod: mcu.LastMonthPeriod}).CalcMonthlyCostWithHistoricalRates(rates, RateModeDaily, "JPY")
+		assert.ErrorContains(t, err, "no daily costs in the period")
+	})
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	service "github.com/tamaco489/cost_explorer/batch/internal/service"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +41,21 @@ func (m *MockIDailyCostExplorerClient) EXPECT() *MockIDailyCostExplorerClientMoc
 	return m.recorder
 }
 
+// GetAccountCosts mocks base method.
+func (m *MockIDailyCostExplorerClient) GetAccountCosts(ctx context.Context, yesterday, startDate, endDate string) ([]service.DailyAccountCost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountCosts", ctx, yesterday, startDate, endDate)
+	ret0, _ := ret[0].([]service.DailyAccountCost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountCosts indicates an expected call of GetAccountCosts.
+func (mr *MockIDailyCostExplorerClientMockRecorder) GetAccountCosts(ctx, yesterday, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountCosts", reflect.TypeOf((*MockIDailyCostExplorerClient)(nil).GetAccountCosts), ctx, yesterday, startDate, endDate)
+}
+
 // GetActualCost mocks base method.
 func (m *MockIDailyCostExplorerClient) GetActualCost(ctx context.Context, startDate, endDate string) (float64, error) {
 	m.ctrl.T.Helper()
@@ -55,19 +71,49 @@ func (mr *MockIDailyCostExplorerClientMockRecorder) GetActualCost(ctx, startDate
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActualCost", reflect.TypeOf((*MockIDailyCostExplorerClient)(nil).GetActualCost), ctx, startDate, endDate)
 }
 
+// GetActualServiceCosts mocks base method.
+func (m *MockIDailyCostExplorerClient) GetActualServiceCosts(ctx context.Context, startDate, endDate string) ([]service.ServiceCost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActualServiceCosts", ctx, startDate, endDate)
+	ret0, _ := ret[0].([]service.ServiceCost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActualServiceCosts indicates an expected call of GetActualServiceCosts.
+func (mr *MockIDailyCostExplorerClientMockRecorder) GetActualServiceCosts(ctx, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActualServiceCosts", reflect.TypeOf((*MockIDailyCostExplorerClient)(nil).GetActualServiceCosts), ctx, startDate, endDate)
+}
+
 // GetForecastCost mocks base method.
-func (m *MockIDailyCostExplorerClient) GetForecastCost(ctx context.Context, actualCost float64, currentDay, daysInMonth int) (float64, error) {
+func (m *MockIDailyCostExplorerClient) GetForecastCost(ctx context.Context, actualCost float64, startDate, endDate string, currentDay, daysInMonth int) (service.Forecast, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetForecastCost", ctx, actualCost, currentDay, daysInMonth)
-	ret0, _ := ret[0].(float64)
+	ret := m.ctrl.Call(m, "GetForecastCost", ctx, actualCost, startDate, endDate, currentDay, daysInMonth)
+	ret0, _ := ret[0].(service.Forecast)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetForecastCost indicates an expected call of GetForecastCost.
-func (mr *MockIDailyCostExplorerClientMockRecorder) GetForecastCost(ctx, actualCost, currentDay, daysInMonth any) *gomock.Call {
+func (mr *MockIDailyCostExplorerClientMockRecorder) GetForecastCost(ctx, actualCost, startDate, endDate, currentDay, daysInMonth any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForecastCost", reflect.TypeOf((*MockIDailyCostExplorerClient)(nil).GetForecastCost), ctx, actualCost, currentDay, daysInMonth)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForecastCost", reflect.TypeOf((*MockIDailyCostExplorerClient)(nil).GetForecastCost), ctx, actualCost, startDate, endDate, currentDay, daysInMonth)
+}
+
+// GetTagCosts mocks base method.
+func (m *MockIDailyCostExplorerClient) GetTagCosts(ctx context.Context, yesterday, startDate, endDate string) ([]service.DailyTagCostTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagCosts", ctx, yesterday, startDate, endDate)
+	ret0, _ := ret[0].([]service.DailyTagCostTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagCosts indicates an expected call of GetTagCosts.
+func (mr *MockIDailyCostExplorerClientMockRecorder) GetTagCosts(ctx, yesterday, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagCosts", reflect.TypeOf((*MockIDailyCostExplorerClient)(nil).GetTagCosts), ctx, yesterday, startDate, endDate)
 }
 
 // GetYesterdayCost mocks base method.
@@ -84,3 +130,18 @@ func (mr *MockIDailyCostExplorerClientMockRecorder) GetYesterdayCost(ctx, yester
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetYesterdayCost", reflect.TypeOf((*MockIDailyCostExplorerClient)(nil).GetYesterdayCost), ctx, yesterday, endDate)
 }
+
+// GetYesterdayServiceCosts mocks base method.
+func (m *MockIDailyCostExplorerClient) GetYesterdayServiceCosts(ctx context.Context, yesterday, endDate string) ([]service.ServiceCost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetYesterdayServiceCosts", ctx, yesterday, endDate)
+	ret0, _ := ret[0].([]service.ServiceCost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetYesterdayServiceCosts indicates an expected call of GetYesterdayServiceCosts.
+func (mr *MockIDailyCostExplorerClientMockRecorder) GetYesterdayServiceCosts(ctx, yesterday, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetYesterdayServiceCosts", reflect.TypeOf((*MockIDailyCostExplorerClient)(nil).GetYesterdayServiceCosts), ctx, yesterday, endDate)
+}
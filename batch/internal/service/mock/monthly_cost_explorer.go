@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/monthly_cost_explorer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/service/monthly_cost_explorer.go -destination=./internal/service/mock/monthly_cost_explorer.go -package=service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	service "github.com/tamaco489/cost_explorer/batch/internal/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIMonthlyCostExplorerClient is a mock of IMonthlyCostExplorerClient interface.
+type MockIMonthlyCostExplorerClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMonthlyCostExplorerClientMockRecorder
+	isgomock struct{}
+}
+
+// MockIMonthlyCostExplorerClientMockRecorder is the mock recorder for MockIMonthlyCostExplorerClient.
+type MockIMonthlyCostExplorerClientMockRecorder struct {
+	mock *MockIMonthlyCostExplorerClient
+}
+
+// NewMockIMonthlyCostExplorerClient creates a new mock instance.
+func NewMockIMonthlyCostExplorerClient(ctrl *gomock.Controller) *MockIMonthlyCostExplorerClient {
+	mock := &MockIMonthlyCostExplorerClient{ctrl: ctrl}
+	mock.recorder = &MockIMonthlyCostExplorerClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMonthlyCostExplorerClient) EXPECT() *MockIMonthlyCostExplorerClientMockRecorder {
+	return m.recorder
+}
+
+// CalcPercentageChange mocks base method.
+func (m *MockIMonthlyCostExplorerClient) CalcPercentageChange(ctx context.Context, lastMonthCost, monthBeforeLastCost float64) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalcPercentageChange", ctx, lastMonthCost, monthBeforeLastCost)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CalcPercentageChange indicates an expected call of CalcPercentageChange.
+func (mr *MockIMonthlyCostExplorerClientMockRecorder) CalcPercentageChange(ctx, lastMonthCost, monthBeforeLastCost any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcPercentageChange", reflect.TypeOf((*MockIMonthlyCostExplorerClient)(nil).CalcPercentageChange), ctx, lastMonthCost, monthBeforeLastCost)
+}
+
+// GetLastMonthCost mocks base method.
+func (m *MockIMonthlyCostExplorerClient) GetLastMonthCost(ctx context.Context, lastMonthStartDate, lastMonthEndDate string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastMonthCost", ctx, lastMonthStartDate, lastMonthEndDate)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastMonthCost indicates an expected call of GetLastMonthCost.
+func (mr *MockIMonthlyCostExplorerClientMockRecorder) GetLastMonthCost(ctx, lastMonthStartDate, lastMonthEndDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastMonthCost", reflect.TypeOf((*MockIMonthlyCostExplorerClient)(nil).GetLastMonthCost), ctx, lastMonthStartDate, lastMonthEndDate)
+}
+
+// GetMonthBeforeLastCost mocks base method.
+func (m *MockIMonthlyCostExplorerClient) GetMonthBeforeLastCost(ctx context.Context, monthBeforeLastStartDate, monthBeforeLastEndDate string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonthBeforeLastCost", ctx, monthBeforeLastStartDate, monthBeforeLastEndDate)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonthBeforeLastCost indicates an expected call of GetMonthBeforeLastCost.
+func (mr *MockIMonthlyCostExplorerClientMockRecorder) GetMonthBeforeLastCost(ctx, monthBeforeLastStartDate, monthBeforeLastEndDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonthBeforeLastCost", reflect.TypeOf((*MockIMonthlyCostExplorerClient)(nil).GetMonthBeforeLastCost), ctx, monthBeforeLastStartDate, monthBeforeLastEndDate)
+}
+
+// GetMonthlyDailyCosts mocks base method.
+func (m *MockIMonthlyCostExplorerClient) GetMonthlyDailyCosts(ctx context.Context, lastMonthStartDate, lastMonthEndDate, monthBeforeLastStartDate, monthBeforeLastEndDate string) (service.MonthlyDailyCosts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMonthlyDailyCosts", ctx, lastMonthStartDate, lastMonthEndDate, monthBeforeLastStartDate, monthBeforeLastEndDate)
+	ret0, _ := ret[0].(service.MonthlyDailyCosts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMonthlyDailyCosts indicates an expected call of GetMonthlyDailyCosts.
+func (mr *MockIMonthlyCostExplorerClientMockRecorder) GetMonthlyDailyCosts(ctx, lastMonthStartDate, lastMonthEndDate, monthBeforeLastStartDate, monthBeforeLastEndDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMonthlyDailyCosts", reflect.TypeOf((*MockIMonthlyCostExplorerClient)(nil).GetMonthlyDailyCosts), ctx, lastMonthStartDate, lastMonthEndDate, monthBeforeLastStartDate, monthBeforeLastEndDate)
+}
+
+// GetTopServiceCosts mocks base method.
+func (m *MockIMonthlyCostExplorerClient) GetTopServiceCosts(ctx context.Context, startDate, endDate string) ([]service.ServiceCost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopServiceCosts", ctx, startDate, endDate)
+	ret0, _ := ret[0].([]service.ServiceCost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopServiceCosts indicates an expected call of GetTopServiceCosts.
+func (mr *MockIMonthlyCostExplorerClientMockRecorder) GetTopServiceCosts(ctx, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopServiceCosts", reflect.TypeOf((*MockIMonthlyCostExplorerClient)(nil).GetTopServiceCosts), ctx, startDate, endDate)
+}
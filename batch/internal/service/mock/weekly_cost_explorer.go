@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	service "github.com/tamaco489/cost_explorer/batch/internal/service"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -55,32 +56,47 @@ func (mr *MockIWeeklyCostExplorerClientMockRecorder) CalcPercentageChange(ctx, l
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalcPercentageChange", reflect.TypeOf((*MockIWeeklyCostExplorerClient)(nil).CalcPercentageChange), ctx, lastWeekCost, weekBeforeLastCost)
 }
 
-// GetLastWeekCost mocks base method.
-func (m *MockIWeeklyCostExplorerClient) GetLastWeekCost(ctx context.Context, lastWeekStartDate, lastWeekEndDate string) (float64, error) {
+// GetAccountCosts mocks base method.
+func (m *MockIWeeklyCostExplorerClient) GetAccountCosts(ctx context.Context, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate string) ([]service.WeeklyAccountCost, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLastWeekCost", ctx, lastWeekStartDate, lastWeekEndDate)
-	ret0, _ := ret[0].(float64)
+	ret := m.ctrl.Call(m, "GetAccountCosts", ctx, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate)
+	ret0, _ := ret[0].([]service.WeeklyAccountCost)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLastWeekCost indicates an expected call of GetLastWeekCost.
-func (mr *MockIWeeklyCostExplorerClientMockRecorder) GetLastWeekCost(ctx, lastWeekStartDate, lastWeekEndDate any) *gomock.Call {
+// GetAccountCosts indicates an expected call of GetAccountCosts.
+func (mr *MockIWeeklyCostExplorerClientMockRecorder) GetAccountCosts(ctx, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastWeekCost", reflect.TypeOf((*MockIWeeklyCostExplorerClient)(nil).GetLastWeekCost), ctx, lastWeekStartDate, lastWeekEndDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountCosts", reflect.TypeOf((*MockIWeeklyCostExplorerClient)(nil).GetAccountCosts), ctx, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate)
 }
 
-// GetWeekBeforeLastCost mocks base method.
-func (m *MockIWeeklyCostExplorerClient) GetWeekBeforeLastCost(ctx context.Context, weekBeforeLastStartDate, weekBeforeLastEndDate string) (float64, error) {
+// GetTagCosts mocks base method.
+func (m *MockIWeeklyCostExplorerClient) GetTagCosts(ctx context.Context, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate string) ([]service.WeeklyTagCostTable, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWeekBeforeLastCost", ctx, weekBeforeLastStartDate, weekBeforeLastEndDate)
-	ret0, _ := ret[0].(float64)
+	ret := m.ctrl.Call(m, "GetTagCosts", ctx, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate)
+	ret0, _ := ret[0].([]service.WeeklyTagCostTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagCosts indicates an expected call of GetTagCosts.
+func (mr *MockIWeeklyCostExplorerClientMockRecorder) GetTagCosts(ctx, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagCosts", reflect.TypeOf((*MockIWeeklyCostExplorerClient)(nil).GetTagCosts), ctx, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate)
+}
+
+// GetWeeklyCosts mocks base method.
+func (m *MockIWeeklyCostExplorerClient) GetWeeklyCosts(ctx context.Context, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate string) (service.WeeklyCosts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWeeklyCosts", ctx, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate)
+	ret0, _ := ret[0].(service.WeeklyCosts)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetWeekBeforeLastCost indicates an expected call of GetWeekBeforeLastCost.
-func (mr *MockIWeeklyCostExplorerClientMockRecorder) GetWeekBeforeLastCost(ctx, weekBeforeLastStartDate, weekBeforeLastEndDate any) *gomock.Call {
+// GetWeeklyCosts indicates an expected call of GetWeeklyCosts.
+func (mr *MockIWeeklyCostExplorerClientMockRecorder) GetWeeklyCosts(ctx, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWeekBeforeLastCost", reflect.TypeOf((*MockIWeeklyCostExplorerClient)(nil).GetWeekBeforeLastCost), ctx, weekBeforeLastStartDate, weekBeforeLastEndDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWeeklyCosts", reflect.TypeOf((*MockIWeeklyCostExplorerClient)(nil).GetWeeklyCosts), ctx, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate)
 }
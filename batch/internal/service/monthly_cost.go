@@ -0,0 +1,44 @@
+package service
+
+import (
+	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
+)
+
+// MonthlyDailyCosts: 先月と先々月の日ごとの利用コスト
+type MonthlyDailyCosts struct {
+	LastMonth       []DailyCost // 先月の日ごとの利用コスト (日付の昇順)
+	MonthBeforeLast []DailyCost // 先々月の日ごとの利用コスト (日付の昇順)
+}
+
+// LastMonthTotal: 先月の利用コストの合計を算出
+func (mc MonthlyDailyCosts) LastMonthTotal() float64 {
+	return sumDailyCosts(mc.LastMonth)
+}
+
+// MonthBeforeLastTotal: 先々月の利用コストの合計を算出
+func (mc MonthlyDailyCosts) MonthBeforeLastTotal() float64 {
+	return sumDailyCosts(mc.MonthBeforeLast)
+}
+
+// splitMonthlyDailyCosts: 先々月から先月までを日単位で集計したレスポンスを、日付を基に先月と先々月の利用コストに振り分ける
+func splitMonthlyDailyCosts(output *cost_explorer.GetCostAndUsageOutput, metric, lastMonthStartDate, lastMonthEndDate, monthBeforeLastStartDate, monthBeforeLastEndDate string) (MonthlyDailyCosts, error) {
+	costs, err := sumDailyTotals(output, metric)
+	if err != nil {
+		return MonthlyDailyCosts{}, err
+	}
+
+	lastMonth, err := dailyCostSeries(costs, lastMonthStartDate, lastMonthEndDate)
+	if err != nil {
+		return MonthlyDailyCosts{}, err
+	}
+
+	monthBeforeLast, err := dailyCostSeries(costs, monthBeforeLastStartDate, monthBeforeLastEndDate)
+	if err != nil {
+		return MonthlyDailyCosts{}, err
+	}
+
+	return MonthlyDailyCosts{
+		LastMonth:       lastMonth,
+		MonthBeforeLast: monthBeforeLast,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+
+	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+)
+
+type IMonthlyCostExplorerClient interface {
+	GetLastMonthCost(ctx context.Context, lastMonthStartDate, lastMonthEndDate string) (float64, error)
+	GetMonthBeforeLastCost(ctx context.Context, monthBeforeLastStartDate, monthBeforeLastEndDate string) (float64, error)
+	GetTopServiceCosts(ctx context.Context, startDate, endDate string) ([]ServiceCost, error)
+	GetMonthlyDailyCosts(ctx context.Context, lastMonthStartDate, lastMonthEndDate, monthBeforeLastStartDate, monthBeforeLastEndDate string) (MonthlyDailyCosts, error)
+	CalcPercentageChange(ctx context.Context, lastMonthCost, monthBeforeLastCost float64) (float64, error)
+}
+
+var _ IMonthlyCostExplorerClient = (*MonthlyCostExplorerService)(nil)
+
+type MonthlyCostExplorerService struct {
+	query *CostQuery
+}
+
+func NewMonthlyCostExplorerService(query *CostQuery) *MonthlyCostExplorerService {
+	return &MonthlyCostExplorerService{query: query}
+}
+
+// GetLastMonthCost: 先月の利用コストを取得
+func (s *MonthlyCostExplorerService) GetLastMonthCost(ctx context.Context, lastMonthStartDate, lastMonthEndDate string) (float64, error) {
+	return s.query.getTotalCost(ctx, lastMonthStartDate, lastMonthEndDate, types.GranularityMonthly)
+}
+
+// GetMonthBeforeLastCost: 先々月の利用コストを取得
+func (s *MonthlyCostExplorerService) GetMonthBeforeLastCost(ctx context.Context, monthBeforeLastStartDate, monthBeforeLastEndDate string) (float64, error) {
+	return s.query.getTotalCost(ctx, monthBeforeLastStartDate, monthBeforeLastEndDate, types.GranularityMonthly)
+}
+
+// GetMonthlyDailyCosts: 先々月の開始日から先月の終了日までの利用コストを1回の問い合わせで日単位に取得し、先月と先々月の日ごとの利用コストに振り分ける
+//
+// 利用日ごとの為替レートで変換する場合に使用する
+func (s *MonthlyCostExplorerService) GetMonthlyDailyCosts(ctx context.Context, lastMonthStartDate, lastMonthEndDate, monthBeforeLastStartDate, monthBeforeLastEndDate string) (MonthlyDailyCosts, error) {
+	output, err := s.query.getCostAndUsage(ctx, &cost_explorer.GetCostAndUsageInput{
+		TimePeriod: &types.DateInterval{
+			Start: &monthBeforeLastStartDate,
+			End:   &lastMonthEndDate,
+		},
+		Granularity: types.GranularityDaily,
+		Metrics:     []string{s.query.metric.String()},
+	})
+	if err != nil {
+		return MonthlyDailyCosts{}, err
+	}
+
+	return splitMonthlyDailyCosts(output, s.query.metric.String(), lastMonthStartDate, lastMonthEndDate, monthBeforeLastStartDate, monthBeforeLastEndDate)
+}
+
+// GetTopServiceCosts: 指定期間の利用コストをサービス単位で集計し、コストの高い順に上位のサービスを取得 (上位以外は "Others" にまとめる)
+func (s *MonthlyCostExplorerService) GetTopServiceCosts(ctx context.Context, startDate, endDate string) ([]ServiceCost, error) {
+	costs, err := s.query.getGroupCosts(ctx, startDate, endDate, types.GranularityMonthly, groupByService())
+	if err != nil {
+		return nil, err
+	}
+
+	return rankServiceCosts(costs, topServicesLimit), nil
+}
+
+// CalcPercentageChange: コストの増減率を算出
+//
+// 先々月のコストが0の場合 (利用開始の翌月など) は比較できないため、ジョブを失敗させずに増減率を0とする (レポートでは "-" と表示する)
+func (s *MonthlyCostExplorerService) CalcPercentageChange(ctx context.Context, lastMonthCost, monthBeforeLastCost float64) (float64, error) {
+	if monthBeforeLastCost == 0 {
+		return 0, nil
+	}
+
+	change := (lastMonthCost / monthBeforeLastCost) * 100
+	return change, nil
+}
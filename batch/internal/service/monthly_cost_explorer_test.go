@@ -0,0 +1,106 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+	service_mock "github.com/tamaco489/cost_explorer/batch/internal/service/mock"
+)
+
+func TestGetLastMonthCost(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// MockのMonthlyCostExplorerClientを生成
+	mockClient := service_mock.NewMockIMonthlyCostExplorerClient(ctrl)
+
+	// GetLastMonthCost を実行するための引数を定義
+	lastMonthStartDate := "2024-11-01"
+	lastMonthEndDate := "2024-12-01"
+
+	// GetLastMonthCost を実行した結果得られるレスポンスを定義
+	var expectedResponse float64 = 100
+
+	mockClient.EXPECT().
+		GetLastMonthCost(ctx, lastMonthStartDate, lastMonthEndDate).
+		Return(expectedResponse, nil).
+		Times(1)
+
+	response, err := mockClient.GetLastMonthCost(ctx, lastMonthStartDate, lastMonthEndDate)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResponse, response)
+}
+
+func TestNewMonthlyReportDateFormatter(t *testing.T) {
+	tests := map[string]struct {
+		execTime time.Time
+		expected service.MonthlyReportDateFormatter
+	}{
+		"月の途中で実行した場合は先月と先々月の期間を返す": {
+			execTime: time.Date(2024, 12, 3, 9, 15, 0, 0, time.UTC),
+			expected: service.MonthlyReportDateFormatter{
+				LastMonth:                "2024-11",
+				LastMonthStartDate:       "2024-11-01",
+				LastMonthEndDate:         "2024-12-01",
+				MonthBeforeLast:          "2024-10",
+				MonthBeforeLastStartDate: "2024-10-01",
+				MonthBeforeLastEndDate:   "2024-11-01",
+			},
+		},
+		"年初に実行した場合は前年の期間を返す": {
+			execTime: time.Date(2025, 1, 31, 9, 15, 0, 0, time.UTC),
+			expected: service.MonthlyReportDateFormatter{
+				LastMonth:                "2024-12",
+				LastMonthStartDate:       "2024-12-01",
+				LastMonthEndDate:         "2025-01-01",
+				MonthBeforeLast:          "2024-11",
+				MonthBeforeLastStartDate: "2024-11-01",
+				MonthBeforeLastEndDate:   "2024-12-01",
+			},
+		},
+	}
+
+	s := service.NewMonthlyCostExplorerService(nil)
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, s.NewMonthlyReportDateFormatter(tt.execTime))
+		})
+	}
+}
+
+func TestCalcMonthlyPercentageChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		lastCost float64
+		baseCost float64
+		want     float64
+	}{
+		{
+			name:     "正常系: 先々月のコストに対する先月のコストの割合を算出すること",
+			lastCost: 150,
+			baseCost: 100,
+			want:     150,
+		},
+		{
+			name:     "正常系: 先々月のコストが0の場合はエラーを返さずに0を返すこと",
+			lastCost: 150,
+			baseCost: 0,
+			want:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change, err := (&service.MonthlyCostExplorerService{}).CalcPercentageChange(context.Background(), tt.lastCost, tt.baseCost)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, change)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/slack"
+)
+
+// MonthlyCostUsage: 月次レポートに必要な要素を含む構造体
+type MonthlyCostUsage struct {
+	LastMonth           string
+	MonthBeforeLast     string
+	LastMonthCost       float64
+	MonthBeforeLastCost float64
+	CostDifference      float64
+	PercentageChange    float64
+	TopServices         []ServiceCost
+
+	Metric       CostMetric   // 集計した利用コストの指標
+	Currency     string       // 利用コストの通貨
+	ExchangeRate ExchangeRate // 利用コストの変換に使用した為替レート (変換前は空)
+
+	LastMonthPeriod       Period // 先月の集計期間
+	MonthBeforeLastPeriod Period // 先々月の集計期間
+
+	MonthBeforeLastExchangeRate ExchangeRate // 先々月の利用コストの変換に使用した為替レート (先月と同じ為替レートを適用した場合は空)
+
+	DailyCosts MonthlyDailyCosts // 先月と先々月の日ごとの利用コスト (利用日ごとの為替レートで変換する場合のみ)
+
+	Conversions map[string]*MonthlyCostUsage // 他の表示する通貨に変換した利用コスト (通貨コードごと、Currency の利用コストは含まない)
+}
+
+// NewMonthlyCostUsage: MonthlyCostUsage のコンストラクタ
+func (mcs *MonthlyCostExplorerService) NewMonthlyCostUsage(fd MonthlyReportDateFormatter, lastMonthCost, monthBeforeLastCost, percentageChange float64, topServices []ServiceCost, dailyCosts MonthlyDailyCosts) *MonthlyCostUsage {
+	return &MonthlyCostUsage{
+		LastMonth:           fd.LastMonth,
+		MonthBeforeLast:     fd.MonthBeforeLast,
+		LastMonthCost:       lastMonthCost,
+		MonthBeforeLastCost: monthBeforeLastCost,
+		CostDifference:      lastMonthCost - monthBeforeLastCost,
+		PercentageChange:    percentageChange,
+		TopServices:         topServices,
+		Metric:              mcs.query.Metric(),
+
+		Currency:              mcs.query.Currency(),
+		LastMonthPeriod:       Period{Start: fd.LastMonthStartDate, End: fd.LastMonthEndDate},
+		MonthBeforeLastPeriod: Period{Start: fd.MonthBeforeLastStartDate, End: fd.MonthBeforeLastEndDate},
+
+		DailyCosts: dailyCosts,
+	}
+}
+
+// exchangeRates: 利用コストの変換に使用した為替レートを取得 (集計期間ごとに異なる為替レートを適用した場合は先月、先々月の順)
+func (mcu *MonthlyCostUsage) exchangeRates() []ExchangeRate {
+	if mcu.MonthBeforeLastExchangeRate.Currency == "" {
+		return []ExchangeRate{mcu.ExchangeRate}
+	}
+	return []ExchangeRate{mcu.ExchangeRate, mcu.MonthBeforeLastExchangeRate}
+}
+
+// GenMonthlySlackMessage: 月次利用コストレポートのメッセージを生成
+func (mcu *MonthlyCostUsage) GenMonthlySlackMessage() slack.Attachment {
+	// 先々月のコストが0の場合は比較できないため "-" と表示する
+	change := "-"
+	if mcu.MonthBeforeLastCost != 0 {
+		change = fmt.Sprintf("%.2f %%", mcu.PercentageChange)
+	}
+
+	return slack.Attachment{
+		Pretext: fmt.Sprintf(`
+• 先月 (%s) の利用コスト: %s
+• 先々月 (%s) の利用コスト: %s
+• 先々月からの増減額: %s
+• 先々月のコストに対する先月のコスト: %s
+• 先月の利用コスト上位サービス:
+%s`,
+			mcu.LastMonth, formatCost(mcu.Currency, mcu.LastMonthCost),
+			mcu.MonthBeforeLast, formatCost(mcu.Currency, mcu.MonthBeforeLastCost),
+			formatSignedCost(mcu.Currency, mcu.CostDifference), change,
+			formatServiceCostRanking(mcu.Currency, mcu.TopServices),
+		),
+		Footer: reportFooter(mcu.Metric, mcu.exchangeRates()...),
+	}
+}
+
+// GenMonthlySlackBlocks: 月次利用コストレポートを Block Kit のメッセージとして生成
+//
+// 先々月のコストに対する先月のコストが閾値（%）以上の場合は、赤色のバーで強調表示する
+func (mcu *MonthlyCostUsage) GenMonthlySlackBlocks(alertThreshold float64) slack.BlockMessage {
+	message := slack.BlockMessage{
+		Blocks: []slack.Block{
+			slack.HeaderBlock(fmt.Sprintf("AWS 月次利用コストレポート (%s)", mcu.LastMonth)),
+			slack.FieldsBlock(
+				slack.Field{Label: fmt.Sprintf("先月 (%s) の利用コスト", mcu.LastMonth), Value: formatCost(mcu.Currency, mcu.LastMonthCost)},
+				slack.Field{Label: fmt.Sprintf("先々月 (%s) の利用コスト", mcu.MonthBeforeLast), Value: formatCost(mcu.Currency, mcu.MonthBeforeLastCost)},
+				slack.Field{Label: "先々月からの増減額", Value: formatSignedCost(mcu.Currency, mcu.CostDifference)},
+				slack.Field{Label: "先々月のコストに対する先月のコスト", Value: formatChange(mcu.PercentageChange, mcu.MonthBeforeLastCost)},
+			),
+			slack.ContextBlock(reportFooter(mcu.Metric, mcu.exchangeRates()...)),
+		},
+		Details: [][]slack.Block{
+			{slack.TableBlock("先月の利用コスト上位サービス", serviceCostTable(mcu.Currency, mcu.TopServices))},
+		},
+	}
+
+	if exceedsThreshold(mcu.PercentageChange, mcu.MonthBeforeLastCost, alertThreshold) {
+		message.Highlights = append(message.Highlights, thresholdHighlight(
+			fmt.Sprintf("先々月のコストに対する先月のコストが %.0f %% を超えています", alertThreshold),
+			[]string{fmt.Sprintf("全体: %s → %s (%.2f %%)", formatCost(mcu.Currency, mcu.MonthBeforeLastCost), formatCost(mcu.Currency, mcu.LastMonthCost), mcu.PercentageChange)},
+		))
+	}
+
+	return message
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonthlyCostExplorerService_GetMonthlyDailyCosts(t *testing.T) {
+	stub := &stubCostExplorerAPI{
+		pages: map[string]*cost_explorer.GetCostAndUsageOutput{
+			"": {
+				ResultsByTime: []types.ResultByTime{
+					totalResult("2024-10-30", "1"),
+					totalResult("2024-10-31", "2"),
+					totalResult("2024-11-01", "3"),
+					totalResult("2024-11-02", "4"),
+				},
+			},
+		},
+	}
+	query, err := newCostQuery(stub, CostMetricUnblended, CostFilter{})
+	assert.NoError(t, err)
+
+	ms := NewMonthlyCostExplorerService(query)
+	result, err := ms.GetMonthlyDailyCosts(context.Background(), "2024-11-01", "2024-11-04", "2024-10-30", "2024-11-01")
+	assert.NoError(t, err)
+
+	assert.Len(t, stub.inputs, 1)
+	assert.Equal(t, "2024-10-30", *stub.inputs[0].TimePeriod.Start)
+	assert.Equal(t, "2024-11-04", *stub.inputs[0].TimePeriod.End)
+	assert.Equal(t, types.GranularityDaily, stub.inputs[0].Granularity)
+
+	assert.Equal(t, []DailyCost{
+		{Date: "2024-11-01", Cost: 3},
+		{Date: "2024-11-02", Cost: 4},
+		{Date: "2024-11-03", Cost: 0},
+	}, result.LastMonth)
+	assert.Equal(t, []DailyCost{
+		{Date: "2024-10-30", Cost: 1},
+		{Date: "2024-10-31", Cost: 2},
+	}, result.MonthBeforeLast)
+	assert.Equal(t, 7.0, result.LastMonthTotal())
+	assert.Equal(t, 3.0, result.MonthBeforeLastTotal())
+}
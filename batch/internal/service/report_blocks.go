@@ -0,0 +1,145 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/slack"
+)
+
+// formatChange: 比較対象のコストに対する割合（%）を増減の絵文字付きで整形 (比較対象のコストが0の場合は "-")
+func formatChange(percentageChange, baseCost float64) string {
+	if baseCost == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f %% %s", percentageChange, slack.ChangeEmoji(percentageChange))
+}
+
+// formatChangeArrow: 比較対象のコストに対する割合（%）を増減の矢印付きで整形 (比較対象のコストが0の場合は "-")
+func formatChangeArrow(percentageChange, baseCost float64) string {
+	if baseCost == 0 {
+		return "-"
+	}
+
+	arrow := "→"
+	switch {
+	case percentageChange > 100:
+		arrow = "↗"
+	case percentageChange < 100:
+		arrow = "↘"
+	}
+	return fmt.Sprintf("%.2f %% %s", percentageChange, arrow)
+}
+
+// reportFooter: 集計した利用コストの指標と変換に使用した為替レートを、レポートのフッターとして連結
+func reportFooter(metric CostMetric, rates ...ExchangeRate) string {
+	footer := metric.footer()
+	for _, rate := range rates {
+		if f := rate.footer(); f != "" {
+			footer += " | " + f
+		}
+	}
+	return footer
+}
+
+// exceedsThreshold: 比較対象のコストに対する割合（%）が閾値以上かを判定 (閾値が0以下の場合は判定しない)
+func exceedsThreshold(percentageChange, baseCost, threshold float64) bool {
+	return threshold > 0 && baseCost != 0 && percentageChange >= threshold
+}
+
+// serviceCostTable: サービスごとの利用コストを順位付きの表に変換
+func serviceCostTable(currency string, serviceCosts []ServiceCost) slack.Table {
+	rows := make([][]string, 0, len(serviceCosts))
+	for i, sc := range serviceCosts {
+		label := fmt.Sprintf("%d. %s", i+1, sc.ServiceName)
+		if sc.ServiceName == OthersServiceName {
+			label = fmt.Sprintf("-  %s", sc.ServiceName)
+		}
+		rows = append(rows, []string{label, formatAmount(currency, sc.Cost)})
+	}
+	return slack.Table{Header: []string{"サービス", costHeader("", currency)}, Rows: rows}
+}
+
+// dailyAccountCostTable: アカウントごとの昨日と今月の利用コストを表に変換
+func dailyAccountCostTable(currency string, accountCosts []DailyAccountCost) slack.Table {
+	rows := make([][]string, 0, len(accountCosts))
+	for _, ac := range accountCosts {
+		rows = append(rows, []string{
+			formatAccountLabel(ac.AccountID, ac.AccountName),
+			formatAmount(currency, ac.YesterdayCost),
+			formatAmount(currency, ac.ActualCost),
+		})
+	}
+	return slack.Table{Header: []string{"アカウント", costHeader("昨日", currency), costHeader("今月", currency)}, Rows: rows}
+}
+
+// weeklyAccountCostTable: アカウントごとの先週と先々週の利用コストを表に変換
+func weeklyAccountCostTable(currency string, accountCosts []WeeklyAccountCost) slack.Table {
+	rows := make([][]string, 0, len(accountCosts))
+	for _, ac := range accountCosts {
+		rows = append(rows, []string{
+			formatAccountLabel(ac.AccountID, ac.AccountName),
+			formatAmount(currency, ac.LastWeekCost),
+			formatAmount(currency, ac.WeekBeforeLastCost),
+			formatTableChange(ac.PercentageChange, ac.WeekBeforeLastCost),
+		})
+	}
+	return slack.Table{Header: []string{"アカウント", costHeader("先週", currency), costHeader("先々週", currency), "増減 (%)"}, Rows: rows}
+}
+
+// dailyTagCostTable: タグの値ごとの昨日と今月の利用コストを表に変換
+func dailyTagCostTable(currency string, table DailyTagCostTable) slack.Table {
+	rows := make([][]string, 0, len(table.Costs))
+	for _, tc := range table.Costs {
+		rows = append(rows, []string{tc.TagValue, formatAmount(currency, tc.YesterdayCost), formatAmount(currency, tc.ActualCost)})
+	}
+	return slack.Table{Header: []string{table.TagKey, costHeader("昨日", currency), costHeader("今月", currency)}, Rows: rows}
+}
+
+// weeklyTagCostTable: タグの値ごとの先週と先々週の利用コストを表に変換
+func weeklyTagCostTable(currency string, table WeeklyTagCostTable) slack.Table {
+	rows := make([][]string, 0, len(table.Costs))
+	for _, tc := range table.Costs {
+		rows = append(rows, []string{
+			tc.TagValue,
+			formatAmount(currency, tc.LastWeekCost),
+			formatAmount(currency, tc.WeekBeforeLastCost),
+			formatTableChange(tc.PercentageChange, tc.WeekBeforeLastCost),
+		})
+	}
+	return slack.Table{Header: []string{table.TagKey, costHeader("先週", currency), costHeader("先々週", currency), "増減 (%)"}, Rows: rows}
+}
+
+// weeklyDailyCostTable: 先週と先々週の同じ曜日の利用コストを表に変換
+func weeklyDailyCostTable(currency string, dailyCosts []WeeklyDailyCost) slack.Table {
+	rows := make([][]string, 0, len(dailyCosts))
+	for _, dc := range dailyCosts {
+		rows = append(rows, []string{
+			shortDate(dc.LastWeekDate),
+			formatAmount(currency, dc.LastWeekCost),
+			shortDate(dc.WeekBeforeLastDate),
+			formatAmount(currency, dc.WeekBeforeLastCost),
+			formatTableChange(dc.PercentageChange, dc.WeekBeforeLastCost),
+		})
+	}
+	return slack.Table{Header: []string{"先週", costHeader("", currency), "先々週", costHeader("", currency), "増減 (%)"}, Rows: rows}
+}
+
+// formatTableChange: 表に表示する増減率を整形 (比較対象のコストが0の場合は "-")
+func formatTableChange(percentageChange, baseCost float64) string {
+	if baseCost == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", percentageChange)
+}
+
+// thresholdHighlight: 閾値を超えた項目を赤色のバーで強調表示するブロックを生成
+func thresholdHighlight(title string, lines []string) slack.Highlight {
+	text := fmt.Sprintf(":warning: *%s*", title)
+	for _, line := range lines {
+		text += "\n• " + line
+	}
+	return slack.Highlight{
+		Color:  slack.ColorDanger,
+		Blocks: []slack.Block{slack.TextBlock(text)},
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/slack"
+)
+
+func TestWeeklyCostUsage_GenWeeklySlackBlocks(t *testing.T) {
+	wcu := &WeeklyCostUsage{
+		LastWeekCost:       150,
+		WeekBeforeLastCost: 100,
+		PercentageChange:   150,
+		Metric:             CostMetricUnblended,
+		AccountCosts: []WeeklyAccountCost{
+			{AccountID: "111111111111", AccountName: "production", LastWeekCost: 140, WeekBeforeLastCost: 70, PercentageChange: 200},
+			{AccountID: "222222222222", AccountName: "staging", LastWeekCost: 10, WeekBeforeLastCost: 30, PercentageChange: 33.33},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		alertThreshold float64
+		expectedLines  int
+	}{
+		{name: "正常系: 閾値以上の全体とアカウントを赤色のバーで強調表示すること", alertThreshold: 120, expectedLines: 2},
+		{name: "正常系: 閾値を超える項目がない場合は強調表示しないこと", alertThreshold: 300, expectedLines: 0},
+		{name: "正常系: 閾値が0の場合は強調表示しないこと", alertThreshold: 0, expectedLines: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := wcu.GenWeeklySlackBlocks(tt.alertThreshold)
+			assert.NotEmpty(t, message.Blocks)
+			assert.Len(t, wcu.exceededLines(tt.alertThreshold), tt.expectedLines)
+
+			if tt.expectedLines == 0 {
+				assert.Empty(t, message.Highlights)
+				return
+			}
+			assert.Len(t, message.Highlights, 1)
+			assert.Equal(t, slack.ColorDanger, message.Highlights[0].Color)
+		})
+	}
+}
+
+func TestFormatChange(t *testing.T) {
+	assert.Equal(t, "120.00 % :arrow_upper_right:", formatChange(120, 100))
+	assert.Equal(t, "-", formatChange(0, 0))
+}
+
+func TestReportFooter(t *testing.T) {
+	rate := ExchangeRate{BaseCurrency: "USD", Currency: "JPY", Rate: 150, Timestamp: time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC), Source: "openexchangerates"}
+	assert.Equal(t, CostMetricUnblended.footer()+" | exchange rate: 1 USD = 150.00 JPY (source: openexchangerates, 2024-12-16 00:00 UTC)", reportFooter(CostMetricUnblended, rate))
+
+	rate.Stale = true
+	assert.Contains(t, reportFooter(CostMetricUnblended, rate), "(source: openexchangerates, 2024-12-16 00:00 UTC) ⚠ stale")
+	assert.Equal(t, CostMetricUnblended.footer(), reportFooter(CostMetricUnblended, ExchangeRate{}))
+}
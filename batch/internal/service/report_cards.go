@@ -0,0 +1,126 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/slack"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/teams"
+)
+
+// GenDailyTeamsCard: 日次利用コストレポートを Teams の Adaptive Card として生成
+//
+// 昨日の利用コストが今月の1日あたりの平均利用コストに対して閾値（%）以上の場合は、警告を赤字で表示する
+func (dcu DailyCostUsage) GenDailyTeamsCard(alertThreshold float64) teams.AdaptiveCard {
+	yesterdayChange := 0.0
+	if dcu.AverageDailyCost != 0 {
+		yesterdayChange = dcu.YesterdayCost / dcu.AverageDailyCost * 100
+	}
+
+	body := []teams.Element{
+		teams.TextBlock{Text: "AWS 日次利用コストレポート", Size: "Large", Weight: "Bolder", Wrap: true},
+		teams.FactSet{Facts: []teams.Fact{
+			{Title: "昨日の利用コスト", Value: formatCost(dcu.Currency, dcu.YesterdayCost)},
+			{Title: "今月の1日あたりの平均に対する昨日のコスト", Value: formatChangeArrow(yesterdayChange, dcu.AverageDailyCost)},
+			{Title: "本日時点での今月の利用コスト", Value: formatCost(dcu.Currency, dcu.ActualCost)},
+			{Title: "今月の利用コストの予測値", Value: fmt.Sprintf("%s %s", formatCost(dcu.Currency, dcu.ForecastCost), dcu.formatForecastDetail())},
+		}},
+	}
+
+	if exceedsThreshold(yesterdayChange, dcu.AverageDailyCost, alertThreshold) {
+		body = append(body, teamsAlert(
+			fmt.Sprintf("昨日の利用コストが今月の1日あたりの平均の %.0f %% を超えています (%.2f %%)", alertThreshold, yesterdayChange),
+		))
+	}
+
+	body = append(body,
+		teamsTable("サービス別の利用コスト (昨日)", serviceCostTable(dcu.Currency, dcu.YesterdayServiceCosts)),
+		teamsTable("サービス別の利用コスト (今月)", serviceCostTable(dcu.Currency, dcu.ActualServiceCosts)),
+	)
+
+	// 連結アカウントが複数存在する場合のみ、アカウント別の内訳を表示
+	if len(dcu.AccountCosts) > 1 {
+		body = append(body, teamsTable("アカウント別の利用コスト", dailyAccountCostTable(dcu.Currency, dcu.AccountCosts)))
+	}
+
+	for _, table := range dcu.TagCosts {
+		body = append(body, teamsTable(fmt.Sprintf("タグ別の利用コスト (%s)", table.TagKey), dailyTagCostTable(dcu.Currency, table)))
+	}
+
+	return teams.AdaptiveCard{Body: append(body, teamsFooter(dcu.Metric, dcu.ExchangeRate))}
+}
+
+// GenWeeklyTeamsCard: 週次利用コストレポートを Teams の Adaptive Card として生成
+//
+// 先々週のコストに対する先週のコストが閾値（%）以上の項目がある場合は、警告を赤字で表示する
+func (wcu *WeeklyCostUsage) GenWeeklyTeamsCard(alertThreshold float64) teams.AdaptiveCard {
+	body := []teams.Element{
+		teams.TextBlock{Text: "AWS 週次利用コストレポート", Size: "Large", Weight: "Bolder", Wrap: true},
+		teams.FactSet{Facts: []teams.Fact{
+			{Title: "先週の利用コスト", Value: formatCost(wcu.Currency, wcu.LastWeekCost)},
+			{Title: "先々週の利用コスト", Value: formatCost(wcu.Currency, wcu.WeekBeforeLastCost)},
+			{Title: "先々週のコストに対する先週のコスト", Value: formatChangeArrow(wcu.PercentageChange, wcu.WeekBeforeLastCost)},
+		}},
+	}
+
+	for _, line := range wcu.exceededLines(alertThreshold) {
+		body = append(body, teamsAlert(line))
+	}
+
+	if len(wcu.DailyCosts) > 0 {
+		body = append(body, teamsTable("日別の利用コスト", weeklyDailyCostTable(wcu.Currency, wcu.DailyCosts)))
+	}
+
+	// 連結アカウントが複数存在する場合のみ、アカウント別の内訳を表示
+	if len(wcu.AccountCosts) > 1 {
+		body = append(body, teamsTable("アカウント別の利用コスト", weeklyAccountCostTable(wcu.Currency, wcu.AccountCosts)))
+	}
+
+	for _, table := range wcu.TagCosts {
+		body = append(body, teamsTable(fmt.Sprintf("タグ別の利用コスト (%s)", table.TagKey), weeklyTagCostTable(wcu.Currency, table)))
+	}
+
+	return teams.AdaptiveCard{Body: append(body, teamsFooter(wcu.Metric, wcu.exchangeRates()...))}
+}
+
+// GenMonthlyTeamsCard: 月次利用コストレポートを Teams の Adaptive Card として生成
+//
+// 先々月のコストに対する先月のコストが閾値（%）以上の場合は、警告を赤字で表示する
+func (mcu *MonthlyCostUsage) GenMonthlyTeamsCard(alertThreshold float64) teams.AdaptiveCard {
+	body := []teams.Element{
+		teams.TextBlock{Text: fmt.Sprintf("AWS 月次利用コストレポート (%s)", mcu.LastMonth), Size: "Large", Weight: "Bolder", Wrap: true},
+		teams.FactSet{Facts: []teams.Fact{
+			{Title: fmt.Sprintf("先月 (%s) の利用コスト", mcu.LastMonth), Value: formatCost(mcu.Currency, mcu.LastMonthCost)},
+			{Title: fmt.Sprintf("先々月 (%s) の利用コスト", mcu.MonthBeforeLast), Value: formatCost(mcu.Currency, mcu.MonthBeforeLastCost)},
+			{Title: "先々月からの増減額", Value: formatSignedCost(mcu.Currency, mcu.CostDifference)},
+			{Title: "先々月のコストに対する先月のコスト", Value: formatChangeArrow(mcu.PercentageChange, mcu.MonthBeforeLastCost)},
+		}},
+	}
+
+	if exceedsThreshold(mcu.PercentageChange, mcu.MonthBeforeLastCost, alertThreshold) {
+		body = append(body, teamsAlert(
+			fmt.Sprintf("先々月のコストに対する先月のコストが %.0f %% を超えています (%.2f %%)", alertThreshold, mcu.PercentageChange),
+		))
+	}
+
+	body = append(body,
+		teamsTable("先月の利用コスト上位サービス", serviceCostTable(mcu.Currency, mcu.TopServices)),
+		teamsFooter(mcu.Metric, mcu.exchangeRates()...),
+	)
+
+	return teams.AdaptiveCard{Body: body}
+}
+
+// teamsTable: Slack 向けに生成した表を Teams の Table 要素に変換
+func teamsTable(title string, table slack.Table) teams.Table {
+	return teams.Table{Title: title, Header: table.Header, Rows: table.Rows}
+}
+
+// teamsAlert: 閾値を超えた項目を警告として赤字で表示する要素を生成
+func teamsAlert(text string) teams.TextBlock {
+	return teams.TextBlock{Text: "⚠ " + text, Color: teams.ColorAttention, Weight: "Bolder", Wrap: true}
+}
+
+// teamsFooter: 集計した利用コストの指標と為替レートを補足情報として表示する要素を生成
+func teamsFooter(metric CostMetric, rates ...ExchangeRate) teams.TextBlock {
+	return teams.TextBlock{Text: reportFooter(metric, rates...), Size: "Small", IsSubtle: true, Wrap: true, Separator: true}
+}
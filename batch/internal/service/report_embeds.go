@@ -0,0 +1,135 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/discord"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/slack"
+)
+
+// GenDailyDiscordEmbeds: 日次利用コストレポートを Discord の Embed として生成
+//
+// バーの色は、昨日の利用コストの今月の1日あたりの平均利用コストに対する割合（%）で決定する
+func (dcu DailyCostUsage) GenDailyDiscordEmbeds(alertThreshold float64) []discord.Embed {
+	yesterdayChange := 0.0
+	if dcu.AverageDailyCost != 0 {
+		yesterdayChange = dcu.YesterdayCost / dcu.AverageDailyCost * 100
+	}
+
+	embed := discord.Embed{
+		Title: "AWS 日次利用コストレポート",
+		Color: discordColor(yesterdayChange, dcu.AverageDailyCost, alertThreshold),
+		Fields: []discord.Field{
+			{Name: "昨日の利用コスト", Value: formatCost(dcu.Currency, dcu.YesterdayCost), Inline: true},
+			{Name: "今月の1日あたりの平均に対する昨日のコスト", Value: formatChangeArrow(yesterdayChange, dcu.AverageDailyCost), Inline: true},
+			{Name: "本日時点での今月の利用コスト", Value: formatCost(dcu.Currency, dcu.ActualCost), Inline: true},
+			{Name: "今月の利用コストの予測値", Value: fmt.Sprintf("%s %s", formatCost(dcu.Currency, dcu.ForecastCost), dcu.formatForecastDetail())},
+			discordTable("サービス別の利用コスト (昨日)", serviceCostTable(dcu.Currency, dcu.YesterdayServiceCosts)),
+			discordTable("サービス別の利用コスト (今月)", serviceCostTable(dcu.Currency, dcu.ActualServiceCosts)),
+		},
+		Footer: &discord.Footer{Text: reportFooter(dcu.Metric, dcu.ExchangeRate)},
+	}
+
+	if exceedsThreshold(yesterdayChange, dcu.AverageDailyCost, alertThreshold) {
+		embed.Description = discordAlerts([]string{
+			fmt.Sprintf("昨日の利用コストが今月の1日あたりの平均の %.0f %% を超えています (%.2f %%)", alertThreshold, yesterdayChange),
+		})
+	}
+
+	// 連結アカウントが複数存在する場合のみ、アカウント別の内訳を表示
+	if len(dcu.AccountCosts) > 1 {
+		embed.Fields = append(embed.Fields, discordTable("アカウント別の利用コスト", dailyAccountCostTable(dcu.Currency, dcu.AccountCosts)))
+	}
+
+	for _, table := range dcu.TagCosts {
+		embed.Fields = append(embed.Fields, discordTable(fmt.Sprintf("タグ別の利用コスト (%s)", table.TagKey), dailyTagCostTable(dcu.Currency, table)))
+	}
+
+	return []discord.Embed{embed}
+}
+
+// GenWeeklyDiscordEmbeds: 週次利用コストレポートを Discord の Embed として生成
+//
+// バーの色は、先々週のコストに対する先週のコストの割合（%）で決定する
+func (wcu *WeeklyCostUsage) GenWeeklyDiscordEmbeds(alertThreshold float64) []discord.Embed {
+	embed := discord.Embed{
+		Title:       "AWS 週次利用コストレポート",
+		Description: discordAlerts(wcu.exceededLines(alertThreshold)),
+		Color:       discordColor(wcu.PercentageChange, wcu.WeekBeforeLastCost, alertThreshold),
+		Fields: []discord.Field{
+			{Name: "先週の利用コスト", Value: formatCost(wcu.Currency, wcu.LastWeekCost), Inline: true},
+			{Name: "先々週の利用コスト", Value: formatCost(wcu.Currency, wcu.WeekBeforeLastCost), Inline: true},
+			{Name: "先々週のコストに対する先週のコスト", Value: formatChangeArrow(wcu.PercentageChange, wcu.WeekBeforeLastCost), Inline: true},
+		},
+		Footer: &discord.Footer{Text: reportFooter(wcu.Metric, wcu.exchangeRates()...)},
+	}
+
+	if len(wcu.DailyCosts) > 0 {
+		embed.Fields = append(embed.Fields, discordTable("日別の利用コスト", weeklyDailyCostTable(wcu.Currency, wcu.DailyCosts)))
+	}
+
+	// 連結アカウントが複数存在する場合のみ、アカウント別の内訳を表示
+	if len(wcu.AccountCosts) > 1 {
+		embed.Fields = append(embed.Fields, discordTable("アカウント別の利用コスト", weeklyAccountCostTable(wcu.Currency, wcu.AccountCosts)))
+	}
+
+	for _, table := range wcu.TagCosts {
+		embed.Fields = append(embed.Fields, discordTable(fmt.Sprintf("タグ別の利用コスト (%s)", table.TagKey), weeklyTagCostTable(wcu.Currency, table)))
+	}
+
+	return []discord.Embed{embed}
+}
+
+// GenMonthlyDiscordEmbeds: 月次利用コストレポートを Discord の Embed として生成
+//
+// バーの色は、先々月のコストに対する先月のコストの割合（%）で決定する
+func (mcu *MonthlyCostUsage) GenMonthlyDiscordEmbeds(alertThreshold float64) []discord.Embed {
+	embed := discord.Embed{
+		Title: fmt.Sprintf("AWS 月次利用コストレポート (%s)", mcu.LastMonth),
+		Color: discordColor(mcu.PercentageChange, mcu.MonthBeforeLastCost, alertThreshold),
+		Fields: []discord.Field{
+			{Name: fmt.Sprintf("先月 (%s) の利用コスト", mcu.LastMonth), Value: formatCost(mcu.Currency, mcu.LastMonthCost), Inline: true},
+			{Name: fmt.Sprintf("先々月 (%s) の利用コスト", mcu.MonthBeforeLast), Value: formatCost(mcu.Currency, mcu.MonthBeforeLastCost), Inline: true},
+			{Name: "先々月からの増減額", Value: formatSignedCost(mcu.Currency, mcu.CostDifference), Inline: true},
+			{Name: "先々月のコストに対する先月のコスト", Value: formatChangeArrow(mcu.PercentageChange, mcu.MonthBeforeLastCost), Inline: true},
+			discordTable("先月の利用コスト上位サービス", serviceCostTable(mcu.Currency, mcu.TopServices)),
+		},
+		Footer: &discord.Footer{Text: reportFooter(mcu.Metric, mcu.exchangeRates()...)},
+	}
+
+	if exceedsThreshold(mcu.PercentageChange, mcu.MonthBeforeLastCost, alertThreshold) {
+		embed.Description = discordAlerts([]string{
+			fmt.Sprintf("先々月のコストに対する先月のコストが %.0f %% を超えています (%.2f %%)", alertThreshold, mcu.PercentageChange),
+		})
+	}
+
+	return []discord.Embed{embed}
+}
+
+// discordColor: 比較対象のコストに対する割合（%）から Embed のバーの色を決定
+//
+// 閾値以上の場合は赤、増加している場合 (比較対象のコストが0の場合を含む) は黄、減少または横ばいの場合は緑とする
+func discordColor(percentageChange, baseCost, threshold float64) discord.Color {
+	switch {
+	case exceedsThreshold(percentageChange, baseCost, threshold):
+		return discord.ColorDanger
+	case baseCost == 0 || percentageChange > 100:
+		return discord.ColorWarning
+	default:
+		return discord.ColorGood
+	}
+}
+
+// discordTable: Slack 向けに生成した表を Discord のコードブロックの項目に変換
+func discordTable(title string, table slack.Table) discord.Field {
+	return discord.CodeBlockField(title, table.String())
+}
+
+// discordAlerts: 閾値を超えた項目を Embed の説明文として整形 (項目がない場合は空文字)
+func discordAlerts(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return ":warning: **閾値を超えた項目があります**\n• " + strings.Join(lines, "\n• ")
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/library/email"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/slack"
+)
+
+// GenDailyMailReport: 日次利用コストレポートをメール本文のレポートとして生成
+//
+// 昨日の利用コストが今月の1日あたりの平均利用コストに対して閾値（%）以上の場合は、警告を表示する
+func (dcu DailyCostUsage) GenDailyMailReport(alertThreshold float64) email.Report {
+	yesterdayChange := 0.0
+	if dcu.AverageDailyCost != 0 {
+		yesterdayChange = dcu.YesterdayCost / dcu.AverageDailyCost * 100
+	}
+
+	report := email.Report{
+		Title: "AWS 日次利用コストレポート",
+		Facts: []email.Fact{
+			{Label: "昨日の利用コスト", Value: formatCost(dcu.Currency, dcu.YesterdayCost)},
+			{Label: "今月の1日あたりの平均に対する昨日のコスト", Value: formatChangeArrow(yesterdayChange, dcu.AverageDailyCost)},
+			{Label: "本日時点での今月の利用コスト", Value: formatCost(dcu.Currency, dcu.ActualCost)},
+			{Label: "今月の利用コストの予測値", Value: fmt.Sprintf("%s %s", formatCost(dcu.Currency, dcu.ForecastCost), dcu.formatForecastDetail())},
+		},
+		Tables: []email.Table{
+			mailTable("サービス別の利用コスト (昨日)", serviceCostTable(dcu.Currency, dcu.YesterdayServiceCosts)),
+			mailTable("サービス別の利用コスト (今月)", serviceCostTable(dcu.Currency, dcu.ActualServiceCosts)),
+		},
+		Footer: reportFooter(dcu.Metric, dcu.ExchangeRate),
+	}
+
+	if exceedsThreshold(yesterdayChange, dcu.AverageDailyCost, alertThreshold) {
+		report.Alerts = append(report.Alerts,
+			fmt.Sprintf("昨日の利用コストが今月の1日あたりの平均の %.0f %% を超えています (%.2f %%)", alertThreshold, yesterdayChange),
+		)
+	}
+
+	// 連結アカウントが複数存在する場合のみ、アカウント別の内訳を表示
+	if len(dcu.AccountCosts) > 1 {
+		report.Tables = append(report.Tables, mailTable("アカウント別の利用コスト", dailyAccountCostTable(dcu.Currency, dcu.AccountCosts)))
+	}
+
+	for _, table := range dcu.TagCosts {
+		report.Tables = append(report.Tables, mailTable(fmt.Sprintf("タグ別の利用コスト (%s)", table.TagKey), dailyTagCostTable(dcu.Currency, table)))
+	}
+
+	return report
+}
+
+// GenWeeklyMailReport: 週次利用コストレポートをメール本文のレポートとして生成
+//
+// 先々週のコストに対する先週のコストが閾値（%）以上の項目がある場合は、警告を表示する
+func (wcu *WeeklyCostUsage) GenWeeklyMailReport(alertThreshold float64) email.Report {
+	report := email.Report{
+		Title: "AWS 週次利用コストレポート",
+		Facts: []email.Fact{
+			{Label: "先週の利用コスト", Value: formatCost(wcu.Currency, wcu.LastWeekCost)},
+			{Label: "先々週の利用コスト", Value: formatCost(wcu.Currency, wcu.WeekBeforeLastCost)},
+			{Label: "先々週のコストに対する先週のコスト", Value: formatChangeArrow(wcu.PercentageChange, wcu.WeekBeforeLastCost)},
+		},
+		Alerts: wcu.exceededLines(alertThreshold),
+		Footer: reportFooter(wcu.Metric, wcu.exchangeRates()...),
+	}
+
+	if len(wcu.DailyCosts) > 0 {
+		report.Tables = append(report.Tables, mailTable("日別の利用コスト", weeklyDailyCostTable(wcu.Currency, wcu.DailyCosts)))
+	}
+
+	// 連結アカウントが複数存在する場合のみ、アカウント別の内訳を表示
+	if len(wcu.AccountCosts) > 1 {
+		report.Tables = append(report.Tables, mailTable("アカウント別の利用コスト", weeklyAccountCostTable(wcu.Currency, wcu.AccountCosts)))
+	}
+
+	for _, table := range wcu.TagCosts {
+		report.Tables = append(report.Tables, mailTable(fmt.Sprintf("タグ別の利用コスト (%s)", table.TagKey), weeklyTagCostTable(wcu.Currency, table)))
+	}
+
+	return report
+}
+
+// GenMonthlyMailReport: 月次利用コストレポートをメール本文のレポートとして生成
+//
+// 先々月のコストに対する先月のコストが閾値（%）以上の場合は、警告を表示する
+func (mcu *MonthlyCostUsage) GenMonthlyMailReport(alertThreshold float64) email.Report {
+	report := email.Report{
+		Title: fmt.Sprintf("AWS 月次利用コストレポート (%s)", mcu.LastMonth),
+		Facts: []email.Fact{
+			{Label: fmt.Sprintf("先月 (%s) の利用コスト", mcu.LastMonth), Value: formatCost(mcu.Currency, mcu.LastMonthCost)},
+			{Label: fmt.Sprintf("先々月 (%s) の利用コスト", mcu.MonthBeforeLast), Value: formatCost(mcu.Currency, mcu.MonthBeforeLastCost)},
+			{Label: "先々月からの増減額", Value: formatSignedCost(mcu.Currency, mcu.CostDifference)},
+			{Label: "先々月のコストに対する先月のコスト", Value: formatChangeArrow(mcu.PercentageChange, mcu.MonthBeforeLastCost)},
+		},
+		Tables: []email.Table{
+			mailTable("先月の利用コスト上位サービス", serviceCostTable(mcu.Currency, mcu.TopServices)),
+		},
+		Footer: reportFooter(mcu.Metric, mcu.exchangeRates()...),
+	}
+
+	if exceedsThreshold(mcu.PercentageChange, mcu.MonthBeforeLastCost, alertThreshold) {
+		report.Alerts = append(report.Alerts,
+			fmt.Sprintf("先々月のコストに対する先月のコストが %.0f %% を超えています (%.2f %%)", alertThreshold, mcu.PercentageChange),
+		)
+	}
+
+	return report
+}
+
+// mailTable: Slack 向けに生成した表をメール本文の表に変換
+func mailTable(title string, table slack.Table) email.Table {
+	return email.Table{Title: title, Header: table.Header, Rows: table.Rows}
+}
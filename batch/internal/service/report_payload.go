@@ -0,0 +1,372 @@
+package service
+
+import "time"
+
+// ReportSchemaVersion: 外部連携用の利用コストレポートの JSON スキーマのバージョン
+//
+// フィールドの追加は後方互換の変更として扱い、フィールドの削除や型・意味の変更を行う場合にのみメジャーバージョンを上げる
+const ReportSchemaVersion = "1.0"
+
+// ReportEnvelope: 全てのレポートに共通する JSON の項目
+type ReportEnvelope struct {
+	SchemaVersion string                `json:"schema_version"`
+	ReportType    string                `json:"report_type"` // daily, weekly, monthly
+	GeneratedAt   time.Time             `json:"generated_at"`
+	Metric        string                `json:"metric"`         // Cost Explorer の利用コストの指標 (例: UnblendedCost)
+	Currency      string                `json:"currency"`       // 金額の通貨 (ISO 4217)
+	ExchangeRates []PayloadExchangeRate `json:"exchange_rates"` // 金額の変換に使用した為替レート (変換していない場合は空)
+}
+
+// PayloadExchangeRate: 金額の変換に使用した為替レート
+type PayloadExchangeRate struct {
+	Base      string    `json:"base"`      // 変換元の通貨
+	Quote     string    `json:"quote"`     // 変換先の通貨
+	Rate      float64   `json:"rate"`      // 変換元の通貨1単位あたりの変換先の通貨の額
+	Timestamp time.Time `json:"timestamp"` // 為替レートの公表時刻
+	Source    string    `json:"source"`    // 為替レートの取得元 (例: openexchangerates, ecb, static)
+
+	Period *PayloadPeriod `json:"period,omitempty"` // 為替レートを適用した集計期間 (実行時点の最新の為替レートを適用した場合は省略)
+	Basis  string         `json:"basis,omitempty"`  // 集計期間に適用した為替レートの算出方法 (daily, average)
+	Stale  bool           `json:"stale,omitempty"`  // 取得元で失敗したため、期限切れのキャッシュの為替レートを使用したか
+}
+
+// PayloadPeriod: 集計期間 (終了日付は期間に含まない)
+type PayloadPeriod struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// PayloadServiceCost: サービスごとの利用コスト
+type PayloadServiceCost struct {
+	Service string  `json:"service"`
+	Cost    float64 `json:"cost"`
+}
+
+// DailyReportPayload: 日次利用コストレポートの JSON
+type DailyReportPayload struct {
+	ReportEnvelope
+	Periods    DailyPeriodsPayload    `json:"periods"`
+	Summary    DailySummaryPayload    `json:"summary"`
+	Breakdowns DailyBreakdownsPayload `json:"breakdowns"`
+}
+
+// DailyPeriodsPayload: 日次利用コストレポートの集計期間
+type DailyPeriodsPayload struct {
+	Yesterday   PayloadPeriod `json:"yesterday"`
+	MonthToDate PayloadPeriod `json:"month_to_date"`
+}
+
+// DailySummaryPayload: 日次利用コストレポートの概要
+type DailySummaryPayload struct {
+	YesterdayCost    float64         `json:"yesterday_cost"`
+	MonthToDateCost  float64         `json:"month_to_date_cost"`
+	AverageDailyCost float64         `json:"average_daily_cost"`
+	Forecast         ForecastPayload `json:"forecast"`
+}
+
+// ForecastPayload: 今月の利用コストの予測値
+type ForecastPayload struct {
+	Cost       float64 `json:"cost"`
+	LowerBound float64 `json:"lower_bound"`
+	UpperBound float64 `json:"upper_bound"`
+	Mode       string  `json:"mode"` // api, linear
+}
+
+// DailyBreakdownsPayload: 日次利用コストレポートの内訳
+type DailyBreakdownsPayload struct {
+	YesterdayServices   []PayloadServiceCost      `json:"yesterday_services"`
+	MonthToDateServices []PayloadServiceCost      `json:"month_to_date_services"`
+	Accounts            []DailyAccountCostPayload `json:"accounts"`
+	Tags                []DailyTagCostPayload     `json:"tags"`
+}
+
+// DailyAccountCostPayload: 連結アカウントごとの昨日と今月の利用コスト
+type DailyAccountCostPayload struct {
+	AccountID       string  `json:"account_id"`
+	AccountName     string  `json:"account_name"`
+	YesterdayCost   float64 `json:"yesterday_cost"`
+	MonthToDateCost float64 `json:"month_to_date_cost"`
+}
+
+// DailyTagCostPayload: コスト配分タグごとの内訳
+type DailyTagCostPayload struct {
+	Key    string                     `json:"key"`
+	Values []DailyTagValueCostPayload `json:"values"`
+}
+
+// DailyTagValueCostPayload: コスト配分タグの値ごとの昨日と今月の利用コスト
+type DailyTagValueCostPayload struct {
+	Value           string  `json:"value"`
+	YesterdayCost   float64 `json:"yesterday_cost"`
+	MonthToDateCost float64 `json:"month_to_date_cost"`
+}
+
+// WeeklyReportPayload: 週次利用コストレポートの JSON
+//
+// percentage_change は比較対象のコストに対する割合（%）で、100 は増減なしを表す (比較対象のコストが0の場合は null)
+type WeeklyReportPayload struct {
+	ReportEnvelope
+	Periods    WeeklyPeriodsPayload    `json:"periods"`
+	Summary    WeeklySummaryPayload    `json:"summary"`
+	Breakdowns WeeklyBreakdownsPayload `json:"breakdowns"`
+}
+
+// WeeklyPeriodsPayload: 週次利用コストレポートの集計期間
+type WeeklyPeriodsPayload struct {
+	LastWeek       PayloadPeriod `json:"last_week"`
+	WeekBeforeLast PayloadPeriod `json:"week_before_last"`
+}
+
+// WeeklySummaryPayload: 週次利用コストレポートの概要
+type WeeklySummaryPayload struct {
+	LastWeekCost       float64  `json:"last_week_cost"`
+	WeekBeforeLastCost float64  `json:"week_before_last_cost"`
+	PercentageChange   *float64 `json:"percentage_change"`
+}
+
+// WeeklyBreakdownsPayload: 週次利用コストレポートの内訳
+type WeeklyBreakdownsPayload struct {
+	Daily    []WeeklyDailyCostPayload   `json:"daily"`
+	Accounts []WeeklyAccountCostPayload `json:"accounts"`
+	Tags     []WeeklyTagCostPayload     `json:"tags"`
+}
+
+// WeeklyDailyCostPayload: 先週と先々週の同じ曜日の利用コスト
+type WeeklyDailyCostPayload struct {
+	LastWeekDate       string   `json:"last_week_date"`
+	LastWeekCost       float64  `json:"last_week_cost"`
+	WeekBeforeLastDate string   `json:"week_before_last_date"`
+	WeekBeforeLastCost float64  `json:"week_before_last_cost"`
+	PercentageChange   *float64 `json:"percentage_change"`
+}
+
+// WeeklyAccountCostPayload: 連結アカウントごとの先週と先々週の利用コスト
+type WeeklyAccountCostPayload struct {
+	AccountID          string   `json:"account_id"`
+	AccountName        string   `json:"account_name"`
+	LastWeekCost       float64  `json:"last_week_cost"`
+	WeekBeforeLastCost float64  `json:"week_before_last_cost"`
+	PercentageChange   *float64 `json:"percentage_change"`
+}
+
+// WeeklyTagCostPayload: コスト配分タグごとの内訳
+type WeeklyTagCostPayload struct {
+	Key    string                      `json:"key"`
+	Values []WeeklyTagValueCostPayload `json:"values"`
+}
+
+// WeeklyTagValueCostPayload: コスト配分タグの値ごとの先週と先々週の利用コスト
+type WeeklyTagValueCostPayload struct {
+	Value              string   `json:"value"`
+	LastWeekCost       float64  `json:"last_week_cost"`
+	WeekBeforeLastCost float64  `json:"week_before_last_cost"`
+	PercentageChange   *float64 `json:"percentage_change"`
+}
+
+// MonthlyReportPayload: 月次利用コストレポートの JSON
+//
+// percentage_change は比較対象のコストに対する割合（%）で、100 は増減なしを表す (比較対象のコストが0の場合は null)
+type MonthlyReportPayload struct {
+	ReportEnvelope
+	Periods    MonthlyPeriodsPayload    `json:"periods"`
+	Summary    MonthlySummaryPayload    `json:"summary"`
+	Breakdowns MonthlyBreakdownsPayload `json:"breakdowns"`
+}
+
+// MonthlyPeriodsPayload: 月次利用コストレポートの集計期間
+type MonthlyPeriodsPayload struct {
+	LastMonth       PayloadPeriod `json:"last_month"`
+	MonthBeforeLast PayloadPeriod `json:"month_before_last"`
+}
+
+// MonthlySummaryPayload: 月次利用コストレポートの概要
+type MonthlySummaryPayload struct {
+	LastMonthCost       float64  `json:"last_month_cost"`
+	MonthBeforeLastCost float64  `json:"month_before_last_cost"`
+	CostDifference      float64  `json:"cost_difference"`
+	PercentageChange    *float64 `json:"percentage_change"`
+}
+
+// MonthlyBreakdownsPayload: 月次利用コストレポートの内訳
+type MonthlyBreakdownsPayload struct {
+	TopServices []PayloadServiceCost `json:"top_services"`
+}
+
+// GenDailyReportPayload: 日次利用コストレポートを外部連携用の JSON の構造体として生成
+func (dcu DailyCostUsage) GenDailyReportPayload(generatedAt time.Time) DailyReportPayload {
+	accounts := make([]DailyAccountCostPayload, 0, len(dcu.AccountCosts))
+	for _, ac := range dcu.AccountCosts {
+		accounts = append(accounts, DailyAccountCostPayload{
+			AccountID:       ac.AccountID,
+			AccountName:     ac.AccountName,
+			YesterdayCost:   ac.YesterdayCost,
+			MonthToDateCost: ac.ActualCost,
+		})
+	}
+
+	tags := make([]DailyTagCostPayload, 0, len(dcu.TagCosts))
+	for _, table := range dcu.TagCosts {
+		values := make([]DailyTagValueCostPayload, 0, len(table.Costs))
+		for _, tc := range table.Costs {
+			values = append(values, DailyTagValueCostPayload{Value: tc.TagValue, YesterdayCost: tc.YesterdayCost, MonthToDateCost: tc.ActualCost})
+		}
+		tags = append(tags, DailyTagCostPayload{Key: table.TagKey, Values: values})
+	}
+
+	return DailyReportPayload{
+		ReportEnvelope: newReportEnvelope("daily", generatedAt, dcu.Metric, dcu.Currency, dcu.ExchangeRate),
+		Periods: DailyPeriodsPayload{
+			Yesterday:   newPayloadPeriod(dcu.YesterdayPeriod),
+			MonthToDate: newPayloadPeriod(dcu.ActualPeriod),
+		},
+		Summary: DailySummaryPayload{
+			YesterdayCost:    dcu.YesterdayCost,
+			MonthToDateCost:  dcu.ActualCost,
+			AverageDailyCost: dcu.AverageDailyCost,
+			Forecast: ForecastPayload{
+				Cost:       dcu.ForecastCost,
+				LowerBound: dcu.ForecastLowerBound,
+				UpperBound: dcu.ForecastUpperBound,
+				Mode:       string(dcu.ForecastMode),
+			},
+		},
+		Breakdowns: DailyBreakdownsPayload{
+			YesterdayServices:   newPayloadServiceCosts(dcu.YesterdayServiceCosts),
+			MonthToDateServices: newPayloadServiceCosts(dcu.ActualServiceCosts),
+			Accounts:            accounts,
+			Tags:                tags,
+		},
+	}
+}
+
+// GenWeeklyReportPayload: 週次利用コストレポートを外部連携用の JSON の構造体として生成
+func (wcu *WeeklyCostUsage) GenWeeklyReportPayload(generatedAt time.Time) WeeklyReportPayload {
+	daily := make([]WeeklyDailyCostPayload, 0, len(wcu.DailyCosts))
+	for _, dc := range wcu.DailyCosts {
+		daily = append(daily, WeeklyDailyCostPayload{
+			LastWeekDate:       dc.LastWeekDate,
+			LastWeekCost:       dc.LastWeekCost,
+			WeekBeforeLastDate: dc.WeekBeforeLastDate,
+			WeekBeforeLastCost: dc.WeekBeforeLastCost,
+			PercentageChange:   payloadPercentageChange(dc.PercentageChange, dc.WeekBeforeLastCost),
+		})
+	}
+
+	accounts := make([]WeeklyAccountCostPayload, 0, len(wcu.AccountCosts))
+	for _, ac := range wcu.AccountCosts {
+		accounts = append(accounts, WeeklyAccountCostPayload{
+			AccountID:          ac.AccountID,
+			AccountName:        ac.AccountName,
+			LastWeekCost:       ac.LastWeekCost,
+			WeekBeforeLastCost: ac.WeekBeforeLastCost,
+			PercentageChange:   payloadPercentageChange(ac.PercentageChange, ac.WeekBeforeLastCost),
+		})
+	}
+
+	tags := make([]WeeklyTagCostPayload, 0, len(wcu.TagCosts))
+	for _, table := range wcu.TagCosts {
+		values := make([]WeeklyTagValueCostPayload, 0, len(table.Costs))
+		for _, tc := range table.Costs {
+			values = append(values, WeeklyTagValueCostPayload{
+				Value:              tc.TagValue,
+				LastWeekCost:       tc.LastWeekCost,
+				WeekBeforeLastCost: tc.WeekBeforeLastCost,
+				PercentageChange:   payloadPercentageChange(tc.PercentageChange, tc.WeekBeforeLastCost),
+			})
+		}
+		tags = append(tags, WeeklyTagCostPayload{Key: table.TagKey, Values: values})
+	}
+
+	return WeeklyReportPayload{
+		ReportEnvelope: newReportEnvelope("weekly", generatedAt, wcu.Metric, wcu.Currency, wcu.exchangeRates()...),
+		Periods: WeeklyPeriodsPayload{
+			LastWeek:       newPayloadPeriod(wcu.LastWeekPeriod),
+			WeekBeforeLast: newPayloadPeriod(wcu.WeekBeforeLastPeriod),
+		},
+		Summary: WeeklySummaryPayload{
+			LastWeekCost:       wcu.LastWeekCost,
+			WeekBeforeLastCost: wcu.WeekBeforeLastCost,
+			PercentageChange:   payloadPercentageChange(wcu.PercentageChange, wcu.WeekBeforeLastCost),
+		},
+		Breakdowns: WeeklyBreakdownsPayload{
+			Daily:    daily,
+			Accounts: accounts,
+			Tags:     tags,
+		},
+	}
+}
+
+// GenMonthlyReportPayload: 月次利用コストレポートを外部連携用の JSON の構造体として生成
+func (mcu *MonthlyCostUsage) GenMonthlyReportPayload(generatedAt time.Time) MonthlyReportPayload {
+	return MonthlyReportPayload{
+		ReportEnvelope: newReportEnvelope("monthly", generatedAt, mcu.Metric, mcu.Currency, mcu.exchangeRates()...),
+		Periods: MonthlyPeriodsPayload{
+			LastMonth:       newPayloadPeriod(mcu.LastMonthPeriod),
+			MonthBeforeLast: newPayloadPeriod(mcu.MonthBeforeLastPeriod),
+		},
+		Summary: MonthlySummaryPayload{
+			LastMonthCost:       mcu.LastMonthCost,
+			MonthBeforeLastCost: mcu.MonthBeforeLastCost,
+			CostDifference:      mcu.CostDifference,
+			PercentageChange:    payloadPercentageChange(mcu.PercentageChange, mcu.MonthBeforeLastCost),
+		},
+		Breakdowns: MonthlyBreakdownsPayload{
+			TopServices: newPayloadServiceCosts(mcu.TopServices),
+		},
+	}
+}
+
+// newReportEnvelope: 全てのレポートに共通する JSON の項目を生成
+func newReportEnvelope(reportType string, generatedAt time.Time, metric CostMetric, currency string, rates ...ExchangeRate) ReportEnvelope {
+	payloadRates := []PayloadExchangeRate{}
+	for _, rate := range rates {
+		if rate.Currency == "" {
+			continue
+		}
+		pr := PayloadExchangeRate{
+			Base:      rate.BaseCurrency,
+			Quote:     rate.Currency,
+			Rate:      rate.Rate,
+			Timestamp: rate.Timestamp,
+			Source:    rate.Source,
+			Basis:     rate.Basis,
+			Stale:     rate.Stale,
+		}
+		if rate.Period != (Period{}) {
+			period := newPayloadPeriod(rate.Period)
+			pr.Period = &period
+		}
+		payloadRates = append(payloadRates, pr)
+	}
+
+	return ReportEnvelope{
+		SchemaVersion: ReportSchemaVersion,
+		ReportType:    reportType,
+		GeneratedAt:   generatedAt.UTC(),
+		Metric:        string(metric),
+		Currency:      currency,
+		ExchangeRates: payloadRates,
+	}
+}
+
+// newPayloadPeriod: 集計期間を JSON の構造体に変換
+func newPayloadPeriod(period Period) PayloadPeriod {
+	return PayloadPeriod{Start: period.Start, End: period.End}
+}
+
+// newPayloadServiceCosts: サービスごとの利用コストを JSON の構造体に変換
+func newPayloadServiceCosts(serviceCosts []ServiceCost) []PayloadServiceCost {
+	costs := make([]PayloadServiceCost, 0, len(serviceCosts))
+	for _, sc := range serviceCosts {
+		costs = append(costs, PayloadServiceCost{Service: sc.ServiceName, Cost: sc.Cost})
+	}
+	return costs
+}
+
+// payloadPercentageChange: 比較対象のコストが0の場合は、増減率を null として扱う
+func payloadPercentageChange(percentageChange, baseCost float64) *float64 {
+	if baseCost == 0 {
+		return nil
+	}
+	return &percentageChange
+}
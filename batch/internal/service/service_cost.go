@@ -0,0 +1,97 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+)
+
+// topServicesLimit: レポートに表示する利用コスト上位サービスの件数
+const topServicesLimit = 5
+
+// OthersServiceName: 上位サービス以外のコストをまとめた項目名
+const OthersServiceName = "Others"
+
+// ServiceCost: サービスごとの利用コスト
+type ServiceCost struct {
+	ServiceName string
+	Cost        float64
+}
+
+// groupByService: 利用コストをサービス単位で集計するための GroupBy 定義
+func groupByService() []types.GroupDefinition {
+	return []types.GroupDefinition{
+		{
+			Type: types.GroupDefinitionTypeDimension,
+			Key:  aws.String(string(types.DimensionService)),
+		},
+	}
+}
+
+// sumGroupCosts: GroupBy を指定した GetCostAndUsage のレスポンスから、グループのキーごとに利用コストを合算
+func sumGroupCosts(output *cost_explorer.GetCostAndUsageOutput, metric string) (map[string]float64, error) {
+	costs := make(map[string]float64)
+	for _, result := range output.ResultsByTime {
+		for _, group := range result.Groups {
+			if len(group.Keys) == 0 {
+				continue
+			}
+			cost, ok := group.Metrics[metric]
+			if !ok || cost.Amount == nil {
+				continue
+			}
+			amount, err := strconv.ParseFloat(*cost.Amount, 64)
+			if err != nil {
+				return nil, err
+			}
+			costs[group.Keys[0]] += amount
+		}
+	}
+
+	return costs, nil
+}
+
+// rankServiceCosts: サービスごとの利用コストを降順に並べ、上位 limit 件以外を "Others" にまとめる
+func rankServiceCosts(costs map[string]float64, limit int) []ServiceCost {
+	serviceCosts := make([]ServiceCost, 0, len(costs))
+	for name, cost := range costs {
+		serviceCosts = append(serviceCosts, ServiceCost{ServiceName: name, Cost: cost})
+	}
+
+	// コストの降順、同額の場合はサービス名の昇順で並び替え
+	sort.Slice(serviceCosts, func(i, j int) bool {
+		if serviceCosts[i].Cost == serviceCosts[j].Cost {
+			return serviceCosts[i].ServiceName < serviceCosts[j].ServiceName
+		}
+		return serviceCosts[i].Cost > serviceCosts[j].Cost
+	})
+
+	if len(serviceCosts) <= limit {
+		return serviceCosts
+	}
+
+	others := 0.0
+	for _, sc := range serviceCosts[limit:] {
+		others += sc.Cost
+	}
+
+	return append(serviceCosts[:limit:limit], ServiceCost{ServiceName: OthersServiceName, Cost: others})
+}
+
+// formatServiceCostRanking: サービスごとの利用コストを順位付きのリストとして整形
+func formatServiceCostRanking(currency string, serviceCosts []ServiceCost) string {
+	var sb strings.Builder
+	for i, sc := range serviceCosts {
+		if sc.ServiceName == OthersServiceName {
+			sb.WriteString(fmt.Sprintf("    -  %s: %s\n", sc.ServiceName, formatCost(currency, sc.Cost)))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("    %d. %s: %s\n", i+1, sc.ServiceName, formatCost(currency, sc.Cost)))
+	}
+	return sb.String()
+}
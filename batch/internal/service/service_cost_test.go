@@ -0,0 +1,65 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankServiceCosts(t *testing.T) {
+	tests := map[string]struct {
+		costs    map[string]float64
+		limit    int
+		expected []ServiceCost
+	}{
+		"上位件数以下の場合はコストの降順にそのまま返す": {
+			costs: map[string]float64{
+				"AWS Lambda":      0.5,
+				"Amazon RDS":      3.0,
+				"Amazon DynamoDB": 1.2,
+			},
+			limit: 5,
+			expected: []ServiceCost{
+				{ServiceName: "Amazon RDS", Cost: 3.0},
+				{ServiceName: "Amazon DynamoDB", Cost: 1.2},
+				{ServiceName: "AWS Lambda", Cost: 0.5},
+			},
+		},
+		"上位件数を超える場合は残りをOthersにまとめる": {
+			costs: map[string]float64{
+				"AWS Lambda":      0.5,
+				"Amazon RDS":      3.0,
+				"Amazon DynamoDB": 1.2,
+				"Amazon S3":       0.25,
+			},
+			limit: 2,
+			expected: []ServiceCost{
+				{ServiceName: "Amazon RDS", Cost: 3.0},
+				{ServiceName: "Amazon DynamoDB", Cost: 1.2},
+				{ServiceName: OthersServiceName, Cost: 0.75},
+			},
+		},
+		"同額の場合はサービス名の昇順に並べる": {
+			costs: map[string]float64{
+				"Amazon S3":  1.0,
+				"AWS Lambda": 1.0,
+			},
+			limit: 5,
+			expected: []ServiceCost{
+				{ServiceName: "AWS Lambda", Cost: 1.0},
+				{ServiceName: "Amazon S3", Cost: 1.0},
+			},
+		},
+		"コストが存在しない場合は空のスライスを返す": {
+			costs:    map[string]float64{},
+			limit:    5,
+			expected: []ServiceCost{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, rankServiceCosts(tt.costs, tt.limit))
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package service
+
+import "fmt"
+
+// Severity: 利用コストレポートの重要度
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"     // 閾値や上限を超えた項目がない
+	SeverityWarn     Severity = "warn"     // 比較対象のコストに対する割合が閾値を超えた項目がある
+	SeverityCritical Severity = "critical" // 利用コストが上限を超えている
+)
+
+// String: 重要度の型を文字列型に変換
+func (s Severity) String() string {
+	return string(s)
+}
+
+// ParseSeverity: 文字列から重要度を取得
+func ParseSeverity(s string) (Severity, error) {
+	switch sv := Severity(s); sv {
+	case SeverityInfo, SeverityWarn, SeverityCritical:
+		return sv, nil
+	default:
+		return "", fmt.Errorf("invalid severity: %s", s)
+	}
+}
+
+// SeverityRule: 利用コストレポートの重要度の判定基準
+type SeverityRule struct {
+	AlertThreshold float64    // 比較対象のコストに対する割合（%）がこの値以上の場合に warn とする (0 以下で無効)
+	SpendLimit     SpendLimit // 日次レポートで利用コストがこの上限を超えた場合に critical とする
+}
+
+// Severity: 日次利用コストレポートの重要度を判定
+func (dcu DailyCostUsage) Severity(rule SeverityRule) Severity {
+	if len(dcu.ExceededSpendLimits(rule.SpendLimit)) > 0 {
+		return SeverityCritical
+	}
+
+	yesterdayChange := 0.0
+	if dcu.AverageDailyCost != 0 {
+		yesterdayChange = dcu.YesterdayCost / dcu.AverageDailyCost * 100
+	}
+	if exceedsThreshold(yesterdayChange, dcu.AverageDailyCost, rule.AlertThreshold) {
+		return SeverityWarn
+	}
+	return SeverityInfo
+}
+
+// Severity: 週次利用コストレポートの重要度を判定
+//
+// 全体、アカウント別、タグ別のいずれかが閾値を超えた場合に warn とする
+func (wcu *WeeklyCostUsage) Severity(rule SeverityRule) Severity {
+	if len(wcu.exceededLines(rule.AlertThreshold)) > 0 {
+		return SeverityWarn
+	}
+	return SeverityInfo
+}
+
+// Severity: 月次利用コストレポートの重要度を判定
+func (mcu *MonthlyCostUsage) Severity(rule SeverityRule) Severity {
+	if exceedsThreshold(mcu.PercentageChange, mcu.MonthBeforeLastCost, rule.AlertThreshold) {
+		return SeverityWarn
+	}
+	return SeverityInfo
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyCostUsage_Severity(t *testing.T) {
+	rule := SeverityRule{AlertThreshold: 120, SpendLimit: SpendLimit{ForecastCost: 300000}}
+
+	tests := []struct {
+		name  string
+		usage DailyCostUsage
+		want  Severity
+	}{
+		{
+			name:  "正常系: 今月の利用コストの予測値が上限を超えた場合は critical となること",
+			usage: DailyCostUsage{YesterdayCost: 100, AverageDailyCost: 100, ForecastCost: 350000},
+			want:  SeverityCritical,
+		},
+		{
+			name:  "正常系: 昨日の利用コストが1日あたりの平均の閾値を超えた場合は warn となること",
+			usage: DailyCostUsage{YesterdayCost: 150, AverageDailyCost: 100, ForecastCost: 3000},
+			want:  SeverityWarn,
+		},
+		{
+			name:  "正常系: 閾値と上限を超えていない場合は info となること",
+			usage: DailyCostUsage{YesterdayCost: 110, AverageDailyCost: 100, ForecastCost: 3000},
+			want:  SeverityInfo,
+		},
+		{
+			name:  "正常系: 1日あたりの平均が0の場合は info となること",
+			usage: DailyCostUsage{YesterdayCost: 110},
+			want:  SeverityInfo,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.usage.Severity(rule))
+		})
+	}
+}
+
+func TestWeeklyCostUsage_Severity(t *testing.T) {
+	rule := SeverityRule{AlertThreshold: 120}
+
+	t.Run("正常系: アカウント別の内訳が閾値を超えた場合は warn となること", func(t *testing.T) {
+		usage := &WeeklyCostUsage{
+			LastWeekCost: 1000, WeekBeforeLastCost: 1000, PercentageChange: 100,
+			AccountCosts: []WeeklyAccountCost{{AccountID: "123456789012", LastWeekCost: 300, WeekBeforeLastCost: 200, PercentageChange: 150}},
+		}
+		assert.Equal(t, SeverityWarn, usage.Severity(rule))
+	})
+
+	t.Run("正常系: 閾値を超えた項目がない場合は info となること", func(t *testing.T) {
+		usage := &WeeklyCostUsage{LastWeekCost: 1000, WeekBeforeLastCost: 1000, PercentageChange: 100}
+		assert.Equal(t, SeverityInfo, usage.Severity(rule))
+	})
+}
+
+func TestParseSeverity(t *testing.T) {
+	severity, err := ParseSeverity("warn")
+	assert.NoError(t, err)
+	assert.Equal(t, SeverityWarn, severity)
+
+	_, err = ParseSeverity("warning")
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+)
+
+// SpendLimit: 日次利用コストレポートで監視する利用コストの上限
+//
+// 上限の金額はレポートの通貨 (表示する通貨の先頭) で指定し、0 以下の項目は監視しない
+type SpendLimit struct {
+	YesterdayCost float64 // 昨日の利用コストの上限
+	ForecastCost  float64 // 今月の利用コストの予測値の上限
+}
+
+// Enabled: 監視する上限が1つ以上設定されているかを判定
+func (sl SpendLimit) Enabled() bool {
+	return sl.YesterdayCost > 0 || sl.ForecastCost > 0
+}
+
+// SpendLimitKind: 監視する利用コストの上限の項目
+type SpendLimitKind string
+
+const (
+	SpendLimitKindYesterday SpendLimitKind = "yesterday-cost" // 昨日の利用コスト
+	SpendLimitKindForecast  SpendLimitKind = "forecast-cost"  // 今月の利用コストの予測値
+)
+
+// SpendLimitBreach: 上限を超えた利用コストの項目
+type SpendLimitBreach struct {
+	Kind     SpendLimitKind // 上限の項目
+	Name     string         // 項目名
+	Cost     float64        // 利用コスト
+	Limit    float64        // 上限
+	Currency string         // 利用コストと上限の通貨
+}
+
+// String: 上限を超えた項目を文字列に整形
+func (slb SpendLimitBreach) String() string {
+	return fmt.Sprintf("%s %s (上限 %s)", slb.Name, formatCost(slb.Currency, slb.Cost), formatCost(slb.Currency, slb.Limit))
+}
+
+// ExceededSpendLimits: 昨日の利用コストと今月の利用コストの予測値のうち、上限を超えた項目を抽出
+func (dcu DailyCostUsage) ExceededSpendLimits(limit SpendLimit) []SpendLimitBreach {
+	var breaches []SpendLimitBreach
+	if limit.YesterdayCost > 0 && dcu.YesterdayCost > limit.YesterdayCost {
+		breaches = append(breaches, SpendLimitBreach{Kind: SpendLimitKindYesterday, Name: "昨日の利用コスト", Cost: dcu.YesterdayCost, Limit: limit.YesterdayCost, Currency: dcu.Currency})
+	}
+	if limit.ForecastCost > 0 && dcu.ForecastCost > limit.ForecastCost {
+		breaches = append(breaches, SpendLimitBreach{Kind: SpendLimitKindForecast, Name: "今月の利用コストの予測値", Cost: dcu.ForecastCost, Limit: limit.ForecastCost, Currency: dcu.Currency})
+	}
+	return breaches
+}
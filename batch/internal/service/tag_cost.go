@@ -0,0 +1,165 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+)
+
+// UntaggedValue: コスト配分タグが付与されていないリソースの利用コストをまとめた項目名
+const UntaggedValue = "untagged"
+
+// DailyTagCostTable: 日次レポートに表示するコスト配分タグの値ごとの利用コスト
+type DailyTagCostTable struct {
+	TagKey string
+	Costs  []DailyTagCost
+}
+
+// DailyTagCost: コスト配分タグの値ごとの昨日と今月の利用コスト
+type DailyTagCost struct {
+	TagValue      string
+	YesterdayCost float64 // 昨日の利用コスト
+	ActualCost    float64 // 本日時点での今月の利用コスト
+}
+
+// WeeklyTagCostTable: 週次レポートに表示するコスト配分タグの値ごとの利用コスト
+type WeeklyTagCostTable struct {
+	TagKey string
+	Costs  []WeeklyTagCost
+}
+
+// WeeklyTagCost: コスト配分タグの値ごとの先週と先々週の利用コスト
+type WeeklyTagCost struct {
+	TagValue           string
+	LastWeekCost       float64 // 先週の利用コスト
+	WeekBeforeLastCost float64 // 先々週の利用コスト
+	PercentageChange   float64 // 先々週のコストに対する先週のコスト（%）、先々週のコストが0の場合は0
+}
+
+// groupByTag: 利用コストをコスト配分タグの値単位で集計するための GroupBy 定義
+func groupByTag(tagKey string) []types.GroupDefinition {
+	return []types.GroupDefinition{
+		{
+			Type: types.GroupDefinitionTypeTag,
+			Key:  aws.String(tagKey),
+		},
+	}
+}
+
+// parseTagValue: GroupBy に TAG を指定した場合のキー (例: "team$backend") からタグの値を取り出す
+//
+// タグが付与されていないリソースのキーは "team$" のように値が空になるため、"untagged" として扱う
+func parseTagValue(groupKey string) string {
+	_, value, _ := strings.Cut(groupKey, "$")
+	if value == "" {
+		return UntaggedValue
+	}
+	return value
+}
+
+// toDailyTagCostTable: グループのキーごとの利用コストをタグの値ごとの利用コストに変換 ("untagged" は常に末尾に表示)
+func toDailyTagCostTable(tagKey string, groupCosts []dailyGroupCost) DailyTagCostTable {
+	untagged := DailyTagCost{TagValue: UntaggedValue}
+	costs := make([]DailyTagCost, 0, len(groupCosts)+1)
+	for _, gc := range groupCosts {
+		value := parseTagValue(gc.key)
+		if value == UntaggedValue {
+			untagged.YesterdayCost += gc.yesterdayCost
+			untagged.ActualCost += gc.actualCost
+			continue
+		}
+		costs = append(costs, DailyTagCost{
+			TagValue:      value,
+			YesterdayCost: gc.yesterdayCost,
+			ActualCost:    gc.actualCost,
+		})
+	}
+
+	return DailyTagCostTable{
+		TagKey: tagKey,
+		Costs:  append(costs, untagged),
+	}
+}
+
+// toWeeklyTagCostTable: グループのキーごとの利用コストをタグの値ごとの利用コストに変換 ("untagged" は常に末尾に表示)
+func toWeeklyTagCostTable(tagKey string, groupCosts []weeklyGroupCost) WeeklyTagCostTable {
+	untagged := WeeklyTagCost{TagValue: UntaggedValue}
+	costs := make([]WeeklyTagCost, 0, len(groupCosts)+1)
+	for _, gc := range groupCosts {
+		value := parseTagValue(gc.key)
+		if value == UntaggedValue {
+			untagged.LastWeekCost += gc.lastWeekCost
+			untagged.WeekBeforeLastCost += gc.weekBeforeLastCost
+			continue
+		}
+		costs = append(costs, WeeklyTagCost{
+			TagValue:           value,
+			LastWeekCost:       gc.lastWeekCost,
+			WeekBeforeLastCost: gc.weekBeforeLastCost,
+			PercentageChange:   gc.percentageChange,
+		})
+	}
+
+	if untagged.WeekBeforeLastCost != 0 {
+		untagged.PercentageChange = untagged.LastWeekCost / untagged.WeekBeforeLastCost * 100
+	}
+
+	return WeeklyTagCostTable{
+		TagKey: tagKey,
+		Costs:  append(costs, untagged),
+	}
+}
+
+// formatDailyTagCostTable: タグの値ごとの昨日と今月の利用コストを表として整形
+func formatDailyTagCostTable(currency string, table DailyTagCostTable) string {
+	width := tagValueWidth(table.TagKey, len(table.Costs), func(i int) string { return table.Costs[i].TagValue })
+
+	var sb strings.Builder
+	sb.WriteString("```\n")
+	sb.WriteString(fmt.Sprintf("%s  %12s  %12s\n", padRight(table.TagKey, width), costHeader("昨日", currency), costHeader("今月", currency)))
+	for _, tc := range table.Costs {
+		sb.WriteString(fmt.Sprintf("%s  %12s  %12s\n", padRight(tc.TagValue, width), formatAmount(currency, tc.YesterdayCost), formatAmount(currency, tc.ActualCost)))
+	}
+	sb.WriteString("```\n")
+	return sb.String()
+}
+
+// formatWeeklyTagCostTable: タグの値ごとの先週と先々週の利用コストを表として整形
+func formatWeeklyTagCostTable(currency string, table WeeklyTagCostTable) string {
+	width := tagValueWidth(table.TagKey, len(table.Costs), func(i int) string { return table.Costs[i].TagValue })
+
+	var sb strings.Builder
+	sb.WriteString("```\n")
+	sb.WriteString(fmt.Sprintf("%s  %12s  %12s  %10s\n", padRight(table.TagKey, width), costHeader("先週", currency), costHeader("先々週", currency), "増減 (%)"))
+	for _, tc := range table.Costs {
+		change := "-"
+		if tc.WeekBeforeLastCost != 0 {
+			change = fmt.Sprintf("%.2f", tc.PercentageChange)
+		}
+		sb.WriteString(fmt.Sprintf("%s  %12s  %12s  %10s\n", padRight(tc.TagValue, width), formatAmount(currency, tc.LastWeekCost), formatAmount(currency, tc.WeekBeforeLastCost), change))
+	}
+	sb.WriteString("```\n")
+	return sb.String()
+}
+
+// tagValueWidth: 表の1列目 (タグの値) の表示幅を算出
+func tagValueWidth(tagKey string, n int, value func(i int) string) int {
+	width := max(utf8.RuneCountInString(tagKey), utf8.RuneCountInString(UntaggedValue))
+	for i := 0; i < n; i++ {
+		if w := utf8.RuneCountInString(value(i)); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// padRight: 文字列を指定した幅になるまで右側を空白で埋める
+func padRight(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
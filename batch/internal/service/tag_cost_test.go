@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTagValue(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected string
+	}{
+		"タグの値を取り出す":              {input: "team$backend", expected: "backend"},
+		"値に$を含む場合も最初の区切り以降を値とする": {input: "project$cost$explorer", expected: "cost$explorer"},
+		"値が空の場合はuntaggedとして扱う":   {input: "team$", expected: UntaggedValue},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseTagValue(tt.input))
+		})
+	}
+}
+
+func TestToWeeklyTagCostTable(t *testing.T) {
+	groupCosts := []weeklyGroupCost{
+		{key: "team$", lastWeekCost: 8, weekBeforeLastCost: 4, percentageChange: 200},
+		{key: "team$backend", lastWeekCost: 6, weekBeforeLastCost: 3, percentageChange: 200},
+		{key: "team$frontend", lastWeekCost: 1, weekBeforeLastCost: 0, percentageChange: 0},
+	}
+
+	t.Run("正常系: untaggedの行を末尾に配置すること", func(t *testing.T) {
+		assert.Equal(t, WeeklyTagCostTable{
+			TagKey: "team",
+			Costs: []WeeklyTagCost{
+				{TagValue: "backend", LastWeekCost: 6, WeekBeforeLastCost: 3, PercentageChange: 200},
+				{TagValue: "frontend", LastWeekCost: 1, WeekBeforeLastCost: 0, PercentageChange: 0},
+				{TagValue: UntaggedValue, LastWeekCost: 8, WeekBeforeLastCost: 4, PercentageChange: 200},
+			},
+		}, toWeeklyTagCostTable("team", groupCosts))
+	})
+
+	t.Run("正常系: タグが付与されたリソースがない場合もuntaggedの行を返すこと", func(t *testing.T) {
+		assert.Equal(t, WeeklyTagCostTable{
+			TagKey: "team",
+			Costs:  []WeeklyTagCost{{TagValue: UntaggedValue}},
+		}, toWeeklyTagCostTable("team", nil))
+	})
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
+)
+
+// DailyCost: 1日分の利用コスト
+type DailyCost struct {
+	Date string  // 利用日 (YYYY-MM-DD)
+	Cost float64 // 利用コスト
+}
+
+// WeeklyCosts: 先週と先々週の日ごとの利用コスト
+type WeeklyCosts struct {
+	LastWeek       []DailyCost // 先週の日ごとの利用コスト (日付の昇順)
+	WeekBeforeLast []DailyCost // 先々週の日ごとの利用コスト (日付の昇順)
+}
+
+// LastWeekTotal: 先週の利用コストの合計を算出
+func (wc WeeklyCosts) LastWeekTotal() float64 {
+	return sumDailyCosts(wc.LastWeek)
+}
+
+// WeekBeforeLastTotal: 先々週の利用コストの合計を算出
+func (wc WeeklyCosts) WeekBeforeLastTotal() float64 {
+	return sumDailyCosts(wc.WeekBeforeLast)
+}
+
+// CompareByDay: 先週と先々週の利用コストを同じ曜日同士で並べた比較表を生成
+func (wc WeeklyCosts) CompareByDay() []WeeklyDailyCost {
+	days := max(len(wc.LastWeek), len(wc.WeekBeforeLast))
+	comparisons := make([]WeeklyDailyCost, 0, days)
+	for i := 0; i < days; i++ {
+		var c WeeklyDailyCost
+		if i < len(wc.LastWeek) {
+			c.LastWeekDate = wc.LastWeek[i].Date
+			c.LastWeekCost = wc.LastWeek[i].Cost
+		}
+		if i < len(wc.WeekBeforeLast) {
+			c.WeekBeforeLastDate = wc.WeekBeforeLast[i].Date
+			c.WeekBeforeLastCost = wc.WeekBeforeLast[i].Cost
+		}
+		if c.WeekBeforeLastCost != 0 {
+			c.PercentageChange = c.LastWeekCost / c.WeekBeforeLastCost * 100
+		}
+		comparisons = append(comparisons, c)
+	}
+	return comparisons
+}
+
+// WeeklyDailyCost: 週次レポートに表示する先週と先々週の同じ曜日の利用コスト
+type WeeklyDailyCost struct {
+	LastWeekDate       string  // 先週の利用日
+	LastWeekCost       float64 // 先週の利用コスト
+	WeekBeforeLastDate string  // 先々週の利用日
+	WeekBeforeLastCost float64 // 先々週の利用コスト
+	PercentageChange   float64 // 先々週のコストに対する先週のコスト（%）、先々週のコストが0の場合は0
+}
+
+func sumDailyCosts(dailyCosts []DailyCost) float64 {
+	total := 0.0
+	for _, dc := range dailyCosts {
+		total += dc.Cost
+	}
+	return total
+}
+
+// splitWeeklyCosts: 先々週から先週までを日単位で集計したレスポンスを、日付を基に先週と先々週の利用コストに振り分ける
+//
+// 期間内に Cost Explorer の結果が存在しない日は利用コストを0として扱い、どちらの週にも含まれない日は無視する
+func splitWeeklyCosts(output *cost_explorer.GetCostAndUsageOutput, metric, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate string) (WeeklyCosts, error) {
+	costs, err := sumDailyTotals(output, metric)
+	if err != nil {
+		return WeeklyCosts{}, err
+	}
+
+	lastWeek, err := dailyCostSeries(costs, lastWeekStartDate, lastWeekEndDate)
+	if err != nil {
+		return WeeklyCosts{}, err
+	}
+
+	weekBeforeLast, err := dailyCostSeries(costs, weekBeforeLastStartDate, weekBeforeLastEndDate)
+	if err != nil {
+		return WeeklyCosts{}, err
+	}
+
+	return WeeklyCosts{
+		LastWeek:       lastWeek,
+		WeekBeforeLast: weekBeforeLast,
+	}, nil
+}
+
+// sumDailyTotals: 日単位で集計したレスポンスから、利用日 (YYYY-MM-DD) ごとの利用コストを集計
+func sumDailyTotals(output *cost_explorer.GetCostAndUsageOutput, metric string) (map[string]float64, error) {
+	costs := make(map[string]float64)
+	for _, result := range output.ResultsByTime {
+		if result.TimePeriod == nil {
+			continue
+		}
+		cost, ok := result.Total[metric]
+		if !ok || cost.Amount == nil {
+			continue
+		}
+		amount, err := strconv.ParseFloat(*cost.Amount, 64)
+		if err != nil {
+			return nil, err
+		}
+		costs[aws.ToString(result.TimePeriod.Start)] += amount
+	}
+	return costs, nil
+}
+
+// dailyCostSeries: 開始日付から終了日付の前日までの日ごとの利用コストを生成
+func dailyCostSeries(costs map[string]float64, startDate, endDate string) ([]DailyCost, error) {
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return nil, err
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]DailyCost, 0)
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		series = append(series, DailyCost{Date: date, Cost: costs[date]})
+	}
+	return series, nil
+}
+
+// formatWeeklyDailyCostTable: 先週と先々週の同じ曜日の利用コストを表として整形
+func formatWeeklyDailyCostTable(currency string, dailyCosts []WeeklyDailyCost) string {
+	var sb strings.Builder
+	sb.WriteString("```\n")
+	sb.WriteString(fmt.Sprintf("%s  %12s  %s  %12s  %10s\n", padRight("先週", 5), costHeader("", currency), padRight("先々週", 5), costHeader("", currency), "増減 (%)"))
+	for _, dc := range dailyCosts {
+		change := "-"
+		if dc.WeekBeforeLastCost != 0 {
+			change = fmt.Sprintf("%.2f", dc.PercentageChange)
+		}
+		sb.WriteString(fmt.Sprintf("%-5s  %12s  %-5s  %12s  %10s\n",
+			shortDate(dc.LastWeekDate), formatAmount(currency, dc.LastWeekCost), shortDate(dc.WeekBeforeLastDate), formatAmount(currency, dc.WeekBeforeLastCost), change,
+		))
+	}
+	sb.WriteString("```\n")
+	return sb.String()
+}
+
+// shortDate: 表に表示するため、日付 (YYYY-MM-DD) を月日 (MM-DD) に短縮
+func shortDate(date string) string {
+	if len(date) == len("2006-01-02") {
+		return date[len("2006-"):]
+	}
+	return date
+}
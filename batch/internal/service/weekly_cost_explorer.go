@@ -2,92 +2,58 @@ package service
 
 import (
 	"context"
-	"fmt"
-	"strconv"
 
 	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
 	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
 )
 
 type IWeeklyCostExplorerClient interface {
-	GetLastWeekCost(ctx context.Context, lastWeekStartDate, lastWeekEndDate string) (float64, error)
-	GetWeekBeforeLastCost(ctx context.Context, weekBeforeLastStartDate, weekBeforeLastEndDate string) (float64, error)
+	GetWeeklyCosts(ctx context.Context, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate string) (WeeklyCosts, error)
 	CalcPercentageChange(ctx context.Context, lastWeekCost, weekBeforeLastCost float64) (float64, error)
+	GetAccountCosts(ctx context.Context, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate string) ([]WeeklyAccountCost, error)
+	GetTagCosts(ctx context.Context, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate string) ([]WeeklyTagCostTable, error)
 }
 
 var _ IWeeklyCostExplorerClient = (*WeeklyCostExplorerService)(nil)
 
 type WeeklyCostExplorerService struct {
-	client *cost_explorer.Client
+	query        *CostQuery
+	accountNames map[string]string
+	tagKeys      []string
 }
 
-func NewWeeklyCostExplorerService(client *cost_explorer.Client) *WeeklyCostExplorerService {
-	return &WeeklyCostExplorerService{client: client}
-}
-
-// getLastWeekCost: 先週の利用コストを取得
-func (s *WeeklyCostExplorerService) GetLastWeekCost(ctx context.Context, lastWeekStartDate, lastWeekEndDate string) (float64, error) {
-
-	output, err := s.client.GetCostAndUsage(ctx, &cost_explorer.GetCostAndUsageInput{
-		TimePeriod: &types.DateInterval{
-			Start: &lastWeekStartDate,
-			End:   &lastWeekEndDate,
-		},
-		Metrics:     []string{"UnblendedCost"},
-		Granularity: types.GranularityDaily,
-	})
-	if err != nil {
-		return 0, err
-	}
-
-	totalCost := 0.0
-	for _, result := range output.ResultsByTime {
-		if cost, ok := result.Total["UnblendedCost"]; ok {
-			amount, err := s.parseFloat(*cost.Amount)
-			if err != nil {
-				return 0, err
-			}
-			totalCost += amount
-		}
+func NewWeeklyCostExplorerService(query *CostQuery, accountNames map[string]string, tagKeys []string) *WeeklyCostExplorerService {
+	return &WeeklyCostExplorerService{
+		query:        query,
+		accountNames: accountNames,
+		tagKeys:      tagKeys,
 	}
-
-	return totalCost, nil
 }
 
-// getWeekBeforeLastCost: 先々週の利用コストを取得
-func (s *WeeklyCostExplorerService) GetWeekBeforeLastCost(ctx context.Context, weekBeforeLastStartDate, weekBeforeLastEndDate string) (float64, error) {
-
-	output, err := s.client.GetCostAndUsage(ctx, &cost_explorer.GetCostAndUsageInput{
+// GetWeeklyCosts: 先々週の開始日から先週の終了日までの利用コストを1回の問い合わせで日単位に取得し、先週と先々週の日ごとの利用コストに振り分ける
+func (s *WeeklyCostExplorerService) GetWeeklyCosts(ctx context.Context, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate string) (WeeklyCosts, error) {
+	output, err := s.query.getCostAndUsage(ctx, &cost_explorer.GetCostAndUsageInput{
 		TimePeriod: &types.DateInterval{
 			Start: &weekBeforeLastStartDate,
-			End:   &weekBeforeLastEndDate,
+			End:   &lastWeekEndDate,
 		},
-		Metrics:     []string{"UnblendedCost"},
 		Granularity: types.GranularityDaily,
+		Metrics:     []string{s.query.metric.String()},
 	})
 	if err != nil {
-		return 0, err
-	}
-
-	totalCost := 0.0
-	for _, result := range output.ResultsByTime {
-		if cost, ok := result.Total["UnblendedCost"]; ok {
-			amount, err := strconv.ParseFloat(*cost.Amount, 64)
-			if err != nil {
-				return 0, err
-			}
-			totalCost += amount
-		}
+		return WeeklyCosts{}, err
 	}
 
-	return totalCost, nil
+	return splitWeeklyCosts(output, s.query.metric.String(), lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate)
 }
 
 // calcPercentageChange: コストの増減率を算出
+//
+// 先々週のコストが0の場合は比較できないため、ジョブを失敗させずに増減率を0とする (レポートでは "-" と表示する)
 func (s *WeeklyCostExplorerService) CalcPercentageChange(ctx context.Context, lastWeekCost, weekBeforeLastCost float64) (float64, error) {
 
 	if weekBeforeLastCost == 0 {
-		return 0, fmt.Errorf("week before last cost is zero")
+		return 0, nil
 	}
 
 	change := (lastWeekCost / weekBeforeLastCost) * 100
@@ -95,6 +61,38 @@ func (s *WeeklyCostExplorerService) CalcPercentageChange(ctx context.Context, la
 	return change, nil
 }
 
-func (s *WeeklyCostExplorerService) parseFloat(value string) (float64, error) {
-	return strconv.ParseFloat(value, 64)
+// GetAccountCosts: 先々週の開始日から先週の終了日までの利用コストを1回の問い合わせで連結アカウント単位・日単位に取得し、アカウントごとに先週と先々週の利用コストと増減率を算出
+func (s *WeeklyCostExplorerService) GetAccountCosts(ctx context.Context, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate string) ([]WeeklyAccountCost, error) {
+	output, err := s.query.getGroupedCostAndUsage(ctx, weekBeforeLastStartDate, lastWeekEndDate, types.GranularityDaily, groupByLinkedAccount())
+	if err != nil {
+		return nil, err
+	}
+
+	lastWeek, weekBeforeLast, err := splitWeeklyGroupCosts(output, s.query.metric.String(), lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	names := resolveAccountNames(s.accountNames, output)
+	return toWeeklyAccountCosts(mergeWeeklyGroupCosts(lastWeek, weekBeforeLast), names), nil
+}
+
+// GetTagCosts: 設定したコスト配分タグのキーごとに、先々週の開始日から先週の終了日までの利用コストを1回の問い合わせでタグの値単位・日単位に取得し、先週と先々週の利用コストと増減率を算出
+func (s *WeeklyCostExplorerService) GetTagCosts(ctx context.Context, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate string) ([]WeeklyTagCostTable, error) {
+	tables := make([]WeeklyTagCostTable, 0, len(s.tagKeys))
+	for _, tagKey := range s.tagKeys {
+		output, err := s.query.getGroupedCostAndUsage(ctx, weekBeforeLastStartDate, lastWeekEndDate, types.GranularityDaily, groupByTag(tagKey))
+		if err != nil {
+			return nil, err
+		}
+
+		lastWeek, weekBeforeLast, err := splitWeeklyGroupCosts(output, s.query.metric.String(), lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate)
+		if err != nil {
+			return nil, err
+		}
+
+		tables = append(tables, toWeeklyTagCostTable(tagKey, mergeWeeklyGroupCosts(lastWeek, weekBeforeLast)))
+	}
+
+	return tables, nil
 }
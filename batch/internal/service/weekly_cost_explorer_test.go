@@ -8,10 +8,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
 	service_mock "github.com/tamaco489/cost_explorer/batch/internal/service/mock"
 )
 
-func TestGetLastWeekCost(t *testing.T) {
+func TestGetWeeklyCosts(t *testing.T) {
 	ctx := context.Background()
 
 	ctrl := gomock.NewController(t)
@@ -20,20 +21,55 @@ func TestGetLastWeekCost(t *testing.T) {
 	// MockのSlackClientを生成
 	mockClient := service_mock.NewMockIWeeklyCostExplorerClient(ctrl)
 
-	// GetLastWeekCost を実行するための引数を定義
+	// GetWeeklyCosts を実行するための引数を定義
 	execTime := time.Now()
 	lastWeekStartDate := execTime.AddDate(0, 0, -1).Format("2006-01-02")
 	lastWeekEndDate := execTime.Format("2006-01-02")
+	weekBeforeLastStartDate := execTime.AddDate(0, 0, -8).Format("2006-01-02")
+	weekBeforeLastEndDate := execTime.AddDate(0, 0, -7).Format("2006-01-02")
 
-	// GetLastWeekCost を実行した結果得られるレスポンスを定義
-	var expectedResponse float64 = 100
+	// GetWeeklyCosts を実行した結果得られるレスポンスを定義
+	expectedResponse := service.WeeklyCosts{
+		LastWeek:       []service.DailyCost{{Date: lastWeekStartDate, Cost: 100}},
+		WeekBeforeLast: []service.DailyCost{{Date: weekBeforeLastStartDate, Cost: 80}},
+	}
 
 	mockClient.EXPECT().
-		GetLastWeekCost(ctx, lastWeekStartDate, lastWeekEndDate).
+		GetWeeklyCosts(ctx, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate).
 		Return(expectedResponse, nil).
 		Times(1)
 
-	response, err := mockClient.GetLastWeekCost(ctx, lastWeekStartDate, lastWeekEndDate)
+	response, err := mockClient.GetWeeklyCosts(ctx, lastWeekStartDate, lastWeekEndDate, weekBeforeLastStartDate, weekBeforeLastEndDate)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedResponse, response)
 }
+
+func TestCalcWeeklyPercentageChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		lastCost float64
+		baseCost float64
+		want     float64
+	}{
+		{
+			name:     "正常系: 先々週のコストに対する先週のコストの割合を算出すること",
+			lastCost: 150,
+			baseCost: 100,
+			want:     150,
+		},
+		{
+			name:     "正常系: 先々週のコストが0の場合はエラーを返さずに0を返すこと",
+			lastCost: 150,
+			baseCost: 0,
+			want:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change, err := (&service.WeeklyCostExplorerService{}).CalcPercentageChange(context.Background(), tt.lastCost, tt.baseCost)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, change)
+		})
+	}
+}
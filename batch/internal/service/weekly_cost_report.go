@@ -11,25 +11,146 @@ type WeeklyCostUsage struct {
 	LastWeekCost       float64
 	WeekBeforeLastCost float64
 	PercentageChange   float64
+
+	Metric       CostMetric   // 集計した利用コストの指標
+	Currency     string       // 利用コストの通貨
+	ExchangeRate ExchangeRate // 利用コストの変換に使用した為替レート (変換前は空)
+
+	LastWeekPeriod       Period // 先週の集計期間
+	WeekBeforeLastPeriod Period // 先々週の集計期間
+
+	WeekBeforeLastExchangeRate ExchangeRate // 先々週の利用コストの変換に使用した為替レート (先週と同じ為替レートを適用した場合は空)
+
+	DailyCosts   []WeeklyDailyCost // 先週と先々週の同じ曜日の利用コスト
+	AccountCosts []WeeklyAccountCost
+	TagCosts     []WeeklyTagCostTable
+
+	Conversions map[string]*WeeklyCostUsage // 他の表示する通貨に変換した利用コスト (通貨コードごと、Currency の利用コストは含まない)
 }
 
 // newWeeklyCostUsage: WeeklyCostUsage のコンストラクタ
-func (wcs *WeeklyCostExplorerService) NewWeeklyCostUsage(lastWeekCost, weekBeforeLastCost, percentageChange float64) *WeeklyCostUsage {
+func (wcs *WeeklyCostExplorerService) NewWeeklyCostUsage(fd WeeklyReportDateFormatter, weeklyCosts WeeklyCosts, percentageChange float64, accountCosts []WeeklyAccountCost, tagCosts []WeeklyTagCostTable) *WeeklyCostUsage {
 	return &WeeklyCostUsage{
-		LastWeekCost:       lastWeekCost,
-		WeekBeforeLastCost: weekBeforeLastCost,
+		LastWeekCost:       weeklyCosts.LastWeekTotal(),
+		WeekBeforeLastCost: weeklyCosts.WeekBeforeLastTotal(),
 		PercentageChange:   percentageChange,
+		DailyCosts:         weeklyCosts.CompareByDay(),
+		AccountCosts:       accountCosts,
+		TagCosts:           tagCosts,
+		Metric:             wcs.query.Metric(),
+
+		Currency:             wcs.query.Currency(),
+		LastWeekPeriod:       Period{Start: fd.LastWeekStartDate, End: fd.LastWeekEndDate},
+		WeekBeforeLastPeriod: Period{Start: fd.WeekBeforeLastStartDate, End: fd.WeekBeforeLastEndDate},
+	}
+}
+
+// exchangeRates: 利用コストの変換に使用した為替レートを取得 (集計期間ごとに異なる為替レートを適用した場合は先週、先々週の順)
+func (wcu *WeeklyCostUsage) exchangeRates() []ExchangeRate {
+	if wcu.WeekBeforeLastExchangeRate.Currency == "" {
+		return []ExchangeRate{wcu.ExchangeRate}
 	}
+	return []ExchangeRate{wcu.ExchangeRate, wcu.WeekBeforeLastExchangeRate}
 }
 
 // genSlackMessage: 週次利用コストレポートのメッセージを生成
 func (wcu *WeeklyCostUsage) GenWeeklySlackMessage() slack.Attachment {
+	// 先々週のコストが0の場合は比較できないため "-" と表示する
+	change := "-"
+	if wcu.WeekBeforeLastCost != 0 {
+		change = fmt.Sprintf("%.2f %%", wcu.PercentageChange)
+	}
+
+	pretext := fmt.Sprintf(`
+• 先週の利用コスト: %s
+• 先々週の利用コスト: %s
+• 先々週のコストに対する先週のコスト: %s`,
+		formatCost(wcu.Currency, wcu.LastWeekCost), formatCost(wcu.Currency, wcu.WeekBeforeLastCost), change,
+	)
+
+	if len(wcu.DailyCosts) > 0 {
+		pretext += fmt.Sprintf("\n• 日別の利用コスト:\n%s", formatWeeklyDailyCostTable(wcu.Currency, wcu.DailyCosts))
+	}
+
+	// 連結アカウントが複数存在する場合のみ、アカウント別の内訳を表示
+	if len(wcu.AccountCosts) > 1 {
+		pretext += fmt.Sprintf("\n• アカウント別の利用コスト:\n%s", formatWeeklyAccountCosts(wcu.Currency, wcu.AccountCosts))
+	}
+
+	for _, table := range wcu.TagCosts {
+		pretext += fmt.Sprintf("\n• タグ別の利用コスト (%s):\n%s", table.TagKey, formatWeeklyTagCostTable(wcu.Currency, table))
+	}
+
 	return slack.Attachment{
-		Pretext: fmt.Sprintf(`
-• 先週の利用コスト: %.2f 円
-• 先々週の利用コスト: %.2f 円
-• 先々週のコストに対する先週のコスト: %.2f %%`,
-			wcu.LastWeekCost, wcu.WeekBeforeLastCost, wcu.PercentageChange,
-		),
+		Pretext: pretext,
+		Footer:  reportFooter(wcu.Metric, wcu.exchangeRates()...),
+	}
+}
+
+// GenWeeklySlackBlocks: 週次利用コストレポートを Block Kit のメッセージとして生成
+//
+// 先々週のコストに対する先週のコストが閾値（%）以上の場合は、全体・アカウント別・タグ別に赤色のバーで強調表示する
+func (wcu *WeeklyCostUsage) GenWeeklySlackBlocks(alertThreshold float64) slack.BlockMessage {
+	message := slack.BlockMessage{
+		Blocks: []slack.Block{
+			slack.HeaderBlock("AWS 週次利用コストレポート"),
+			slack.FieldsBlock(
+				slack.Field{Label: "先週の利用コスト", Value: formatCost(wcu.Currency, wcu.LastWeekCost)},
+				slack.Field{Label: "先々週の利用コスト", Value: formatCost(wcu.Currency, wcu.WeekBeforeLastCost)},
+				slack.Field{Label: "先々週のコストに対する先週のコスト", Value: formatChange(wcu.PercentageChange, wcu.WeekBeforeLastCost)},
+			),
+			slack.ContextBlock(reportFooter(wcu.Metric, wcu.exchangeRates()...)),
+		},
+	}
+
+	if len(wcu.DailyCosts) > 0 {
+		message.Details = append(message.Details, []slack.Block{
+			slack.TableBlock("日別の利用コスト", weeklyDailyCostTable(wcu.Currency, wcu.DailyCosts)),
+		})
+	}
+
+	// 連結アカウントが複数存在する場合のみ、アカウント別の内訳を表示
+	if len(wcu.AccountCosts) > 1 {
+		message.Details = append(message.Details, []slack.Block{
+			slack.TableBlock("アカウント別の利用コスト", weeklyAccountCostTable(wcu.Currency, wcu.AccountCosts)),
+		})
+	}
+
+	if len(wcu.TagCosts) > 0 {
+		tagBlocks := make([]slack.Block, 0, len(wcu.TagCosts))
+		for _, table := range wcu.TagCosts {
+			tagBlocks = append(tagBlocks, slack.TableBlock(fmt.Sprintf("タグ別の利用コスト (%s)", table.TagKey), weeklyTagCostTable(wcu.Currency, table)))
+		}
+		message.Details = append(message.Details, tagBlocks)
+	}
+
+	if lines := wcu.exceededLines(alertThreshold); len(lines) > 0 {
+		message.Highlights = append(message.Highlights, thresholdHighlight(
+			fmt.Sprintf("先々週のコストに対する先週のコストが %.0f %% を超えています", alertThreshold),
+			lines,
+		))
+	}
+
+	return message
+}
+
+// exceededLines: 先々週のコストに対する先週のコストが閾値（%）以上の項目を列挙
+func (wcu *WeeklyCostUsage) exceededLines(alertThreshold float64) []string {
+	lines := make([]string, 0)
+	if exceedsThreshold(wcu.PercentageChange, wcu.WeekBeforeLastCost, alertThreshold) {
+		lines = append(lines, fmt.Sprintf("全体: %s → %s (%.2f %%)", formatCost(wcu.Currency, wcu.WeekBeforeLastCost), formatCost(wcu.Currency, wcu.LastWeekCost), wcu.PercentageChange))
+	}
+	for _, ac := range wcu.AccountCosts {
+		if exceedsThreshold(ac.PercentageChange, ac.WeekBeforeLastCost, alertThreshold) {
+			lines = append(lines, fmt.Sprintf("%s: %s → %s (%.2f %%)", formatAccountLabel(ac.AccountID, ac.AccountName), formatCost(wcu.Currency, ac.WeekBeforeLastCost), formatCost(wcu.Currency, ac.LastWeekCost), ac.PercentageChange))
+		}
+	}
+	for _, table := range wcu.TagCosts {
+		for _, tc := range table.Costs {
+			if exceedsThreshold(tc.PercentageChange, tc.WeekBeforeLastCost, alertThreshold) {
+				lines = append(lines, fmt.Sprintf("%s=%s: %s → %s (%.2f %%)", table.TagKey, tc.TagValue, formatCost(wcu.Currency, tc.WeekBeforeLastCost), formatCost(wcu.Currency, tc.LastWeekCost), tc.PercentageChange))
+			}
+		}
 	}
+	return lines
 }
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeeklyCostExplorerService_GetWeeklyCosts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 1回の問い合わせで取得した日ごとの利用コストを先週と先々週に振り分けること", func(t *testing.T) {
+		stub := &stubCostExplorerAPI{
+			pages: map[string]*cost_explorer.GetCostAndUsageOutput{
+				"": {
+					ResultsByTime: []types.ResultByTime{
+						totalResult("2024-12-12", "1"),
+						totalResult("2024-12-13", "2"),
+						totalResult("2024-12-14", "9"), // どちらの週にも含まれない日
+						totalResult("2024-12-15", "3"),
+						totalResult("2024-12-16", "4"),
+					},
+				},
+			},
+		}
+		query, err := newCostQuery(stub, CostMetricUnblended, CostFilter{})
+		assert.NoError(t, err)
+
+		ws := NewWeeklyCostExplorerService(query, nil, nil)
+		result, err := ws.GetWeeklyCosts(ctx, "2024-12-15", "2024-12-18", "2024-12-12", "2024-12-14")
+		assert.NoError(t, err)
+
+		assert.Len(t, stub.inputs, 1)
+		assert.Equal(t, "2024-12-12", *stub.inputs[0].TimePeriod.Start)
+		assert.Equal(t, "2024-12-18", *stub.inputs[0].TimePeriod.End)
+		assert.Equal(t, types.GranularityDaily, stub.inputs[0].Granularity)
+
+		assert.Equal(t, []DailyCost{
+			{Date: "2024-12-15", Cost: 3},
+			{Date: "2024-12-16", Cost: 4},
+			{Date: "2024-12-17", Cost: 0},
+		}, result.LastWeek)
+		assert.Equal(t, []DailyCost{
+			{Date: "2024-12-12", Cost: 1},
+			{Date: "2024-12-13", Cost: 2},
+		}, result.WeekBeforeLast)
+		assert.Equal(t, 7.0, result.LastWeekTotal())
+		assert.Equal(t, 3.0, result.WeekBeforeLastTotal())
+	})
+}
+
+func TestWeeklyCostExplorerService_GetAccountCosts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 1回の問い合わせで取得した連結アカウントごとの利用コストを先週と先々週に振り分けること", func(t *testing.T) {
+		stub := &stubCostExplorerAPI{
+			pages: map[string]*cost_explorer.GetCostAndUsageOutput{
+				"": {
+					ResultsByTime: []types.ResultByTime{
+						groupResult("2024-12-12", map[string]string{"111111111111": "4", "222222222222": "1"}),
+						groupResult("2024-12-14", map[string]string{"111111111111": "9"}), // どちらの週にも含まれない日
+						groupResult("2024-12-15", map[string]string{"111111111111": "3"}),
+						groupResult("2024-12-16", map[string]string{"111111111111": "3", "333333333333": "2"}),
+					},
+					DimensionValueAttributes: []types.DimensionValuesWithAttributes{
+						{Value: aws.String("111111111111"), Attributes: map[string]string{"description": "production"}},
+					},
+				},
+			},
+		}
+		query, err := newCostQuery(stub, CostMetricUnblended, CostFilter{})
+		assert.NoError(t, err)
+
+		ws := NewWeeklyCostExplorerService(query, nil, nil)
+		result, err := ws.GetAccountCosts(ctx, "2024-12-15", "2024-12-18", "2024-12-12", "2024-12-14")
+		assert.NoError(t, err)
+
+		assert.Len(t, stub.inputs, 1)
+		assert.Equal(t, "2024-12-12", *stub.inputs[0].TimePeriod.Start)
+		assert.Equal(t, "2024-12-18", *stub.inputs[0].TimePeriod.End)
+		assert.Equal(t, types.GranularityDaily, stub.inputs[0].Granularity)
+
+		assert.Equal(t, []WeeklyAccountCost{
+			{AccountID: "111111111111", AccountName: "production", LastWeekCost: 6, WeekBeforeLastCost: 4, PercentageChange: 150},
+			{AccountID: "333333333333", AccountName: "333333333333", LastWeekCost: 2},
+			{AccountID: "222222222222", AccountName: "222222222222", WeekBeforeLastCost: 1},
+		}, result)
+	})
+}
+
+func TestWeeklyCostExplorerService_GetTagCosts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: タグのキーごとに1回の問い合わせで取得した利用コストを先週と先々週に振り分けること", func(t *testing.T) {
+		stub := &stubCostExplorerAPI{
+			pages: map[string]*cost_explorer.GetCostAndUsageOutput{
+				"": {
+					ResultsByTime: []types.ResultByTime{
+						groupResult("2024-12-13", map[string]string{"team$platform": "5"}),
+						groupResult("2024-12-17", map[string]string{"team$platform": "10"}),
+					},
+				},
+			},
+		}
+		query, err := newCostQuery(stub, CostMetricUnblended, CostFilter{})
+		assert.NoError(t, err)
+
+		ws := NewWeeklyCostExplorerService(query, nil, []string{"team", "env"})
+		result, err := ws.GetTagCosts(ctx, "2024-12-15", "2024-12-18", "2024-12-12", "2024-12-14")
+		assert.NoError(t, err)
+
+		assert.Len(t, stub.inputs, 2)
+		for _, input := range stub.inputs {
+			assert.Equal(t, "2024-12-12", *input.TimePeriod.Start)
+			assert.Equal(t, "2024-12-18", *input.TimePeriod.End)
+		}
+
+		if assert.Len(t, result, 2) {
+			assert.Equal(t, "team", result[0].TagKey)
+			assert.Equal(t, []WeeklyTagCost{
+				{TagValue: "platform", LastWeekCost: 10, WeekBeforeLastCost: 5, PercentageChange: 200},
+				{TagValue: "untagged"},
+			}, result[0].Costs)
+		}
+	})
+}
+
+func groupResult(start string, amounts map[string]string) types.ResultByTime {
+	result := types.ResultByTime{TimePeriod: &types.DateInterval{Start: aws.String(start)}}
+	for key, amount := range amounts {
+		result.Groups = append(result.Groups, types.Group{
+			Keys:    []string{key},
+			Metrics: map[string]types.MetricValue{"UnblendedCost": {Amount: aws.String(amount)}},
+		})
+	}
+	return result
+}
+
+func TestWeeklyCosts_CompareByDay(t *testing.T) {
+	wc := WeeklyCosts{
+		LastWeek:       []DailyCost{{Date: "2024-12-19", Cost: 3}, {Date: "2024-12-20", Cost: 5}},
+		WeekBeforeLast: []DailyCost{{Date: "2024-12-12", Cost: 2}, {Date: "2024-12-13", Cost: 0}},
+	}
+
+	assert.Equal(t, []WeeklyDailyCost{
+		{LastWeekDate: "2024-12-19", LastWeekCost: 3, WeekBeforeLastDate: "2024-12-12", WeekBeforeLastCost: 2, PercentageChange: 150},
+		{LastWeekDate: "2024-12-20", LastWeekCost: 5, WeekBeforeLastDate: "2024-12-13", WeekBeforeLastCost: 0, PercentageChange: 0},
+	}, wc.CompareByDay())
+}
@@ -7,7 +7,8 @@ import (
 
 	"github.com/tamaco489/cost_explorer/batch/internal/configuration"
 	"github.com/tamaco489/cost_explorer/batch/internal/library/debug_log"
-	"github.com/tamaco489/cost_explorer/batch/internal/library/slack"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/exchange_rates"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
 )
 
 func (j *Job) DailyCostReport(ctx context.Context) error {
@@ -29,57 +30,78 @@ func (j *Job) DailyCostReport(ctx context.Context) error {
 		debug_log.FormatDateForDailyReportLogs(ctx, fd)
 	}
 
-	// ************************* 2. AWS 利用コストの算出 *************************
-	yesterdayCost, err := j.dailyCostExplorerService.GetYesterdayCost(ctx, fd.Yesterday, fd.EndDate)
-	if err != nil {
-		return fmt.Errorf("failed to get yesterday cost: %w", err)
-	}
-
-	actualCost, err := j.dailyCostExplorerService.GetActualCost(ctx, fd.StartDate, fd.EndDate)
-	if err != nil {
-		return fmt.Errorf("failed to get actual cost: %w", err)
-	}
-
-	forecastCost, err := j.dailyCostExplorerService.GetForecastCost(ctx, actualCost, fd.CurrentDay, fd.DaysInMonth)
-	if err != nil {
-		return fmt.Errorf("failed to get forecast cost: %w", err)
-	}
-
-	if configuration.Get().Logging == "on" {
-		debug_log.DailyUsageCostLogs(ctx, yesterdayCost, actualCost, forecastCost)
-	}
+	// ************************* 2. AWS 利用コストと為替レートを並行して取得 *************************
+	var (
+		yesterdayCost, actualCost                 float64
+		yesterdayServiceCosts, actualServiceCosts []service.ServiceCost
+		accountCosts                              []service.DailyAccountCost
+		tagCosts                                  []service.DailyTagCostTable
+		ratesResponse                             *exchange_rates.ExchangeRatesResponse
+	)
 
-	// ************************* 3. Open Exchange Rates API を使用して、為替レートを取得 *************************
-	pxr, err := j.exchangeRatesClient.PrepareExchangeRates()
-	if err != nil {
+	qg := newQueryGroup(ctx, j.queryConcurrency)
+	qg.Go("yesterday cost", func(ctx context.Context) (err error) {
+		yesterdayCost, err = j.dailyCostExplorerService.GetYesterdayCost(ctx, fd.Yesterday, fd.EndDate)
+		return err
+	})
+	qg.Go("actual cost", func(ctx context.Context) (err error) {
+		actualCost, err = j.dailyCostExplorerService.GetActualCost(ctx, fd.StartDate, fd.EndDate)
+		return err
+	})
+	qg.Go("yesterday service costs", func(ctx context.Context) (err error) {
+		yesterdayServiceCosts, err = j.dailyCostExplorerService.GetYesterdayServiceCosts(ctx, fd.Yesterday, fd.EndDate)
+		return err
+	})
+	qg.Go("actual service costs", func(ctx context.Context) (err error) {
+		actualServiceCosts, err = j.dailyCostExplorerService.GetActualServiceCosts(ctx, fd.StartDate, fd.EndDate)
+		return err
+	})
+	qg.Go("account costs", func(ctx context.Context) (err error) {
+		accountCosts, err = j.dailyCostExplorerService.GetAccountCosts(ctx, fd.Yesterday, fd.StartDate, fd.EndDate)
+		return err
+	})
+	qg.Go("tag costs", func(ctx context.Context) (err error) {
+		tagCosts, err = j.dailyCostExplorerService.GetTagCosts(ctx, fd.Yesterday, fd.StartDate, fd.EndDate)
+		return err
+	})
+	qg.Go("exchange rates", func(ctx context.Context) (err error) {
+		ratesResponse, err = j.getExchangeRates(ctx)
+		return err
+	})
+	if err := qg.Wait(); err != nil {
 		return err
 	}
 
-	ratesResponse, err := j.exchangeRatesClient.GetExchangeRates(ctx, pxr.BaseCurrencyCode, pxr.ExchangeCurrencyCodes)
+	// 予測値は本日時点での利用コストを基に算出するため、他の問い合わせの完了後に取得する
+	forecast, err := j.dailyCostExplorerService.GetForecastCost(ctx, actualCost, fd.EndDate, fd.NextMonthStartDate, fd.CurrentDay, fd.DaysInMonth)
 	if err != nil {
-		return fmt.Errorf("failed to get exchange rates: %w", err)
+		return fmt.Errorf("failed to get forecast cost: %w", err)
 	}
 
 	if configuration.Get().Logging == "on" {
+		debug_log.DailyUsageCostLogs(ctx, yesterdayCost, actualCost, forecast.Cost)
+		debug_log.DailyForecastLogs(ctx, forecast)
+		debug_log.DailyServiceCostLogs(ctx, yesterdayServiceCosts, actualServiceCosts)
 		debug_log.ExchangeRatesResponseLogs(ctx, ratesResponse)
 	}
 
-	// ************************* 4. 取得した為替レートを利用して、利用コストをUSDからJPYに変換 *************************
-	costUsage := j.dailyCostExplorerService.NewDailyCostUsage(yesterdayCost, actualCost, forecastCost)
-	jpyUsage, err := costUsage.CalcDailyCostInJPY(ratesResponse)
+	// ************************* 3. 取得した為替レートを利用して、利用コストを利用コストの通貨 (COST_CURRENCY) から表示する通貨ごとに変換 *************************
+	costUsage := j.dailyCostExplorerService.NewDailyCostUsage(fd, yesterdayCost, actualCost, forecast, yesterdayServiceCosts, actualServiceCosts, accountCosts, tagCosts)
+	usage, conversions, err := convertCurrencies(j.currencies, func(currency string) (*service.DailyCostUsage, error) {
+		return costUsage.CalcDailyCost(ratesResponse, currency)
+	})
 	if err != nil {
 		return err
 	}
+	usage.Conversions = conversions
 
 	if configuration.Get().Logging == "on" {
-		debug_log.DailyParseJPYCostLogs(ctx, jpyUsage.YesterdayCost, jpyUsage.ActualCost, jpyUsage.ForecastCost)
+		debug_log.DailyConvertedCostLogs(ctx, usage.Currency, usage.YesterdayCost, usage.ActualCost, usage.ForecastCost)
 	}
 
-	// ************************* 5. Slackにメッセージを送信する *************************
-	message := jpyUsage.GenDailySlackMessage()
-	sc := slack.NewSlackClient(configuration.Get().Slack.DailyWebHookURL, configuration.Get().ServiceName)
-	if err := sc.SendMessage(ctx, slack.DailyReportTitle.String(), message); err != nil {
-		return fmt.Errorf("failed to send slack message: %w", err)
+	// ************************* 4. 設定した通知先にレポートを通知する *************************
+	if err := j.dailyNotifier.NotifyDaily(ctx, usage); err != nil {
+		return fmt.Errorf("failed to notify daily cost report: %w", err)
 	}
 
 	return nil
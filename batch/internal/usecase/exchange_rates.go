@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/configuration"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/exchange_rates"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// newExchangeRatesProvider: 設定した取得元の一覧から、順に試行して為替レートを取得する Provider を生成
+//
+// 取得元には USD を基軸通貨として問い合わせ、他の基軸通貨の為替レートはクロスレートで算出する
+func newExchangeRatesProvider(cfg configuration.Config) (exchange_rates.HistoricalProvider, error) {
+	if len(cfg.ExchangeRates.Providers) == 0 {
+		return nil, fmt.Errorf("no exchange rates providers are configured")
+	}
+
+	providers := make([]exchange_rates.Provider, 0, len(cfg.ExchangeRates.Providers))
+	for _, name := range cfg.ExchangeRates.Providers {
+		switch name {
+		case exchange_rates.ProviderOpenExchangeRates:
+			client, err := exchange_rates.NewExchangeClient()
+			if err != nil {
+				return nil, err
+			}
+			providers = append(providers, client)
+
+		case exchange_rates.ProviderECB:
+			providers = append(providers, exchange_rates.NewECBProvider())
+
+		case exchange_rates.ProviderStatic:
+			if len(cfg.ExchangeRates.StaticRates) == 0 {
+				return nil, fmt.Errorf("no static exchange rates are configured")
+			}
+			providers = append(providers, exchange_rates.NewStaticProvider(exchange_rates.GetBaseCurrency(), cfg.ExchangeRates.StaticRates))
+
+		default:
+			return nil, fmt.Errorf("invalid exchange rates provider: %s", name)
+		}
+	}
+
+	provider := exchange_rates.NewFallbackProvider(providers...)
+
+	store, err := newExchangeRatesCacheStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return exchange_rates.NewCrossRateProvider(provider), nil
+	}
+	return exchange_rates.NewCrossRateProvider(exchange_rates.NewCachedProvider(provider, store, cfg.ExchangeRates.CacheTTL, cfg.ExchangeRates.CacheMaxStale)), nil
+}
+
+// newExchangeRatesCacheStore: 設定した為替レートのキャッシュの保存先を生成 (キャッシュしない場合は nil)
+func newExchangeRatesCacheStore(cfg configuration.Config) (exchange_rates.CacheStore, error) {
+	switch cfg.ExchangeRates.CacheBackend {
+	case "", "none":
+		return nil, nil
+
+	case "file":
+		return exchange_rates.NewFileCacheStore(cfg.ExchangeRates.CacheDir), nil
+
+	case "s3":
+		if cfg.ExchangeRates.CacheBucket == "" {
+			return nil, fmt.Errorf("exchange rates cache bucket is required for s3 cache backend")
+		}
+		return exchange_rates.NewS3CacheStore(s3.NewFromConfig(cfg.AWSConfig), cfg.ExchangeRates.CacheBucket, cfg.ExchangeRates.CachePrefix), nil
+
+	default:
+		return nil, fmt.Errorf("invalid exchange rates cache backend: %s", cfg.ExchangeRates.CacheBackend)
+	}
+}
+
+// newReportCurrencies: 設定値から利用コストを変換して表示する通貨の一覧を生成
+//
+// 先頭の通貨をレポートの通貨とし、重要度の判定と利用コストの上限の監視に使用する
+func newReportCurrencies(cfg configuration.Config) ([]string, error) {
+	currencies := make([]string, 0, len(cfg.ExchangeRates.Currencies))
+	for _, c := range cfg.ExchangeRates.Currencies {
+		currency, err := parseCurrencyCode(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid report currency: %w", err)
+		}
+		if !slices.Contains(currencies, currency) {
+			currencies = append(currencies, currency)
+		}
+	}
+	if len(currencies) == 0 {
+		return nil, fmt.Errorf("no report currencies are configured")
+	}
+	return currencies, nil
+}
+
+// newCostCurrency: 設定値から Cost Explorer から取得する利用コストの通貨を生成
+func newCostCurrency(cfg configuration.Config) (string, error) {
+	currency, err := parseCurrencyCode(cfg.CostCurrency)
+	if err != nil {
+		return "", fmt.Errorf("invalid cost currency: %w", err)
+	}
+	return currency, nil
+}
+
+// parseCurrencyCode: 通貨コードを大文字に揃え、ISO 4217 の通貨コードかを検証
+func parseCurrencyCode(code string) (string, error) {
+	currency := strings.ToUpper(strings.TrimSpace(code))
+	if !exchange_rates.ExchangeRatesCurrencyCode(currency).Valid() {
+		return "", fmt.Errorf("%q is not an ISO 4217 currency code", code)
+	}
+	return currency, nil
+}
+
+// convertCurrencies: 表示する通貨ごとに利用コストを変換し、先頭の通貨の利用コストと他の通貨の利用コストを返す
+func convertCurrencies[T any](currencies []string, convert func(currency string) (*T, error)) (*T, map[string]*T, error) {
+	usage, err := convert(currencies[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conversions := make(map[string]*T, len(currencies)-1)
+	for _, currency := range currencies[1:] {
+		converted, err := convert(currency)
+		if err != nil {
+			return nil, nil, err
+		}
+		conversions[currency] = converted
+	}
+	return usage, conversions, nil
+}
+
+// periodDates: 集計期間に含まれる日付 (YYYY-MM-DD) の一覧を生成
+func periodDates(periods ...service.Period) ([]string, error) {
+	var dates []string
+	for _, p := range periods {
+		d, err := p.Dates()
+		if err != nil {
+			return nil, err
+		}
+		dates = append(dates, d...)
+	}
+	return dates, nil
+}
@@ -2,11 +2,15 @@ package usecase
 
 import (
 	"context"
+	"log/slog"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/tamaco489/cost_explorer/batch/internal/configuration"
 	"github.com/tamaco489/cost_explorer/batch/internal/library/exchange_rates"
 	"github.com/tamaco489/cost_explorer/batch/internal/library/timex"
+	"github.com/tamaco489/cost_explorer/batch/internal/notifier"
 	"github.com/tamaco489/cost_explorer/batch/internal/service"
 
 	cost_explorer "github.com/aws/aws-sdk-go-v2/service/costexplorer"
@@ -15,15 +19,24 @@ import (
 type Jobber interface {
 	DailyCostReport(ctx context.Context) error
 	WeeklyCostReport(ctx context.Context) error
+	MonthlyCostReport(ctx context.Context) error
 }
 
 var _ Jobber = (*Job)(nil)
 
 type Job struct {
-	execTimeJST               time.Time
-	dailyCostExplorerService  *service.DailyCostExplorerService
-	weeklyCostExplorerService *service.WeeklyCostExplorerService
-	exchangeRatesClient       *exchange_rates.ExchangeRatesClient
+	execTimeJST                time.Time
+	dailyCostExplorerService   *service.DailyCostExplorerService
+	weeklyCostExplorerService  *service.WeeklyCostExplorerService
+	monthlyCostExplorerService *service.MonthlyCostExplorerService
+	exchangeRatesProvider      exchange_rates.HistoricalProvider
+	rateMode                   service.RateMode
+	costCurrency               string
+	currencies                 []string
+	dailyNotifier              notifier.Notifier
+	weeklyNotifier             notifier.Notifier
+	monthlyNotifier            notifier.Notifier
+	queryConcurrency           int
 }
 
 func NewJob(cfg configuration.Config) (*Job, error) {
@@ -32,20 +45,147 @@ func NewJob(cfg configuration.Config) (*Job, error) {
 	execTimeJST := time.Now().In(timex.JST())
 
 	// cost explorer sdk
+	costMetric, err := service.ParseCostMetric(cfg.CostMetric)
+	if err != nil {
+		return nil, err
+	}
+
+	forecastMode, err := service.ParseForecastMode(cfg.ForecastMode)
+	if err != nil {
+		return nil, err
+	}
+
+	costCurrency, err := newCostCurrency(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	costExplorerClient := cost_explorer.NewFromConfig(cfg.AWSConfig)
-	dailyCostExplorerService := service.NewDailyCostExplorerService(costExplorerClient)
-	weeklyCostExplorerService := service.NewWeeklyCostExplorerService(costExplorerClient)
+	costQuery, err := service.NewCostQuery(costExplorerClient, costMetric, newCostFilter(cfg), costCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyCostExplorerService := service.NewDailyCostExplorerService(costQuery, cfg.AccountNames, cfg.CostAllocationTagKeys, forecastMode)
+	weeklyCostExplorerService := service.NewWeeklyCostExplorerService(costQuery, cfg.AccountNames, cfg.CostAllocationTagKeys)
+	monthlyCostExplorerService := service.NewMonthlyCostExplorerService(costQuery)
+
+	// exchange rates provider
+	exchangeRatesProvider, err := newExchangeRatesProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	rateMode, err := service.ParseRateMode(cfg.ExchangeRates.RateMode)
+	if err != nil {
+		return nil, err
+	}
+
+	currencies, err := newReportCurrencies(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// notifier
+	dailyNotifier, err := newNotifier(cfg, notifier.ReportTypeDaily, currencies)
+	if err != nil {
+		return nil, err
+	}
 
-	// open exchange rates api client
-	exchangeRatesClient, err := exchange_rates.NewExchangeClient()
+	weeklyNotifier, err := newNotifier(cfg, notifier.ReportTypeWeekly, currencies)
+	if err != nil {
+		return nil, err
+	}
+
+	monthlyNotifier, err := newNotifier(cfg, notifier.ReportTypeMonthly, currencies)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Job{
-		execTimeJST:               execTimeJST,
-		dailyCostExplorerService:  dailyCostExplorerService,
-		weeklyCostExplorerService: weeklyCostExplorerService,
-		exchangeRatesClient:       exchangeRatesClient,
+		execTimeJST:                execTimeJST,
+		dailyCostExplorerService:   dailyCostExplorerService,
+		weeklyCostExplorerService:  weeklyCostExplorerService,
+		monthlyCostExplorerService: monthlyCostExplorerService,
+		exchangeRatesProvider:      exchangeRatesProvider,
+		rateMode:                   rateMode,
+		costCurrency:               costCurrency,
+		currencies:                 currencies,
+		dailyNotifier:              dailyNotifier,
+		weeklyNotifier:             weeklyNotifier,
+		monthlyNotifier:            monthlyNotifier,
+		queryConcurrency:           cfg.QueryConcurrency,
 	}, nil
 }
+
+// getExchangeRates: 設定した取得元を順に試行して、利用コストの通貨を基軸通貨とした為替レートを取得
+func (j *Job) getExchangeRates(ctx context.Context) (*exchange_rates.ExchangeRatesResponse, error) {
+	pxr, err := exchange_rates.Prepare(j.costCurrency, j.currencies)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := j.exchangeRatesProvider.GetExchangeRates(ctx, pxr.BaseCurrencyCode, pxr.ExchangeCurrencyCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "exchange rates are retrieved", slog.String("source", res.Source), slog.Bool("stale", res.Stale))
+	return res, nil
+}
+
+// getHistoricalExchangeRates: 指定した日付 (YYYY-MM-DD) ごとの為替レートを並行して取得
+func (j *Job) getHistoricalExchangeRates(ctx context.Context, dates []string) (service.HistoricalRates, error) {
+	pxr, err := exchange_rates.Prepare(j.costCurrency, j.currencies)
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	rates := make(service.HistoricalRates, len(dates))
+
+	qg := newQueryGroup(ctx, j.queryConcurrency)
+	for _, date := range dates {
+		qg.Go("exchange rates on "+date, func(ctx context.Context) error {
+			d, err := time.Parse("2006-01-02", date)
+			if err != nil {
+				return err
+			}
+			res, err := j.exchangeRatesProvider.GetHistoricalExchangeRates(ctx, d, pxr.BaseCurrencyCode, pxr.ExchangeCurrencyCodes)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			rates[date] = res
+			return nil
+		})
+	}
+	if err := qg.Wait(); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "historical exchange rates are retrieved", slog.Int("days", len(rates)))
+	return rates, nil
+}
+
+// newCostFilter: 設定値から Cost Explorer の絞り込み条件を生成
+func newCostFilter(cfg configuration.Config) service.CostFilter {
+	return service.CostFilter{
+		IncludeRecordTypes: cfg.Filter.IncludeRecordTypes,
+		ExcludeRecordTypes: cfg.Filter.ExcludeRecordTypes,
+		IncludeDimensions:  splitFilterValues(cfg.Filter.IncludeDimensions),
+		ExcludeDimensions:  splitFilterValues(cfg.Filter.ExcludeDimensions),
+		IncludeTags:        splitFilterValues(cfg.Filter.IncludeTags),
+		ExcludeTags:        splitFilterValues(cfg.Filter.ExcludeTags),
+	}
+}
+
+// splitFilterValues: "|" で区切られた絞り込み条件の値を分割
+func splitFilterValues(filters map[string]string) map[string][]string {
+	values := make(map[string][]string, len(filters))
+	for key, value := range filters {
+		values[key] = strings.Split(value, "|")
+	}
+	return values
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/tamaco489/cost_explorer/batch/internal/configuration"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/debug_log"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/exchange_rates"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+func (j *Job) MonthlyCostReport(ctx context.Context) error {
+
+	// ************************* 1. 実行日時からコスト算出に必要な各基準日を取得 *************************
+	slog.InfoContext(ctx, "MonthlyCostReport",
+		slog.String("date (jst)", j.execTimeJST.Format("2006-01-02 15:04:05 MST")),
+	)
+
+	fd := j.monthlyCostExplorerService.NewMonthlyReportDateFormatter(j.execTimeJST)
+	if configuration.Get().Logging == "on" {
+		debug_log.FormatDateForMonthlyReportLogs(ctx, fd)
+	}
+
+	// ************************* 2. AWS 利用コストと為替レートを並行して取得 *************************
+	var (
+		lastMonthCost, monthBeforeLastCost float64
+		topServices                        []service.ServiceCost
+		ratesResponse                      *exchange_rates.ExchangeRatesResponse
+
+		dailyCosts      service.MonthlyDailyCosts
+		historicalRates service.HistoricalRates
+	)
+
+	qg := newQueryGroup(ctx, j.queryConcurrency)
+	if j.rateMode == service.RateModeLatest {
+		qg.Go("last month cost", func(ctx context.Context) (err error) {
+			lastMonthCost, err = j.monthlyCostExplorerService.GetLastMonthCost(ctx, fd.LastMonthStartDate, fd.LastMonthEndDate)
+			return err
+		})
+		qg.Go("month before last cost", func(ctx context.Context) (err error) {
+			monthBeforeLastCost, err = j.monthlyCostExplorerService.GetMonthBeforeLastCost(ctx, fd.MonthBeforeLastStartDate, fd.MonthBeforeLastEndDate)
+			return err
+		})
+	} else {
+		// 利用日ごとの為替レートで変換するため、先月と先々月の利用コストを日単位で取得し、その合計を各月の利用コストとする
+		qg.Go("monthly daily costs", func(ctx context.Context) (err error) {
+			dailyCosts, err = j.monthlyCostExplorerService.GetMonthlyDailyCosts(ctx, fd.LastMonthStartDate, fd.LastMonthEndDate, fd.MonthBeforeLastStartDate, fd.MonthBeforeLastEndDate)
+			lastMonthCost, monthBeforeLastCost = dailyCosts.LastMonthTotal(), dailyCosts.MonthBeforeLastTotal()
+			return err
+		})
+	}
+	qg.Go("top service costs", func(ctx context.Context) (err error) {
+		topServices, err = j.monthlyCostExplorerService.GetTopServiceCosts(ctx, fd.LastMonthStartDate, fd.LastMonthEndDate)
+		return err
+	})
+	qg.Go("exchange rates", func(ctx context.Context) (err error) {
+		if j.rateMode == service.RateModeLatest {
+			ratesResponse, err = j.getExchangeRates(ctx)
+			return err
+		}
+
+		// 利用日ごとの為替レートで変換するため、先月と先々月の各日の為替レートを取得
+		dates, err := periodDates(
+			service.Period{Start: fd.MonthBeforeLastStartDate, End: fd.MonthBeforeLastEndDate},
+			service.Period{Start: fd.LastMonthStartDate, End: fd.LastMonthEndDate},
+		)
+		if err != nil {
+			return err
+		}
+		historicalRates, err = j.getHistoricalExchangeRates(ctx, dates)
+		return err
+	})
+	if err := qg.Wait(); err != nil {
+		return err
+	}
+
+	percentageChange, err := j.monthlyCostExplorerService.CalcPercentageChange(ctx, lastMonthCost, monthBeforeLastCost)
+	if err != nil {
+		return err
+	}
+
+	if configuration.Get().Logging == "on" {
+		debug_log.MonthlyUsageCostLogs(ctx, lastMonthCost, monthBeforeLastCost, percentageChange, topServices)
+		if ratesResponse != nil {
+			debug_log.ExchangeRatesResponseLogs(ctx, ratesResponse)
+		}
+	}
+
+	// ************************* 3. 取得した為替レートを利用して、利用コストを利用コストの通貨 (COST_CURRENCY) から表示する通貨ごとに変換 *************************
+	costUsage := j.monthlyCostExplorerService.NewMonthlyCostUsage(fd, lastMonthCost, monthBeforeLastCost, percentageChange, topServices, dailyCosts)
+	usage, conversions, err := convertCurrencies(j.currencies, func(currency string) (*service.MonthlyCostUsage, error) {
+		if j.rateMode == service.RateModeLatest {
+			return costUsage.CalcMonthlyCost(ratesResponse, currency)
+		}
+		return costUsage.CalcMonthlyCostWithHistoricalRates(historicalRates, j.rateMode, currency)
+	})
+	if err != nil {
+		return err
+	}
+	usage.Conversions = conversions
+
+	if configuration.Get().Logging == "on" {
+		debug_log.MonthlyConvertedCostLogs(ctx, usage.Currency, usage.LastMonthCost, usage.MonthBeforeLastCost)
+	}
+
+	// ************************* 4. 設定した通知先にレポートを通知する *************************
+	if err := j.monthlyNotifier.NotifyMonthly(ctx, usage); err != nil {
+		return fmt.Errorf("failed to notify monthly cost report: %w", err)
+	}
+
+	return nil
+}
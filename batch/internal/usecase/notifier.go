@@ -0,0 +1,324 @@
+package usecase
+
+import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/tamaco489/cost_explorer/batch/internal/configuration"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/discord"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/email"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/pagerduty"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/slack"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/teams"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/webhook"
+	"github.com/tamaco489/cost_explorer/batch/internal/notifier"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+// 通知先の種類
+const (
+	notifierSlack     = "slack"
+	notifierTeams     = "teams"
+	notifierDiscord   = "discord"
+	notifierEmail     = "email"
+	notifierWebhook   = "webhook"
+	notifierPagerDuty = "pagerduty"
+)
+
+// Slack への送信方法
+const (
+	slackClientWebhook = "webhook"
+	slackClientBot     = "bot"
+)
+
+// newNotifier: 通知の振り分けルールに従って、レポートの種類ごとの Notifier を生成
+//
+// 振り分けルールが設定されていない場合は、NOTIFIERS に指定した全ての通知先に通知する。
+// currencies はレポートを変換した通貨の一覧で、通知先ごとに表示する通貨を選択する
+func newNotifier(cfg configuration.Config, reportType notifier.ReportType, currencies []string) (notifier.Notifier, error) {
+	if len(cfg.Notifiers) == 0 {
+		return nil, fmt.Errorf("no notifiers are configured")
+	}
+	for name := range cfg.NotifierCurrencies {
+		if !slices.Contains(cfg.Notifiers, name) {
+			return nil, fmt.Errorf("invalid notifier currencies: unknown notifier: %s", name)
+		}
+	}
+
+	kinds := make(map[string]string, len(cfg.Notifiers)+len(cfg.Routing.Targets)) // 通知先の名前ごとの種類
+	targets := make([]notifier.Target, 0, len(cfg.Notifiers)+len(cfg.Routing.Targets))
+	addTarget := func(name, kind, destination string, targetCurrencies []string) error {
+		if _, ok := kinds[name]; name == "" || ok {
+			return fmt.Errorf("invalid notification target name: %q", name)
+		}
+		kinds[name] = kind
+
+		selected, err := selectCurrencies(name, kind, targetCurrencies, currencies)
+		if err != nil {
+			return err
+		}
+
+		n, desc, err := newTargetNotifier(cfg, reportType, name, kind, destination)
+		if err != nil {
+			return err
+		}
+		if n != nil {
+			if len(selected) > 0 {
+				desc += fmt.Sprintf(" (currencies: %s)", strings.Join(selected, ", "))
+			}
+			targets = append(targets, notifier.Target{Name: name, Destination: desc, Notifier: notifier.WithCurrencies(n, selected)})
+		}
+		return nil
+	}
+
+	for _, name := range cfg.Notifiers {
+		var targetCurrencies []string
+		if value := cfg.NotifierCurrencies[name]; value != "" {
+			targetCurrencies = strings.Split(value, "|")
+		}
+		if err := addTarget(name, name, "", targetCurrencies); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range cfg.Routing.Targets {
+		if err := addTarget(t.Name, t.Notifier, t.Destination, t.Currencies); err != nil {
+			return nil, err
+		}
+	}
+
+	routes, err := newRoutes(cfg, kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := service.SeverityRule{
+		AlertThreshold: cfg.AlertThresholdPercent,
+		SpendLimit:     service.SpendLimit{YesterdayCost: cfg.PagerDuty.DailyCostLimit, ForecastCost: cfg.PagerDuty.ForecastCostLimit},
+	}
+
+	var opts []notifier.RouterOption
+	if cfg.Routing.DryRun {
+		opts = append(opts, notifier.WithDryRun(os.Stdout))
+	}
+
+	return notifier.NewRouter(reportType, cfg.Env, routes, targets, rule, opts...), nil
+}
+
+// selectCurrencies: 通知先に表示する通貨を検証 (未指定の場合はレポートの通貨のまま通知するため nil を返す)
+//
+// PagerDuty はレポートの通貨 (表示する通貨の先頭) で利用コストの上限を判定するため、他の通貨は選択できない
+func selectCurrencies(name, kind string, targetCurrencies, currencies []string) ([]string, error) {
+	if len(targetCurrencies) == 0 {
+		return nil, nil
+	}
+
+	selected := make([]string, 0, len(targetCurrencies))
+	for _, c := range targetCurrencies {
+		currency := strings.ToUpper(strings.TrimSpace(c))
+		if !slices.Contains(currencies, currency) {
+			return nil, fmt.Errorf("invalid currencies for notification target %s: %s is not a report currency", name, c)
+		}
+		if !slices.Contains(selected, currency) {
+			selected = append(selected, currency)
+		}
+	}
+
+	if kind == notifierPagerDuty && !slices.Equal(selected, currencies[:1]) {
+		return nil, fmt.Errorf("invalid currencies for notification target %s: pagerduty supports only the report currency %s", name, currencies[0])
+	}
+	return selected, nil
+}
+
+// newTargetNotifier: 通知先の種類と送信先から Notifier を生成
+//
+// destination が空の場合はレポートの種類ごとに設定した送信先を使用し、送信先がない場合は nil を返す
+func newTargetNotifier(cfg configuration.Config, reportType notifier.ReportType, name, kind, destination string) (notifier.Notifier, string, error) {
+	switch kind {
+	case notifierSlack:
+		client, desc, err := newSlackClient(cfg, reportType, destination)
+		if err != nil {
+			return nil, "", err
+		}
+		if cfg.SlackMessageFormat != notifier.SlackMessageFormatBlocks && cfg.SlackMessageFormat != notifier.SlackMessageFormatAttachment {
+			return nil, "", fmt.Errorf("invalid slack message format: %s", cfg.SlackMessageFormat)
+		}
+		return notifier.NewSlackNotifier(client, cfg.SlackMessageFormat, cfg.AlertThresholdPercent), desc, nil
+
+	case notifierTeams:
+		webhookURL := cmp.Or(destination, reportValue(reportType, cfg.Teams.DailyWebHookURL, cfg.Teams.WeeklyWebHookURL, cfg.Teams.MonthlyWebHookURL))
+		return notifier.NewTeamsNotifier(teams.NewTeamsClient(webhookURL), cfg.AlertThresholdPercent), maskURL(webhookURL), nil
+
+	case notifierDiscord:
+		webhookURL := cmp.Or(destination, reportValue(reportType, cfg.Discord.DailyWebHookURL, cfg.Discord.WeeklyWebHookURL, cfg.Discord.MonthlyWebHookURL))
+		return notifier.NewDiscordNotifier(discord.NewDiscordClient(webhookURL, cfg.ServiceName), cfg.AlertThresholdPercent), maskURL(webhookURL), nil
+
+	case notifierEmail:
+		recipients := reportValue(reportType, cfg.Email.DailyRecipients, cfg.Email.WeeklyRecipients, cfg.Email.MonthlyRecipients)
+		if destination != "" {
+			recipients = strings.Split(destination, ",")
+		}
+		// 宛先が設定されていないレポートはメールで通知しない
+		if len(recipients) == 0 {
+			return nil, "", nil
+		}
+		client := email.NewSMTPClient(cfg.Email.SMTPHost, cfg.Email.SMTPPort, cfg.Email.SMTPUsername, cfg.Email.SMTPPassword, cfg.Email.From)
+		return notifier.NewEmailNotifier(client, recipients, cfg.AlertThresholdPercent), strings.Join(recipients, ", "), nil
+
+	case notifierWebhook:
+		urls := cfg.Webhook.URLs
+		if destination != "" {
+			urls = []string{destination}
+		}
+		if len(urls) == 0 {
+			return nil, "", fmt.Errorf("no webhook urls are configured")
+		}
+		notifiers := make([]notifier.Notifier, 0, len(urls))
+		descs := make([]string, 0, len(urls))
+		for _, webhookURL := range urls {
+			client := webhook.NewWebhookClient(webhookURL, cfg.Webhook.Secret, webhook.WithMaxAttempts(cfg.Webhook.MaxAttempts))
+			notifiers = append(notifiers, notifier.NewWebhookNotifier(client))
+			descs = append(descs, maskURL(webhookURL))
+		}
+		return notifier.Multi(notifiers...), strings.Join(descs, ", "), nil
+
+	case notifierPagerDuty:
+		severity := pagerduty.Severity(cfg.PagerDuty.Severity)
+		if !severity.Valid() {
+			return nil, "", fmt.Errorf("invalid pagerduty severity: %s", cfg.PagerDuty.Severity)
+		}
+		limit := service.SpendLimit{YesterdayCost: cfg.PagerDuty.DailyCostLimit, ForecastCost: cfg.PagerDuty.ForecastCostLimit}
+		if !limit.Enabled() {
+			return nil, "", fmt.Errorf("no pagerduty spend limits are configured")
+		}
+		source := fmt.Sprintf("%s-%s", cfg.ServiceName, cfg.Env)
+		routingKey := cmp.Or(destination, cfg.PagerDuty.RoutingKey)
+		// 未解決のインシデントは通知先ごとに保存する
+		incidents, err := newIncidentStore(cfg, source+"/"+name)
+		if err != nil {
+			return nil, "", err
+		}
+		return notifier.NewPagerDutyNotifier(pagerduty.NewPagerDutyClient(), routingKey, source, severity, limit, incidents), fmt.Sprintf("events api v2 (source: %s)", source), nil
+
+	default:
+		return nil, "", fmt.Errorf("invalid notifier: %s", kind)
+	}
+}
+
+// newIncidentStore: 設定した保存先に、PagerDuty の未解決のインシデントを保存する IncidentStore を生成
+//
+// Lambda の /tmp は実行環境が再作成されると失われ、インシデントが解決されなくなるため、Lambda 上では file を指定できない
+func newIncidentStore(cfg configuration.Config, key string) (notifier.IncidentStore, error) {
+	switch cfg.PagerDuty.IncidentStoreBackend {
+	case "file":
+		if os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "" {
+			return nil, fmt.Errorf("file pagerduty incident store backend is not supported on lambda: use s3")
+		}
+		return notifier.NewFileIncidentStore(cfg.PagerDuty.IncidentStoreDir, key), nil
+
+	case "s3":
+		if cfg.PagerDuty.IncidentStoreBucket == "" {
+			return nil, fmt.Errorf("pagerduty incident store bucket is required for s3 incident store backend")
+		}
+		return notifier.NewS3IncidentStore(s3.NewFromConfig(cfg.AWSConfig), cfg.PagerDuty.IncidentStoreBucket, cfg.PagerDuty.IncidentStorePrefix, key), nil
+
+	default:
+		return nil, fmt.Errorf("invalid pagerduty incident store backend: %s", cfg.PagerDuty.IncidentStoreBackend)
+	}
+}
+
+// newRoutes: 設定値から通知の振り分けルールを生成
+//
+// ルールが設定されていない場合は、NOTIFIERS に指定した全ての通知先に通知するルールを返す。
+// targetKinds は通知先の名前ごとの種類で、PagerDuty は利用コストが上限を下回った日にもインシデントを解決するため重要度で絞り込むことはできない
+func newRoutes(cfg configuration.Config, targetKinds map[string]string) ([]notifier.Route, error) {
+	if len(cfg.Routing.Routes) == 0 {
+		return []notifier.Route{{Name: "default", Targets: cfg.Notifiers}}, nil
+	}
+
+	routes := make([]notifier.Route, 0, len(cfg.Routing.Routes))
+	for i, nr := range cfg.Routing.Routes {
+		route := notifier.Route{
+			Name:     cmp.Or(nr.Name, fmt.Sprintf("route-%d", i+1)),
+			Envs:     nr.Envs,
+			Accounts: nr.Accounts,
+			Tags:     nr.Tags,
+			Targets:  nr.Targets,
+		}
+
+		for _, r := range nr.Reports {
+			reportType, err := notifier.ParseReportType(r)
+			if err != nil {
+				return nil, fmt.Errorf("invalid notification route %s: %w", route.Name, err)
+			}
+			route.Reports = append(route.Reports, reportType)
+		}
+		for _, s := range nr.Severities {
+			severity, err := service.ParseSeverity(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid notification route %s: %w", route.Name, err)
+			}
+			route.Severities = append(route.Severities, severity)
+		}
+
+		if len(nr.Targets) == 0 {
+			return nil, fmt.Errorf("invalid notification route %s: no targets are configured", route.Name)
+		}
+		for _, name := range nr.Targets {
+			kind, ok := targetKinds[name]
+			if !ok {
+				return nil, fmt.Errorf("invalid notification route %s: unknown target: %s", route.Name, name)
+			}
+			if kind == notifierPagerDuty && len(route.Severities) > 0 {
+				return nil, fmt.Errorf("invalid notification route %s: pagerduty target %s cannot be filtered by severity", route.Name, name)
+			}
+		}
+
+		routes = append(routes, route)
+	}
+
+	return routes, nil
+}
+
+// maskURL: ログに表示するため、Webhook URL をホスト名までに省略 (パスに認証情報を含むため)
+func maskURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "(invalid url)"
+	}
+	return fmt.Sprintf("%s://%s/...", u.Scheme, u.Host)
+}
+
+// newSlackClient: 設定した送信方法に応じて Slack クライアントを生成
+//
+// bot の場合はレポートごとのチャンネルIDに、webhook の場合はレポートごとの Webhook URL に送信する (destination を指定した場合はその送信先)
+func newSlackClient(cfg configuration.Config, reportType notifier.ReportType, destination string) (slack.ISlackClient, string, error) {
+	switch cfg.Slack.Client {
+	case slackClientWebhook:
+		webhookURL := cmp.Or(destination, reportValue(reportType, cfg.Slack.DailyWebHookURL, cfg.Slack.WeeklyWebHookURL, cfg.Slack.MonthlyWebHookURL))
+		return slack.NewSlackClient(webhookURL, cfg.ServiceName), maskURL(webhookURL), nil
+
+	case slackClientBot:
+		channelID := cmp.Or(destination, reportValue(reportType, cfg.Slack.DailyChannelID, cfg.Slack.WeeklyChannelID, cfg.Slack.MonthlyChannelID))
+		return slack.NewBotClient(cfg.Slack.BotToken, channelID, cfg.ServiceName), "channel " + channelID, nil
+
+	default:
+		return nil, "", fmt.Errorf("invalid slack client: %s", cfg.Slack.Client)
+	}
+}
+
+// reportValue: レポートの種類に対応する設定値を選択
+func reportValue[T any](reportType notifier.ReportType, daily, weekly, monthly T) T {
+	switch reportType {
+	case notifier.ReportTypeWeekly:
+		return weekly
+	case notifier.ReportTypeMonthly:
+		return monthly
+	default:
+		return daily
+	}
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tamaco489/cost_explorer/batch/internal/configuration"
+	"github.com/tamaco489/cost_explorer/batch/internal/notifier"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
+)
+
+func TestNewRoutes(t *testing.T) {
+	t.Run("正常系: 振り分けルールが未設定の場合は全ての通知先に通知するルールを生成すること", func(t *testing.T) {
+		var cfg configuration.Config
+		cfg.Notifiers = []string{"slack", "email"}
+
+		routes, err := newRoutes(cfg, map[string]string{"slack": "slack", "email": "email"})
+		assert.NoError(t, err)
+		assert.Equal(t, []notifier.Route{{Name: "default", Targets: []string{"slack", "email"}}}, routes)
+	})
+
+	t.Run("正常系: 設定値のレポートの種類と重要度を変換すること", func(t *testing.T) {
+		var cfg configuration.Config
+		cfg.Routing.Routes = configuration.NotificationRoutes{
+			{Reports: []string{"daily"}, Severities: []string{"warn", "critical"}, Targets: []string{"platform-slack"}},
+		}
+
+		routes, err := newRoutes(cfg, map[string]string{"slack": "slack", "platform-slack": "slack"})
+		assert.NoError(t, err)
+		assert.Equal(t, []notifier.Route{{
+			Name:       "route-1",
+			Reports:    []notifier.ReportType{notifier.ReportTypeDaily},
+			Severities: []service.Severity{service.SeverityWarn, service.SeverityCritical},
+			Targets:    []string{"platform-slack"},
+		}}, routes)
+	})
+
+	tests := []struct {
+		name  string
+		route configuration.NotificationRoute
+		want  string
+	}{
+		{
+			name:  "異常系: 定義されていない通知先を指定した場合はエラーを返すこと",
+			route: configuration.NotificationRoute{Name: "r", Targets: []string{"teams"}},
+			want:  "unknown target: teams",
+		},
+		{
+			name:  "異常系: 通知先を指定していない場合はエラーを返すこと",
+			route: configuration.NotificationRoute{Name: "r"},
+			want:  "no targets are configured",
+		},
+		{
+			name:  "異常系: 不正な重要度を指定した場合はエラーを返すこと",
+			route: configuration.NotificationRoute{Name: "r", Severities: []string{"warning"}, Targets: []string{"slack"}},
+			want:  "invalid severity: warning",
+		},
+		{
+			name:  "異常系: 不正なレポートの種類を指定した場合はエラーを返すこと",
+			route: configuration.NotificationRoute{Name: "r", Reports: []string{"yearly"}, Targets: []string{"slack"}},
+			want:  "invalid report type: yearly",
+		},
+		{
+			name:  "異常系: pagerduty を重要度で絞り込むルールを指定した場合はエラーを返すこと",
+			route: configuration.NotificationRoute{Name: "r", Severities: []string{"critical"}, Targets: []string{"slack", "oncall"}},
+			want:  "pagerduty target oncall cannot be filtered by severity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg configuration.Config
+			cfg.Routing.Routes = configuration.NotificationRoutes{tt.route}
+
+			_, err := newRoutes(cfg, map[string]string{"slack": "slack", "oncall": "pagerduty"})
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestNewIncidentStore(t *testing.T) {
+	var cfg configuration.Config
+	cfg.PagerDuty.IncidentStoreBackend = "file"
+	cfg.PagerDuty.IncidentStoreDir = t.TempDir()
+
+	t.Run("正常系: Lambda 以外では file を指定できること", func(t *testing.T) {
+		t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "")
+
+		store, err := newIncidentStore(cfg, "cost-explorer-dev/pagerduty")
+		assert.NoError(t, err)
+		assert.NotNil(t, store)
+	})
+
+	t.Run("異常系: Lambda 上で file を指定した場合はエラーを返すこと", func(t *testing.T) {
+		t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "cost-explorer-dev")
+
+		_, err := newIncidentStore(cfg, "cost-explorer-dev/pagerduty")
+		assert.ErrorContains(t, err, "not supported on lambda")
+	})
+
+	t.Run("異常系: s3 でバケットを指定していない場合はエラーを返すこと", func(t *testing.T) {
+		cfg := cfg
+		cfg.PagerDuty.IncidentStoreBackend = "s3"
+
+		_, err := newIncidentStore(cfg, "cost-explorer-dev/pagerduty")
+		assert.ErrorContains(t, err, "bucket is required")
+	})
+}
+
+func TestSelectCurrencies(t *testing.T) {
+	currencies := []string{"JPY", "EUR", "USD"}
+
+	tests := []struct {
+		name    string
+		kind    string
+		target  []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "正常系: 通貨を指定しない場合はレポートの通貨のまま通知するため nil を返すこと",
+			kind: "slack",
+		},
+		{
+			name:   "正常系: 通貨コードを大文字に揃えて重複を除くこと",
+			kind:   "email",
+			target: []string{"eur", "USD", "EUR"},
+			want:   []string{"EUR", "USD"},
+		},
+		{
+			name:    "異常系: 表示する通貨に含まれない通貨を指定した場合はエラーを返すこと",
+			kind:    "slack",
+			target:  []string{"GBP"},
+			wantErr: "GBP is not a report currency",
+		},
+		{
+			name:    "異常系: pagerduty にレポートの通貨以外を指定した場合はエラーを返すこと",
+			kind:    "pagerduty",
+			target:  []string{"EUR"},
+			wantErr: "pagerduty supports only the report currency JPY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := selectCurrencies("target", tt.kind, tt.target, currencies)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, selected)
+		})
+	}
+}
+
+func TestNewReportCurrencies(t *testing.T) {
+	var cfg configuration.Config
+	cfg.ExchangeRates.Currencies = []string{"jpy", " EUR", "JPY"}
+
+	currencies, err := newReportCurrencies(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"JPY", "EUR"}, currencies)
+
+	cfg.ExchangeRates.Currencies = []string{"YEN!"}
+	_, err = newReportCurrencies(cfg)
+	assert.ErrorContains(t, err, "invalid report currency")
+
+	cfg.ExchangeRates.Currencies = []string{"JPY", "XYZ"}
+	_, err = newReportCurrencies(cfg)
+	assert.ErrorContains(t, err, `"XYZ" is not an ISO 4217 currency code`)
+
+	cfg.ExchangeRates.Currencies = nil
+	_, err = newReportCurrencies(cfg)
+	assert.ErrorContains(t, err, "no report currencies are configured")
+}
+
+func TestNewCostCurrency(t *testing.T) {
+	var cfg configuration.Config
+	cfg.CostCurrency = "eur"
+
+	currency, err := newCostCurrency(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", currency)
+
+	cfg.CostCurrency = ""
+	_, err = newCostCurrency(cfg)
+	assert.ErrorContains(t, err, "invalid cost currency")
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultQueryConcurrency: 1つのジョブ内で同時に実行する問い合わせ数の既定値
+const defaultQueryConcurrency = 4
+
+// queryGroup: 互いに依存しない問い合わせを並行して実行するグループ
+//
+// 同時実行数を制限し、いずれかの問い合わせが失敗した時点で残りの問い合わせをキャンセルする
+type queryGroup struct {
+	ctx   context.Context
+	group *errgroup.Group
+}
+
+// newQueryGroup: queryGroup のコンストラクタ (limit が 0 以下の場合は既定値を利用)
+func newQueryGroup(ctx context.Context, limit int) *queryGroup {
+	if limit <= 0 {
+		limit = defaultQueryConcurrency
+	}
+
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(limit)
+
+	return &queryGroup{
+		ctx:   ctx,
+		group: group,
+	}
+}
+
+// Go: 問い合わせを実行し、失敗した場合は問い合わせ名を含むエラーを返す
+//
+// 他の問い合わせの失敗によってキャンセルされた場合はログを出力しない
+func (qg *queryGroup) Go(name string, query func(ctx context.Context) error) {
+	qg.group.Go(func() error {
+		if err := query(qg.ctx); err != nil {
+			canceled := errors.Is(err, context.Canceled) && qg.ctx.Err() != nil
+			if !canceled {
+				slog.ErrorContext(qg.ctx, "query failed",
+					slog.String("query", name),
+					slog.String("error", err.Error()),
+				)
+			}
+			return fmt.Errorf("failed to get %s: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// Wait: 全ての問い合わせの完了を待ち、最初に失敗した問い合わせのエラーを返す
+func (qg *queryGroup) Wait() error {
+	return qg.group.Wait()
+}
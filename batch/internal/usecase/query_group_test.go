@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryGroup(t *testing.T) {
+	t.Run("正常系: 同時実行数を上限以下に制限して全ての問い合わせを実行すること", func(t *testing.T) {
+		var running, peak, done atomic.Int32
+		qg := newQueryGroup(context.Background(), 2)
+		for i := 0; i < 6; i++ {
+			qg.Go("query", func(ctx context.Context) error {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				done.Add(1)
+				return nil
+			})
+		}
+
+		assert.NoError(t, qg.Wait())
+		assert.Equal(t, int32(6), done.Load())
+		assert.LessOrEqual(t, peak.Load(), int32(2))
+	})
+
+	t.Run("異常系: 失敗した問い合わせ名を含むエラーを返し、残りの問い合わせをキャンセルすること", func(t *testing.T) {
+		errQuery := errors.New("throttling")
+		qg := newQueryGroup(context.Background(), 2)
+		qg.Go("actual cost", func(ctx context.Context) error {
+			return errQuery
+		})
+		qg.Go("tag costs", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		err := qg.Wait()
+		assert.ErrorIs(t, err, errQuery)
+		assert.EqualError(t, err, "failed to get actual cost: throttling")
+	})
+}
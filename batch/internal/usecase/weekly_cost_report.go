@@ -7,7 +7,8 @@ import (
 
 	"github.com/tamaco489/cost_explorer/batch/internal/configuration"
 	"github.com/tamaco489/cost_explorer/batch/internal/library/debug_log"
-	"github.com/tamaco489/cost_explorer/batch/internal/library/slack"
+	"github.com/tamaco489/cost_explorer/batch/internal/library/exchange_rates"
+	"github.com/tamaco489/cost_explorer/batch/internal/service"
 )
 
 func (j *Job) WeeklyCostReport(ctx context.Context) error {
@@ -22,57 +23,83 @@ func (j *Job) WeeklyCostReport(ctx context.Context) error {
 		debug_log.FormatDateForWeeklyReportLogs(ctx, fd)
 	}
 
-	// ************************* 2. AWS 利用コストの算出 *************************
-	lastWeekCost, err := j.weeklyCostExplorerService.GetLastWeekCost(ctx, fd.LastWeekStartDate, fd.LastWeekEndDate)
-	if err != nil {
-		return fmt.Errorf("failed to get last week cost: %w", err)
-	}
+	// ************************* 2. AWS 利用コストと為替レートを並行して取得 *************************
+	var (
+		weeklyCosts   service.WeeklyCosts
+		accountCosts  []service.WeeklyAccountCost
+		tagCosts      []service.WeeklyTagCostTable
+		ratesResponse *exchange_rates.ExchangeRatesResponse
 
-	weekBeforeLastCost, err := j.weeklyCostExplorerService.GetWeekBeforeLastCost(ctx, fd.WeekBeforeLastStartDate, fd.WeekBeforeLastEndDate)
-	if err != nil {
-		return fmt.Errorf("failed to get week before last cost: %w", err)
-	}
+		historicalRates service.HistoricalRates
+	)
 
-	percentageChange, err := j.weeklyCostExplorerService.CalcPercentageChange(ctx, lastWeekCost, weekBeforeLastCost)
-	if err != nil {
+	qg := newQueryGroup(ctx, j.queryConcurrency)
+	qg.Go("weekly costs", func(ctx context.Context) (err error) {
+		weeklyCosts, err = j.weeklyCostExplorerService.GetWeeklyCosts(ctx, fd.LastWeekStartDate, fd.LastWeekEndDate, fd.WeekBeforeLastStartDate, fd.WeekBeforeLastEndDate)
 		return err
-	}
-
-	if configuration.Get().Logging == "on" {
-		debug_log.WeeklyUsageCostLogs(ctx, lastWeekCost, weekBeforeLastCost, percentageChange)
-	}
-
-	// ************************* 3. Open Exchange Rates API を使用して、為替レートを取得 *************************
-	pxr, err := j.exchangeRatesClient.PrepareExchangeRates()
-	if err != nil {
+	})
+	qg.Go("account costs", func(ctx context.Context) (err error) {
+		accountCosts, err = j.weeklyCostExplorerService.GetAccountCosts(ctx, fd.LastWeekStartDate, fd.LastWeekEndDate, fd.WeekBeforeLastStartDate, fd.WeekBeforeLastEndDate)
+		return err
+	})
+	qg.Go("tag costs", func(ctx context.Context) (err error) {
+		tagCosts, err = j.weeklyCostExplorerService.GetTagCosts(ctx, fd.LastWeekStartDate, fd.LastWeekEndDate, fd.WeekBeforeLastStartDate, fd.WeekBeforeLastEndDate)
+		return err
+	})
+	qg.Go("exchange rates", func(ctx context.Context) (err error) {
+		if j.rateMode == service.RateModeLatest {
+			ratesResponse, err = j.getExchangeRates(ctx)
+			return err
+		}
+
+		// 利用日ごとの為替レートで変換するため、先週と先々週の各日の為替レートを取得
+		dates, err := periodDates(
+			service.Period{Start: fd.WeekBeforeLastStartDate, End: fd.WeekBeforeLastEndDate},
+			service.Period{Start: fd.LastWeekStartDate, End: fd.LastWeekEndDate},
+		)
+		if err != nil {
+			return err
+		}
+		historicalRates, err = j.getHistoricalExchangeRates(ctx, dates)
+		return err
+	})
+	if err := qg.Wait(); err != nil {
 		return err
 	}
 
-	ratesResponse, err := j.exchangeRatesClient.GetExchangeRates(ctx, pxr.BaseCurrencyCode, pxr.ExchangeCurrencyCodes)
+	lastWeekCost, weekBeforeLastCost := weeklyCosts.LastWeekTotal(), weeklyCosts.WeekBeforeLastTotal()
+	percentageChange, err := j.weeklyCostExplorerService.CalcPercentageChange(ctx, lastWeekCost, weekBeforeLastCost)
 	if err != nil {
-		return fmt.Errorf("failed to get exchange rates: %w", err)
+		return err
 	}
 
 	if configuration.Get().Logging == "on" {
-		debug_log.ExchangeRatesResponseLogs(ctx, ratesResponse)
+		debug_log.WeeklyUsageCostLogs(ctx, lastWeekCost, weekBeforeLastCost, percentageChange)
+		if ratesResponse != nil {
+			debug_log.ExchangeRatesResponseLogs(ctx, ratesResponse)
+		}
 	}
 
-	// ************************* 4. 取得した為替レートを利用して、利用コストをUSDからJPYに変換 *************************
-	costUsage := j.weeklyCostExplorerService.NewWeeklyCostUsage(lastWeekCost, weekBeforeLastCost, percentageChange)
-	jpyUsage, err := costUsage.CalcWeeklyCostInJPY(ratesResponse)
+	// ************************* 3. 取得した為替レートを利用して、利用コストを利用コストの通貨 (COST_CURRENCY) から表示する通貨ごとに変換 *************************
+	costUsage := j.weeklyCostExplorerService.NewWeeklyCostUsage(fd, weeklyCosts, percentageChange, accountCosts, tagCosts)
+	usage, conversions, err := convertCurrencies(j.currencies, func(currency string) (*service.WeeklyCostUsage, error) {
+		if j.rateMode == service.RateModeLatest {
+			return costUsage.CalcWeeklyCost(ratesResponse, currency)
+		}
+		return costUsage.CalcWeeklyCostWithHistoricalRates(historicalRates, j.rateMode, currency)
+	})
 	if err != nil {
 		return err
 	}
+	usage.Conversions = conversions
 
 	if configuration.Get().Logging == "on" {
-		debug_log.WeeklyParseJPYCostLogs(ctx, jpyUsage.LastWeekCost, jpyUsage.WeekBeforeLastCost)
+		debug_log.WeeklyConvertedCostLogs(ctx, usage.Currency, usage.LastWeekCost, usage.WeekBeforeLastCost)
 	}
 
-	// ************************* 5. Slackにメッセージを送信する *************************
-	message := jpyUsage.GenWeeklySlackMessage()
-	sc := slack.NewSlackClient(configuration.Get().Slack.WeeklyWebHookURL, configuration.Get().ServiceName)
-	if err := sc.SendMessage(ctx, slack.WeeklyReportTitle.String(), message); err != nil {
-		return fmt.Errorf("failed to send slack message: %w", err)
+	// ************************* 4. 設定した通知先にレポートを通知する *************************
+	if err := j.weeklyNotifier.NotifyWeekly(ctx, usage); err != nil {
+		return fmt.Errorf("failed to notify weekly cost report: %w", err)
 	}
 
 	return nil
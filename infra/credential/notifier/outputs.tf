@@ -0,0 +1,6 @@
+output "notifier_config" {
+  value = {
+    name = aws_secretsmanager_secret.notifier_config.name
+    arn  = aws_secretsmanager_secret.notifier_config.arn
+  }
+}
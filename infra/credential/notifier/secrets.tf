@@ -0,0 +1,10 @@
+resource "aws_secretsmanager_secret" "notifier_config" {
+  name        = "${var.product}/${var.env}/notifier/config"
+  description = "manage confidential information on notifiers other than slack"
+}
+
+# NOTE: 一度リソースのみダミー文字列で作成して、AWSマネジメントコンソール上で直接編集する
+resource "aws_secretsmanager_secret_version" "notifier_config_secret" {
+  secret_id     = aws_secretsmanager_secret.notifier_config.id
+  secret_string = jsonencode(var.notifier_config)
+}
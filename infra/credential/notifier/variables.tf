@@ -0,0 +1,37 @@
+variable "env" {
+  description = "environment name"
+  type        = string
+  default     = "dev"
+}
+
+variable "product" {
+  description = "product name"
+  type        = string
+  default     = "cost-explorer"
+}
+
+variable "region" {
+  description = "region name"
+  type        = string
+  default     = "ap-northeast-1"
+}
+
+locals {
+  fqn = "${var.env}-${var.product}"
+}
+
+variable "notifier_config" {
+  type = map(string)
+  default = {
+    teams_daily_webhook_url = "https://<tenant>.webhook.office.com/webhookb2/<webhook-url>"
+    teams_weekly_webhook_url = "https://<tenant>.webhook.office.com/webhookb2/<webhook-url>"
+    teams_monthly_webhook_url = "https://<tenant>.webhook.office.com/webhookb2/<webhook-url>"
+    discord_daily_webhook_url = "https://discord.com/api/webhooks/<webhook-url>"
+    discord_weekly_webhook_url = "https://discord.com/api/webhooks/<webhook-url>"
+    discord_monthly_webhook_url = "https://discord.com/api/webhooks/<webhook-url>"
+    smtp_username = "<smtp-username>"
+    smtp_password = "<smtp-password>"
+    webhook_secret = "<webhook-secret>"
+    pagerduty_routing_key = "<pagerduty-routing-key>"
+  }
+}
@@ -25,5 +25,7 @@ variable "slack_config" {
   default = {
     daily_webhook_url = "https://hooks.slack.com/services/<webhook-url>"
     weekly_webhook_url = "https://hooks.slack.com/services/<webhook-url>"
+    monthly_webhook_url = "https://hooks.slack.com/services/<webhook-url>"
+    bot_token = "xoxb-<bot-token>"
   }
 }
@@ -21,3 +21,7 @@ data "aws_secretsmanager_secret" "slack_config" {
 data "aws_secretsmanager_secret" "exchange_rates_app_id" {
   name = "${var.product}/${var.env}/exchange-rates/app-id"
 }
+
+data "aws_secretsmanager_secret" "notifier_config" {
+  name = "${var.product}/${var.env}/notifier/config"
+}
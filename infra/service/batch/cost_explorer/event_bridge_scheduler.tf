@@ -51,3 +51,30 @@ resource "aws_scheduler_schedule" "weekly_report" {
     })
   }
 }
+
+resource "aws_scheduler_schedule" "monthly_report" {
+  name        = "${local.fqn}-monthly-report"
+  description = "毎月3日AM09:15に月次集計レポートを送信 (前月分のコストが確定するまで待つ)"
+  group_name  = "default"
+
+  flexible_time_window {
+    mode = "OFF"
+  }
+
+  state = "ENABLED"
+
+  schedule_expression          = "cron(15 9 3 * ? *)"
+  schedule_expression_timezone = "Asia/Tokyo"
+
+  target {
+    arn      = aws_lambda_function.cost_explorer.arn
+    role_arn = aws_iam_role.evnetbridge_scheduler.arn
+    retry_policy {
+      maximum_retry_attempts = 0
+    }
+
+    input = jsonencode({
+      "type" = "monthlyCostReport"
+    })
+  }
+}
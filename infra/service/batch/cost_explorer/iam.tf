@@ -45,16 +45,41 @@ data "aws_iam_policy_document" "cost_explorer" {
     ]
     resources = [
       data.aws_secretsmanager_secret.slack_config.arn,
-      data.aws_secretsmanager_secret.exchange_rates_app_id.arn
+      data.aws_secretsmanager_secret.exchange_rates_app_id.arn,
+      data.aws_secretsmanager_secret.notifier_config.arn
     ]
   }
   statement {
     effect = "Allow"
     actions = [
-      "ce:GetCostAndUsage"
+      "ce:GetCostAndUsage",
+      "ce:GetCostForecast"
     ]
     resources = ["*"]
   }
+
+  # DOC: s3:ListBucket がない場合、存在しないオブジェクトの取得は 404 ではなく 403 となり未保存と判別できない
+  statement {
+    effect = "Allow"
+    actions = [
+      "s3:ListBucket"
+    ]
+    resources = [
+      aws_s3_bucket.exchange_rates_cache.arn,
+      aws_s3_bucket.pagerduty_incidents.arn
+    ]
+  }
+  statement {
+    effect = "Allow"
+    actions = [
+      "s3:GetObject",
+      "s3:PutObject"
+    ]
+    resources = [
+      "${aws_s3_bucket.exchange_rates_cache.arn}/exchange_rates/cache/*",
+      "${aws_s3_bucket.pagerduty_incidents.arn}/pagerduty/incidents/*"
+    ]
+  }
 }
 
 resource "aws_iam_role_policy_attachment" "cost_explorer_logs" {
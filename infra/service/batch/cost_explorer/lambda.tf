@@ -13,9 +13,25 @@ resource "aws_lambda_function" "cost_explorer" {
 
   environment {
     variables = {
-      SERVICE_NAME = "cost-explorer"
-      API_ENV      = "dev"
-      LOGGING      = "off"
+      SERVICE_NAME                 = "cost-explorer"
+      API_ENV                      = "dev"
+      LOGGING                      = "off"
+      FORECAST_MODE                = "api"
+      COST_METRIC                  = "UnblendedCost"
+      COST_CURRENCY                = "USD"
+      FILTER_EXCLUDE_RECORD_TYPES  = "Credit,Refund"
+      SLACK_CLIENT                 = "webhook"
+      NOTIFIERS                    = "slack"
+      ROUTING_DRY_RUN              = "false"
+      EXCHANGE_RATES_PROVIDERS     = "openexchangerates,ecb"
+      EXCHANGE_RATES_RATE_MODE     = "daily"
+      EXCHANGE_RATES_CURRENCIES    = "JPY"
+      EXCHANGE_RATES_CACHE_BACKEND = "s3"
+      EXCHANGE_RATES_CACHE_BUCKET  = aws_s3_bucket.exchange_rates_cache.bucket
+
+      # Lambda の /tmp は実行をまたいで保持されないため、PagerDuty の未解決のインシデントは S3 に保存する
+      PAGERDUTY_INCIDENT_STORE_BACKEND = "s3"
+      PAGERDUTY_INCIDENT_STORE_BUCKET  = aws_s3_bucket.pagerduty_incidents.bucket
     }
   }
 
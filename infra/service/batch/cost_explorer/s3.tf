@@ -0,0 +1,55 @@
+# =================================================================
+# exchange rates cache
+# =================================================================
+# Open Exchange Rates のリクエスト数の上限を節約するため、取得した為替レートを実行をまたいでキャッシュする
+resource "aws_s3_bucket" "exchange_rates_cache" {
+  bucket = "${local.fqn}-exchange-rates-cache"
+
+  tags = {
+    Name = "${local.fqn}-exchange-rates-cache"
+  }
+}
+
+resource "aws_s3_bucket_public_access_block" "exchange_rates_cache" {
+  bucket                  = aws_s3_bucket.exchange_rates_cache.id
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}
+
+resource "aws_s3_bucket_lifecycle_configuration" "exchange_rates_cache" {
+  bucket = aws_s3_bucket.exchange_rates_cache.id
+
+  # 過去の日付の為替レートは週次・月次レポートで参照する期間を過ぎると不要になる
+  rule {
+    id     = "expire-exchange-rates-cache"
+    status = "Enabled"
+    filter {
+      prefix = "exchange_rates/cache/"
+    }
+    expiration {
+      days = 90
+    }
+  }
+}
+
+# =================================================================
+# pagerduty incidents
+# =================================================================
+# Lambda の /tmp は実行をまたいで保持されないため、PagerDuty の未解決のインシデントを保存する
+resource "aws_s3_bucket" "pagerduty_incidents" {
+  bucket = "${local.fqn}-pagerduty-incidents"
+
+  tags = {
+    Name = "${local.fqn}-pagerduty-incidents"
+  }
+}
+
+resource "aws_s3_bucket_public_access_block" "pagerduty_incidents" {
+  bucket                  = aws_s3_bucket.pagerduty_incidents.id
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}